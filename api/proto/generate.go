@@ -0,0 +1,4 @@
+// Package proto hold .proto definitions of grpc api, generated code is in transactionpb
+package proto
+
+//go:generate protoc --go_out=transactionpb --go_opt=paths=source_relative --go-grpc_out=transactionpb --go-grpc_opt=paths=source_relative transaction.proto
@@ -0,0 +1,176 @@
+package monolithic
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/composite"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	userusecase "money_forward_code_challenge/internal/domain/transaction/usecase/user"
+	"money_forward_code_challenge/internal/infrastructure/data-provider/mysql"
+	"money_forward_code_challenge/internal/infrastructure/data-provider/redis"
+	"net/http"
+	"strconv"
+)
+
+type AccountHandler struct {
+	routerGroup      *gin.RouterGroup
+	appServerConfig  *AppConfigServer
+	service          *TransactionService
+	overdraftService *OverdraftService
+	interestService  *InterestService
+	logger           *zap.Logger
+}
+
+func InitAccountRouter(logger *zap.Logger, routerGroup *gin.RouterGroup, appServerConfig *AppConfigServer) {
+	a := &AccountHandler{
+		routerGroup:     routerGroup,
+		appServerConfig: appServerConfig,
+		logger:          logger,
+	}
+	a.service = appServerConfig.getTransactionService()
+	a.overdraftService = appServerConfig.getOverdraftService()
+	a.interestService = appServerConfig.getInterestService()
+	a.InitRouter()
+}
+
+// getOverdraftService is shared by rest api and overdraft interest worker
+func (a *AppConfigServer) getOverdraftService() *OverdraftService {
+	if a.overdraftService != nil {
+		return a.overdraftService
+	}
+
+	overdraftRepoComposite := &composite.OverdraftRepoComposite{
+		PersistentRepo: mysql.NewMysqlOverdraftRepo(a.gormDB, a.logger),
+	}
+
+	userRepoComposite := &composite.UserRepoComposite{
+		PersistentRepo: mysql.NewMysqlUserRepo(a.gormDB, a.logger),
+		CacheRepo:      redis.NewRedisUserCacheRepo(a.redisDB, a.logger),
+	}
+
+	a.overdraftService = NewOverdraftService(overdraftRepoComposite, userRepoComposite, a.getTransactionService(), a.logger, a.config.Pool.Size)
+	return a.overdraftService
+}
+
+func (a *AccountHandler) InitRouter() {
+	a.routerGroup.GET("/:account_id", a.getAccount)
+	// request limit and rate, it need approve before usable
+	a.routerGroup.PUT("/:account_id/overdraft", a.setOverdraft)
+	// ?approved=false revoke
+	a.routerGroup.GET("/:account_id/overdraft/interest", a.getOverdraftInterest)
+	a.routerGroup.PUT("/:account_id/interest", a.assignInterestProduct)
+	// accruals and monthly postings
+	a.routerGroup.GET("/:account_id/interest", a.getInterest)
+}
+
+func (a *AccountHandler) getAccount(ginCtx *gin.Context) {
+	accountId, ok := a.bindIds(ginCtx)
+	if !ok {
+		return
+	}
+
+	response := a.service.getAccount(ginCtx, &userusecase.GetAccountByAccountIdReq{
+		AccountId: accountId,
+	})
+	writeResponse(ginCtx, response)
+}
+
+func (a *AccountHandler) setOverdraft(ginCtx *gin.Context) {
+	accountId, ok := a.bindIds(ginCtx)
+	if !ok {
+		return
+	}
+
+	var req SetOverdraftReq
+	err := ginCtx.ShouldBindJSON(&req)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := a.overdraftService.setOverdraft(ginCtx, accountId, &req)
+	writeResponse(ginCtx, response)
+}
+
+func (a *AccountHandler) getOverdraftInterest(ginCtx *gin.Context) {
+	accountId, ok := a.bindIds(ginCtx)
+	if !ok {
+		return
+	}
+
+	type QueryOption struct {
+		Limit  int `form:"limit"`
+		Offset int `form:"offset"`
+	}
+	var queryOption QueryOption
+	err := ginCtx.ShouldBindQuery(&queryOption)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := a.overdraftService.getInterestPostings(ginCtx, accountId, &repo.Query{
+		Limit:  queryOption.Limit,
+		Offset: queryOption.Offset,
+	})
+	writeResponse(ginCtx, response)
+}
+
+func (a *AccountHandler) assignInterestProduct(ginCtx *gin.Context) {
+	accountId, ok := a.bindIds(ginCtx)
+	if !ok {
+		return
+	}
+
+	var req AssignInterestProductReq
+	err := ginCtx.ShouldBindJSON(&req)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := a.interestService.assignProduct(ginCtx, accountId, &req)
+	writeResponse(ginCtx, response)
+}
+
+func (a *AccountHandler) getInterest(ginCtx *gin.Context) {
+	accountId, ok := a.bindIds(ginCtx)
+	if !ok {
+		return
+	}
+
+	type QueryOption struct {
+		Limit  int `form:"limit"`
+		Offset int `form:"offset"`
+	}
+	var queryOption QueryOption
+	err := ginCtx.ShouldBindQuery(&queryOption)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := a.interestService.getHistory(ginCtx, accountId, &repo.Query{
+		Limit:  queryOption.Limit,
+		Offset: queryOption.Offset,
+	})
+	writeResponse(ginCtx, response)
+}
+
+// bindIds parse <user_id> into context and return <account_id>, it write bad request when invalid
+func (a *AccountHandler) bindIds(ginCtx *gin.Context) (uint32, bool) {
+	userIdParam, err := getUserIdURLParam(ginCtx, "id")
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return 0, false
+	}
+
+	accountIdParam, err := strconv.Atoi(ginCtx.Param("account_id"))
+	if err != nil || accountIdParam <= 0 {
+		writeError(ginCtx, http.StatusBadRequest, "account_id must be greater than 0")
+		return 0, false
+	}
+
+	setUserIdToContext(ginCtx, userIdParam)
+	return uint32(accountIdParam), true
+}
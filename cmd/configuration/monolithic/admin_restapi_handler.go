@@ -0,0 +1,109 @@
+package monolithic
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/httpresponse"
+	overdraftusecase "money_forward_code_challenge/internal/domain/transaction/usecase/overdraft"
+	"money_forward_code_challenge/pkgs/repo_pool_async"
+	"net/http"
+	"strconv"
+)
+
+// AdminHandler is operator api, it is not scoped to a user and every route need admin token (middleware.AdminToken)
+type AdminHandler struct {
+	routerGroup     *gin.RouterGroup
+	appServerConfig *AppConfigServer
+	logger          *zap.Logger
+}
+
+func InitAdminRouter(logger *zap.Logger, routerGroup *gin.RouterGroup, appServerConfig *AppConfigServer) {
+	h := &AdminHandler{
+		routerGroup:     routerGroup,
+		appServerConfig: appServerConfig,
+		logger:          logger,
+	}
+	h.InitRouter()
+}
+
+func (h *AdminHandler) InitRouter() {
+	h.routerGroup.GET("/dead-letters", h.getDeadLetters)
+	h.routerGroup.POST("/dead-letters/:dead_letter_id/replay", h.replayDeadLetter)
+	h.routerGroup.POST("/accounts/:account_id/overdraft/approve", h.approveOverdraft)
+}
+
+// DeadLetterReplay is result of replayed dead letter, it failed again into a new dead letter when not processed
+type DeadLetterReplay struct {
+	Id     uint64 `json:"id"`
+	Result string `json:"result"`
+}
+
+// getDeadLetters list async jobs which failed every retry, oldest first
+func (h *AdminHandler) getDeadLetters(ginCtx *gin.Context) {
+	res := &httpresponse.Response{}
+	writeResponse(ginCtx, res.TransformToSuccessOk(repo_pool_async.DeadLetters.List()))
+}
+
+// replayDeadLetter run job again on its pool and wait its result
+func (h *AdminHandler) replayDeadLetter(ginCtx *gin.Context) {
+	deadLetterId, err := strconv.ParseUint(ginCtx.Param("dead_letter_id"), 10, 64)
+	if err != nil || deadLetterId == 0 {
+		writeError(ginCtx, http.StatusBadRequest, "dead_letter_id must be greater than 0")
+		return
+	}
+
+	res := &httpresponse.Response{}
+	job, err := repo_pool_async.DeadLetters.Replay(ginCtx, deadLetterId)
+	if errors.Is(err, repo_pool_async.ErrDeadLetterNotFound) {
+		writeResponse(ginCtx, res.TransformToError(exception.Wrap(exception.NotFound, "DEAD_LETTER_NOT_FOUND", err)))
+		return
+	}
+	if err != nil {
+		// pool is full or closed, letter is kept
+		writeResponse(ginCtx, res.TransformToError(exception.Wrap(exception.Unavailable, "POOL_UNAVAILABLE", err)))
+		return
+	}
+
+	err = job.Wait(ginCtx)
+	if err != nil {
+		h.logger.Warn("[AdminHandler-replayDeadLetter]", zap.Uint64("DeadLetterId", deadLetterId), zap.Error(err))
+		writeResponse(ginCtx, res.TransformToError(exception.Wrap(exception.Unavailable, "JOB_FAILED", err)))
+		return
+	}
+	writeResponse(ginCtx, res.TransformToSuccessOk(&DeadLetterReplay{
+		Id:     deadLetterId,
+		Result: "processed",
+	}))
+}
+
+// approveOverdraft of limit requested by account owner and reviewed by admin, approved=false revoke it
+func (h *AdminHandler) approveOverdraft(ginCtx *gin.Context) {
+	accountId, err := strconv.ParseUint(ginCtx.Param("account_id"), 10, 32)
+	if err != nil || accountId == 0 {
+		writeError(ginCtx, http.StatusBadRequest, "account_id must be greater than 0")
+		return
+	}
+
+	// no default, a request without decision must not approve
+	approved, err := strconv.ParseBool(ginCtx.Query("approved"))
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, "approved must be true or false")
+		return
+	}
+
+	// limit admin reviewed, approval fail when requested limit is another one now
+	limit, err := strconv.ParseFloat(ginCtx.Query("limit"), 32)
+	if approved && err != nil {
+		writeError(ginCtx, http.StatusBadRequest, "limit reviewed is required to approve")
+		return
+	}
+
+	response := h.appServerConfig.getOverdraftService().approveOverdraft(ginCtx, &overdraftusecase.ApproveOverdraftReq{
+		AccountId: uint32(accountId),
+		Limit:     float32(limit),
+		Approved:  approved,
+	})
+	writeResponse(ginCtx, response)
+}
@@ -1,36 +1,63 @@
 package monolithic
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/api/openapi"
+	"money_forward_code_challenge/internal/common/config"
+	"money_forward_code_challenge/internal/common/metrics"
+	"money_forward_code_challenge/internal/common/middleware"
+	"money_forward_code_challenge/internal/common/tracing"
+	mysqlrepo "money_forward_code_challenge/internal/infrastructure/data-provider/mysql"
+	"money_forward_code_challenge/pkgs/pubsub"
+	"money_forward_code_challenge/pkgs/repo_pool_async"
+	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 )
 
 type AppConfigServer struct {
-	logger      *zap.Logger
-	gormDB      *gorm.DB
-	redisDB     *redis.Client
-	Environment string
-	server      *gin.Engine
+	logger  *zap.Logger
+	config  *config.Config
+	gormDB  *gorm.DB
+	redisDB *redis.Client
+	server  *gin.Engine
+	// shared by rest api and background workers (scheduler)
+	transactionService *TransactionService
+	scheduleService    *ScheduleService
+	holdService        *HoldService
+	overdraftService   *OverdraftService
+	interestService    *InterestService
+	webhookService     *WebhookService
+	streamBroker       pubsub.Broker
+	// closed on http shutdown to end live streams
+	shutdownCh   chan struct{}
+	shutdownOnce sync.Once
+	closeOnce    sync.Once
 }
 
 func (a *AppConfigServer) CreateGormMysqlDB() error {
-	a.logger.Info("[AppConfigServer-CreateGormMysqlDB]", zap.String("Environment", a.Environment))
-	dbName := os.Getenv("MYSQL_DATABASE")
-	dbUser := os.Getenv("MYSQL_USER")
-	dbPass := os.Getenv("MYSQL_PASSWORD")
-	dbHost := os.Getenv("MYSQL_HOST")
-	dbPort := os.Getenv("MYSQL_PORT")
-
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local", dbUser, dbPass, dbHost, dbPort, dbName)
-
-	a.logger.Info("[AppConfigServer-CreateGormMysqlDB]", zap.String("EnvironmentMysqlAddr", dsn))
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	// dsn contain password, only address is logged
+	a.logger.Info("[AppConfigServer-CreateGormMysqlDB]", zap.String("Environment", a.config.Environment), zap.String("MysqlAddr", a.config.MySQL.Addr()))
+	db, err := gorm.Open(mysql.Open(a.config.MySQL.DSN()), &gorm.Config{})
+	if err != nil {
+		return err
+	}
+	// latency of every statement by repo method
+	err = db.Use(metrics.GormPlugin{})
+	if err != nil {
+		return err
+	}
+	// driver errors are returned as exception kinds (not found, conflict, unavailable)
+	err = db.Use(mysqlrepo.ErrorPlugin{})
 	if err != nil {
 		return err
 	}
@@ -41,97 +68,181 @@ func (a *AppConfigServer) CreateGormMysqlDB() error {
 func (a *AppConfigServer) SetLogger(logger *zap.Logger) {
 	a.logger = logger
 }
+
+func (a *AppConfigServer) SetConfig(cfg *config.Config) {
+	a.config = cfg
+}
+
+// GormDB is nil until CreateGormMysqlDB succeed, RedisDB until CreateRedisDB is called
+func (a *AppConfigServer) GormDB() *gorm.DB {
+	return a.gormDB
+}
+
+func (a *AppConfigServer) RedisDB() *redis.Client {
+	return a.redisDB
+}
+
 func (a *AppConfigServer) CreateRedisDB() error {
-	a.logger.Info("[AppConfigServer-CreateRedisDB]", zap.String("ConnectRedisDB", "Success"))
-	redisAddr := os.Getenv("REDIS_ADDR")
-	a.logger.Info("[AppConfigServer-CreateRedisDB]", zap.String("EnvironmentRedisAddr", redisAddr))
+	a.logger.Info("[AppConfigServer-CreateRedisDB]", zap.String("RedisAddr", a.config.Redis.Addr))
 	client := redis.NewClient(&redis.Options{
-		Addr:     redisAddr,
-		Password: "",
+		Addr:     a.config.Redis.Addr,
+		Password: a.config.Redis.Password.Value(),
+		DB:       a.config.Redis.DB,
 	})
+	client.AddHook(metrics.RedisHook{})
+	// client reconnect by itself, it is kept even when first ping fail
+	a.redisDB = client
 
 	_, err := client.Ping(client.Context()).Result()
 	if err != nil {
 		a.logger.Error(err.Error())
 		return err
 	}
-
-	a.redisDB = client
 	return nil
 }
 
-func (a *AppConfigServer) InitDB() {
-	err := a.gormDB.AutoMigrate(&models.User{}, &models.Account{}, &models.Transaction{})
+// InitRouter register rest api, every request is validated against openapi spec
+func (a *AppConfigServer) InitRouter() error {
+	doc, err := openapi.Load()
 	if err != nil {
-		a.logger.Error(err.Error())
+		return err
 	}
-
-	user := &models.User{
-		ID:        1,
-		FirstName: "THAI",
-		LastName:  "LE",
+	openAPIValidator, err := middleware.NewOpenAPIValidator(doc, a.logger, middleware.OpenAPIValidatorOption{
+		// response check decode every body, it only catch spec drift so prod skip it
+		ValidateResponses: a.config.Environment == config.EnvironmentDev,
+	})
+	if err != nil {
+		return err
 	}
-	err = a.gormDB.Create(user).Error
 
+	// structured access log of RequestID replace text logger of gin.Default
+	a.server = gin.New()
+	// ginCtx is passed to services as context, it must carry span and logger of request context
+	a.server.ContextWithFallback = true
+	// before validator so rejected requests are observed too
+	a.server.Use(middleware.Tracing())
+	a.server.Use(middleware.RequestID(a.logger))
+	a.server.Use(gin.Recovery())
+	a.server.Use(middleware.Metrics())
+	a.server.Use(openAPIValidator)
+	a.server.GET("/openapi.json", func(ginCtx *gin.Context) {
+		ginCtx.JSON(http.StatusOK, doc)
+	})
+	a.server.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	InitHealthRouter(a.logger, &a.server.RouterGroup, a)
+
+	apiGroup := a.server.Group("/api")
+	userGroup := apiGroup.Group("/users/:id")
+	transactionGroup := userGroup.Group("/transactions")
+	InitTransactionRouter(a.logger, transactionGroup, a)
+	InitStreamRouter(a.logger, transactionGroup, a)
+	ruleGroup := userGroup.Group("/rules")
+	InitRuleRouter(a.logger, ruleGroup, a)
+	scheduleGroup := userGroup.Group("/schedules")
+	InitScheduleRouter(a.logger, scheduleGroup, a)
+	accountGroup := userGroup.Group("/accounts")
+	InitAccountRouter(a.logger, accountGroup, a)
+	holdGroup := userGroup.Group("/holds")
+	InitHoldRouter(a.logger, holdGroup, a)
+	webhookGroup := userGroup.Group("/webhooks")
+	InitWebhookRouter(a.logger, webhookGroup, a)
+	interestProductGroup := apiGroup.Group("/interest-products")
+	InitInterestProductRouter(a.logger, interestProductGroup, a)
+	feeScheduleGroup := apiGroup.Group("/fee-schedules")
+	InitFeeScheduleRouter(a.logger, feeScheduleGroup, a)
+	// operator api, account owners must not reach it
+	adminGroup := apiGroup.Group("/admin", middleware.AdminToken(a.config.Admin.Token.Value()))
+	InitAdminRouter(a.logger, adminGroup, a)
+	return nil
+}
+
+func InitAppConfigServer() {
+	// defaults < CONFIG_FILE yaml < env (deploy/monolithic/.env.*) < flags
+	cfg, err := config.Load("transaction-service", os.Args[1:], os.LookupEnv)
 	if err != nil {
-		a.logger.Error(err.Error())
 		panic(err)
 	}
-	accounts := []*models.Account{
-		&models.Account{
-			ID:      1,
-			UserId:  user.ID,
-			Balance: 10000,
-			Bank:    "VIB",
-		},
-		&models.Account{
-			ID:      2,
-			UserId:  user.ID,
-			Balance: 500000,
-			Bank:    "ACB",
-		},
 
-		&models.Account{
-			ID:      3,
-			UserId:  user.ID,
-			Balance: 300000,
-			Bank:    "VCB",
-		},
+	zapLogger, err := newLogger(cfg.LogLevel)
+	if err != nil {
+		panic(err)
 	}
+	// secrets are redacted
+	zapLogger.Info("[AppConfigServer-Config]", zap.Any("Config", cfg))
 
-	err = a.gormDB.CreateInBatches(accounts, 1).Error
+	// spans of handlers, use cases, repos and async jobs, noop when tracing.exporter is none
+	shutdownTracing, err := tracing.Setup(cfg.Tracing, "transaction-service")
 	if err != nil {
-		a.logger.Error(err.Error())
 		panic(err)
 	}
-	a.logger.Info("[AppConfigServer-InitDB]", zap.String("InitDB", "Success"))
-}
 
-func InitAppConfigServer() {
-
-	zapLogger, err := zap.NewProduction()
+	// pools are created with use cases, bound them before
+	overflow, err := repo_pool_async.ParseOverflowPolicy(cfg.Pool.Overflow)
 	if err != nil {
 		panic(err)
 	}
+	repo_pool_async.SetDefaultOptions(repo_pool_async.Options{
+		Capacity: cfg.Pool.Capacity,
+		Overflow: overflow,
+		MaxSpill: cfg.Pool.MaxSpill,
+		// jobs are committed or aborted with sessionTx, timeout only discard jobs which are forgotten
+		CommitTimeout: cfg.Pool.CommitTimeout,
+		// cache jobs of a key set or delete whole cached value, older writes can be skipped
+		Coalesce: cfg.Pool.Coalesce,
+		Retry: repo_pool_async.RetryPolicy{
+			MaxAttempts:    cfg.Pool.Retry.MaxAttempts,
+			InitialBackoff: cfg.Pool.Retry.InitialBackoff,
+			MaxBackoff:     cfg.Pool.Retry.MaxBackoff,
+			Multiplier:     2,
+			Jitter:         cfg.Pool.Retry.Jitter,
+		},
+	})
 
-	appServerConfig := &AppConfigServer{
-		Environment: os.Getenv("ENVIRONMENT"), // from docker-compose
-	}
+	appServerConfig := &AppConfigServer{}
 	appServerConfig.SetLogger(zapLogger)
+	appServerConfig.SetConfig(cfg)
 	err = appServerConfig.CreateGormMysqlDB()
 	if err != nil {
 		panic(err)
 	}
 	err = appServerConfig.CreateRedisDB()
+	if err != nil {
+		// redis is cache and stream, server start degraded (readyz) and use it once it answer
+		zapLogger.Warn("[AppConfigServer-CreateRedisDB]", zap.String("Degraded", err.Error()))
+	}
 
+	// schema is migrated by server, demo data only when migration.seed_demo_data (SEED_DEMO_DATA=true)
+	err = appServerConfig.InitDB(context.Background(), cfg.Migration.SeedDemoData)
+	if err != nil {
+		// e.g. orphan transactions, message tell what to fix
+		zapLogger.Error("[AppConfigServer-InitDB]", zap.String("Error", err.Error()))
+		os.Exit(1)
+	}
+
+	err = appServerConfig.InitRouter()
 	if err != nil {
 		panic(err)
 	}
 
-	appServerConfig.server = gin.Default()
-	apiGroup := appServerConfig.server.Group("/api")
-	userGroup := apiGroup.Group("/users/:id")
-	transactionGroup := userGroup.Group("/transactions")
-	InitTransactionRouter(appServerConfig.logger, transactionGroup, appServerConfig)
-	appServerConfig.server.Run(":8080")
+	// SIGTERM (docker stop) and SIGINT drain in-flight requests and async jobs before exit
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	err = appServerConfig.Serve(ctx)
+	stop()
+	// flush spans of drained requests and jobs
+	flushCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	err = errors.Join(err, shutdownTracing(flushCtx))
+	cancel()
+	if err != nil {
+		appServerConfig.logger.Error("[AppConfigServer-Exit]", zap.String("Error", err.Error()))
+		os.Exit(1)
+	}
+}
+
+func newLogger(level string) (*zap.Logger, error) {
+	loggerConfig := zap.NewProductionConfig()
+	err := loggerConfig.Level.UnmarshalText([]byte(level))
+	if err != nil {
+		return nil, err
+	}
+	return loggerConfig.Build()
 }
@@ -0,0 +1,84 @@
+package monolithic
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/composite"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	feeusecase "money_forward_code_challenge/internal/domain/transaction/usecase/fee"
+	"money_forward_code_challenge/internal/infrastructure/data-provider/mysql"
+	"net/http"
+	"strconv"
+)
+
+type FeeScheduleHandler struct {
+	routerGroup     *gin.RouterGroup
+	appServerConfig *AppConfigServer
+	service         *FeeService
+	logger          *zap.Logger
+}
+
+func InitFeeScheduleRouter(logger *zap.Logger, routerGroup *gin.RouterGroup, appServerConfig *AppConfigServer) {
+	f := &FeeScheduleHandler{
+		routerGroup:     routerGroup,
+		appServerConfig: appServerConfig,
+		logger:          logger,
+	}
+	feeScheduleRepoComposite := &composite.FeeScheduleRepoComposite{
+		PersistentRepo: mysql.NewMysqlFeeScheduleRepo(appServerConfig.gormDB, logger),
+	}
+
+	f.service = NewFeeService(feeScheduleRepoComposite, logger)
+	f.InitRouter()
+}
+
+func (f *FeeScheduleHandler) InitRouter() {
+	f.routerGroup.POST("", f.createFeeSchedule)
+	f.routerGroup.GET("", f.getFeeSchedules)
+	// deactivate, schedule is kept for history of charged fees
+	f.routerGroup.DELETE("/:fee_schedule_id", f.deactivateFeeSchedule)
+}
+
+func (f *FeeScheduleHandler) createFeeSchedule(ginCtx *gin.Context) {
+	var req feeusecase.CreateFeeScheduleReq
+	err := ginCtx.ShouldBindJSON(&req)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := f.service.createFeeSchedule(ginCtx, &req)
+	writeResponse(ginCtx, response)
+}
+
+func (f *FeeScheduleHandler) getFeeSchedules(ginCtx *gin.Context) {
+	type QueryOption struct {
+		Limit  int `form:"limit"`
+		Offset int `form:"offset"`
+	}
+	var queryOption QueryOption
+	err := ginCtx.ShouldBindQuery(&queryOption)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := f.service.getFeeSchedules(ginCtx, &repo.Query{
+		Limit:  queryOption.Limit,
+		Offset: queryOption.Offset,
+	})
+	writeResponse(ginCtx, response)
+}
+
+func (f *FeeScheduleHandler) deactivateFeeSchedule(ginCtx *gin.Context) {
+	scheduleIdParam, err := strconv.Atoi(ginCtx.Param("fee_schedule_id"))
+	if err != nil || scheduleIdParam <= 0 {
+		writeError(ginCtx, http.StatusBadRequest, "fee_schedule_id must be greater than 0")
+		return
+	}
+
+	response := f.service.deactivateFeeSchedule(ginCtx, &feeusecase.DeactivateFeeScheduleReq{
+		ScheduleId: uint32(scheduleIdParam),
+	})
+	writeResponse(ginCtx, response)
+}
@@ -0,0 +1,88 @@
+package monolithic
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/composite"
+	"money_forward_code_challenge/internal/common/httpresponse"
+	"money_forward_code_challenge/internal/common/validation"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	feeusecase "money_forward_code_challenge/internal/domain/transaction/usecase/fee"
+)
+
+type FeeService struct {
+	repo struct {
+		fee *composite.FeeScheduleRepoComposite
+	}
+	useCase struct {
+		fee *composite.FeeUseCaseComposite
+	}
+	logger *zap.Logger
+}
+
+func newFeeUseCaseComposite(feeScheduleRepoComposite *composite.FeeScheduleRepoComposite, logger *zap.Logger) *composite.FeeUseCaseComposite {
+	return &composite.FeeUseCaseComposite{
+		Create:     feeusecase.NewCreateFeeScheduleUseCase(feeScheduleRepoComposite.PersistentRepo, logger),
+		GetAll:     feeusecase.NewGetFeeSchedules(feeScheduleRepoComposite.PersistentRepo, logger),
+		Deactivate: feeusecase.NewDeactivateFeeScheduleUseCase(feeScheduleRepoComposite.PersistentRepo, logger),
+		Quote:      feeusecase.NewQuoteFeeUseCase(feeScheduleRepoComposite.PersistentRepo, logger),
+	}
+}
+
+func NewFeeService(feeScheduleRepoComposite *composite.FeeScheduleRepoComposite, logger *zap.Logger) *FeeService {
+	return &FeeService{
+		logger: logger,
+		repo: struct {
+			fee *composite.FeeScheduleRepoComposite
+		}{
+			fee: feeScheduleRepoComposite,
+		},
+		useCase: struct {
+			fee *composite.FeeUseCaseComposite
+		}{
+			fee: newFeeUseCaseComposite(feeScheduleRepoComposite, logger),
+		},
+	}
+}
+
+func (f *FeeService) createFeeSchedule(ctx context.Context, req *feeusecase.CreateFeeScheduleReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+
+	err := validation.New().
+		Field("transaction_type", req.TransactionType, validation.TransactionType(models.TRANSACTIONTYPEEXPECTS)).
+		Err()
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	schedule, err := f.useCase.fee.Create.Execute(ctx, req, nil)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToCreatedSuccess(schedule)
+}
+
+func (f *FeeService) getFeeSchedules(ctx context.Context, query *repo.Query) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	schedules, err := f.useCase.fee.GetAll.Execute(ctx, &feeusecase.GetFeeSchedulesReq{
+		Query: query,
+	})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToSuccessOk(schedules)
+}
+
+// deactivateFeeSchedule stop pricing new transactions, fees already charged are kept
+func (f *FeeService) deactivateFeeSchedule(ctx context.Context, req *feeusecase.DeactivateFeeScheduleReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	err := f.useCase.fee.Deactivate.Execute(ctx, req, nil)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToUpdatedSuccess(req)
+}
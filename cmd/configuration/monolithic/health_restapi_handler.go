@@ -0,0 +1,96 @@
+package monolithic
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/pkgs/health"
+	"money_forward_code_challenge/pkgs/repo_pool_async"
+	"net/http"
+)
+
+type HealthHandler struct {
+	routerGroup     *gin.RouterGroup
+	appServerConfig *AppConfigServer
+	logger          *zap.Logger
+}
+
+// InitHealthRouter register probes of docker compose and kubernetes
+func InitHealthRouter(logger *zap.Logger, routerGroup *gin.RouterGroup, appServerConfig *AppConfigServer) {
+	h := &HealthHandler{
+		routerGroup:     routerGroup,
+		appServerConfig: appServerConfig,
+		logger:          logger,
+	}
+	h.InitRouter()
+}
+
+func (h *HealthHandler) InitRouter() {
+	h.routerGroup.GET("/healthz", h.healthz)
+	h.routerGroup.GET("/readyz", h.readyz)
+}
+
+// healthz is liveness, process answer without touching dependencies
+func (h *HealthHandler) healthz(ginCtx *gin.Context) {
+	ginCtx.JSON(http.StatusOK, &health.Report{
+		Status: health.StatusUp,
+		Checks: []*health.Result{},
+	})
+}
+
+// readyz is 503 when mysql is down or async jobs are piling up
+// redis down is only degraded, reads and writes fall back to mysql
+func (h *HealthHandler) readyz(ginCtx *gin.Context) {
+	report := health.Run(ginCtx, h.appServerConfig.config.Health.Timeout, h.appServerConfig.readinessChecks()...)
+	if !report.Ready() {
+		h.logger.Warn("[HealthHandler-readyz]", zap.Any("Report", report))
+		ginCtx.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	if report.Status == health.StatusDegraded {
+		h.logger.Warn("[HealthHandler-readyz]", zap.Any("Report", report))
+	}
+	ginCtx.JSON(http.StatusOK, report)
+}
+
+func (a *AppConfigServer) readinessChecks() []health.Check {
+	checks := []health.Check{
+		{
+			Name:     "mysql",
+			Critical: true,
+			Probe: func(ctx context.Context) error {
+				if a.gormDB == nil {
+					return fmt.Errorf("not connected")
+				}
+				sqlDB, err := a.gormDB.DB()
+				if err != nil {
+					return err
+				}
+				return sqlDB.PingContext(ctx)
+			},
+		},
+		{
+			Name:     "async_pool",
+			Critical: true,
+			Probe: func(ctx context.Context) error {
+				depth := repo_pool_async.QueueDepth()
+				if depth > a.config.Health.MaxQueueDepth {
+					return fmt.Errorf("queue depth %d is over %d", depth, a.config.Health.MaxQueueDepth)
+				}
+				return nil
+			},
+		},
+	}
+
+	checks = append(checks, health.Check{
+		Name: "redis",
+		Probe: func(ctx context.Context) error {
+			if a.redisDB == nil {
+				return fmt.Errorf("not connected")
+			}
+			return a.redisDB.Ping(ctx).Err()
+		},
+	})
+	return checks
+}
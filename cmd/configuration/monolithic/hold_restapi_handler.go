@@ -0,0 +1,162 @@
+package monolithic
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/composite"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	holdusecase "money_forward_code_challenge/internal/domain/transaction/usecase/hold"
+	"money_forward_code_challenge/internal/infrastructure/data-provider/mysql"
+	"money_forward_code_challenge/internal/infrastructure/data-provider/redis"
+	"net/http"
+	"strconv"
+)
+
+type HoldHandler struct {
+	routerGroup     *gin.RouterGroup
+	appServerConfig *AppConfigServer
+	service         *HoldService
+	logger          *zap.Logger
+}
+
+func InitHoldRouter(logger *zap.Logger, routerGroup *gin.RouterGroup, appServerConfig *AppConfigServer) {
+	h := &HoldHandler{
+		routerGroup:     routerGroup,
+		appServerConfig: appServerConfig,
+		logger:          logger,
+	}
+	h.service = appServerConfig.getHoldService()
+	h.InitRouter()
+}
+
+// getHoldService is shared by rest api and hold expiry worker
+func (a *AppConfigServer) getHoldService() *HoldService {
+	if a.holdService != nil {
+		return a.holdService
+	}
+
+	holdRepoComposite := &composite.HoldRepoComposite{
+		PersistentRepo: mysql.NewMysqlHoldRepo(a.gormDB, a.logger),
+	}
+
+	userRepoComposite := &composite.UserRepoComposite{
+		PersistentRepo: mysql.NewMysqlUserRepo(a.gormDB, a.logger),
+		CacheRepo:      redis.NewRedisUserCacheRepo(a.redisDB, a.logger),
+	}
+
+	a.holdService = NewHoldService(holdRepoComposite, userRepoComposite, a.getTransactionService(), a.logger, a.config.Pool.Size)
+	return a.holdService
+}
+
+func (h *HoldHandler) InitRouter() {
+	h.routerGroup.POST("/", h.authorizeHold)
+	h.routerGroup.GET("/", h.getHolds)
+	h.routerGroup.GET("/:hold_id", h.getHold)
+	// body {"amount": n}, empty body or 0 capture full amount
+	h.routerGroup.POST("/:hold_id/capture", h.captureHold)
+	h.routerGroup.POST("/:hold_id/void", h.voidHold)
+}
+
+func (h *HoldHandler) authorizeHold(ginCtx *gin.Context) {
+	userIdParam, err := getUserIdURLParam(ginCtx, "id")
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	setUserIdToContext(ginCtx, userIdParam)
+	var req holdusecase.AuthorizeHoldReq
+	err = ginCtx.ShouldBindJSON(&req)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := h.service.authorizeHold(ginCtx, &req)
+	writeResponse(ginCtx, response)
+}
+
+func (h *HoldHandler) getHolds(ginCtx *gin.Context) {
+	userIdParam, err := getUserIdURLParam(ginCtx, "id")
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	type QueryOption struct {
+		Limit  int `form:"limit"`
+		Offset int `form:"offset"`
+	}
+	var queryOption QueryOption
+	err = ginCtx.ShouldBindQuery(&queryOption)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	setUserIdToContext(ginCtx, userIdParam)
+	response := h.service.getHolds(ginCtx, &repo.Query{
+		Limit:  queryOption.Limit,
+		Offset: queryOption.Offset,
+	})
+	writeResponse(ginCtx, response)
+}
+
+func (h *HoldHandler) getHold(ginCtx *gin.Context) {
+	userIdParam, holdIdParam, ok := h.bindIds(ginCtx)
+	if !ok {
+		return
+	}
+
+	setUserIdToContext(ginCtx, userIdParam)
+	response := h.service.getHold(ginCtx, holdIdParam)
+	writeResponse(ginCtx, response)
+}
+
+func (h *HoldHandler) captureHold(ginCtx *gin.Context) {
+	userIdParam, holdIdParam, ok := h.bindIds(ginCtx)
+	if !ok {
+		return
+	}
+
+	var req CaptureHoldReq
+	if ginCtx.Request.ContentLength != 0 {
+		err := ginCtx.ShouldBindJSON(&req)
+		if err != nil {
+			writeError(ginCtx, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	setUserIdToContext(ginCtx, userIdParam)
+	response := h.service.captureHold(ginCtx, holdIdParam, &req)
+	writeResponse(ginCtx, response)
+}
+
+func (h *HoldHandler) voidHold(ginCtx *gin.Context) {
+	userIdParam, holdIdParam, ok := h.bindIds(ginCtx)
+	if !ok {
+		return
+	}
+
+	setUserIdToContext(ginCtx, userIdParam)
+	response := h.service.voidHold(ginCtx, holdIdParam)
+	writeResponse(ginCtx, response)
+}
+
+// bindIds parse <user_id> and <hold_id> url params, it write bad request when invalid
+func (h *HoldHandler) bindIds(ginCtx *gin.Context) (uint32, uint32, bool) {
+	userIdParam, err := getUserIdURLParam(ginCtx, "id")
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return 0, 0, false
+	}
+
+	holdIdParam, err := strconv.Atoi(ginCtx.Param("hold_id"))
+	if err != nil || holdIdParam <= 0 {
+		writeError(ginCtx, http.StatusBadRequest, "hold_id must be greater than 0")
+		return 0, 0, false
+	}
+
+	return userIdParam, uint32(holdIdParam), true
+}
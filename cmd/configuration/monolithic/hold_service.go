@@ -0,0 +1,300 @@
+package monolithic
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/composite"
+	"money_forward_code_challenge/internal/common/httpresponse"
+	"money_forward_code_challenge/internal/common/logging"
+	"money_forward_code_challenge/internal/common/validation"
+	"money_forward_code_challenge/internal/domain/transaction/aggregate"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	holdusecase "money_forward_code_challenge/internal/domain/transaction/usecase/hold"
+	"money_forward_code_challenge/internal/domain/transaction/usecase/transaction"
+	userusecase "money_forward_code_challenge/internal/domain/transaction/usecase/user"
+	"time"
+)
+
+type HoldService struct {
+	repo struct {
+		hold *composite.HoldRepoComposite
+	}
+	useCase struct {
+		user *composite.UserUseCaseComposite
+		hold *composite.HoldUseCaseComposite
+	}
+	// capture create withdraw transaction through same use case and rules as rest api
+	transactionService *TransactionService
+	logger             *zap.Logger
+}
+
+type CaptureHoldReq struct {
+	// 0 mean capture full amount of hold
+	Amount float32 `json:"amount"`
+}
+
+type CaptureHoldResult struct {
+	Hold        *models.Hold                    `json:"hold"`
+	Transaction *aggregate.TransactionByDetails `json:"transaction"`
+}
+
+func NewHoldService(holdRepoComposite *composite.HoldRepoComposite, userRepoComposite *composite.UserRepoComposite, transactionService *TransactionService, logger *zap.Logger, poolSizeWorkerUseCase int) *HoldService {
+	return &HoldService{
+		logger:             logger,
+		transactionService: transactionService,
+		repo: struct {
+			hold *composite.HoldRepoComposite
+		}{
+			hold: holdRepoComposite,
+		},
+		useCase: struct {
+			user *composite.UserUseCaseComposite
+			hold *composite.HoldUseCaseComposite
+		}{
+			user: &composite.UserUseCaseComposite{
+				GetAccountByAccountId: userusecase.NewGetAccountByAccountId(userRepoComposite.PersistentRepo, userRepoComposite.CacheRepo, logger),
+			},
+			hold: &composite.HoldUseCaseComposite{
+				Authorize:   holdusecase.NewAuthorizeHoldUseCase(holdRepoComposite.PersistentRepo, userRepoComposite.PersistentRepo, userRepoComposite.CacheRepo, logger, poolSizeWorkerUseCase),
+				Capture:     holdusecase.NewCaptureHoldUseCase(holdRepoComposite.PersistentRepo, userRepoComposite.PersistentRepo, userRepoComposite.CacheRepo, logger, poolSizeWorkerUseCase),
+				Release:     holdusecase.NewReleaseHoldUseCase(holdRepoComposite.PersistentRepo, userRepoComposite.PersistentRepo, userRepoComposite.CacheRepo, logger, poolSizeWorkerUseCase),
+				GetById:     holdusecase.NewGetHoldById(holdRepoComposite.PersistentRepo, logger),
+				GetByUserId: holdusecase.NewGetHoldsByUserId(holdRepoComposite.PersistentRepo, logger),
+				GetExpired:  holdusecase.NewGetExpiredHolds(holdRepoComposite.PersistentRepo, logger),
+			},
+		},
+	}
+}
+
+func (h *HoldService) authorizeHold(ctx context.Context, req *holdusecase.AuthorizeHoldReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	req.UserId = getUserIdFromContext(ctx)
+	req.Now = time.Now()
+
+	err := validation.New().
+		Field("amount", req.Amount, validation.Amount(models.TRANSACTIONAMOUNTMIN, models.TRANSACTIONAMOUNTMAX)).
+		Err()
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	accountDetail, err := h.useCase.user.GetAccountByAccountId.Execute(ctx, &userusecase.GetAccountByAccountIdReq{
+		AccountId: req.AccountId,
+	})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	if accountDetail.UserId != req.UserId {
+		// user account owner is not same as url param <user_id>
+		return res.TransformToError(userusecase.ErrAccountOwner)
+	}
+
+	// open session tx pointer, to control from outside
+	sessionTx := h.repo.hold.PersistentRepo.BeginTx()
+	holdModel, asyncJobInvalidateAccount, err := h.useCase.hold.Authorize.Execute(ctx, req, sessionTx)
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		return res.TransformToError(err)
+	}
+
+	err = sessionTx.Commit().Error
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		abortJobs(asyncJobInvalidateAccount)
+		return res.TransformToError(err)
+	}
+
+	defer func(ctx context.Context) {
+		asyncJobInvalidateAccount.Commit()
+	}(ctx)
+
+	return res.TransformToCreatedSuccess(holdModel)
+}
+
+// captureHold turn hold into one withdraw transaction (full or partial)
+// hold, transaction and balance are changed in same sessionTx
+func (h *HoldService) captureHold(ctx context.Context, holdId uint32, req *CaptureHoldReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	userId := getUserIdFromContext(ctx)
+	now := time.Now()
+
+	holdModel, err := h.useCase.hold.GetById.Execute(ctx, &holdusecase.GetHoldByIdReq{
+		UserId: userId,
+		HoldId: holdId,
+	})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	amount, err := holdusecase.ValidateCapture(holdModel, req.Amount, now)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	accountDetail, err := h.useCase.user.GetAccountByAccountId.Execute(ctx, &userusecase.GetAccountByAccountIdReq{
+		AccountId: holdModel.AccountId,
+	})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	createReq := &transaction.CreateReq{
+		UserId:          userId,
+		BankType:        accountDetail.Bank,
+		AccountId:       holdModel.AccountId,
+		Amount:          amount,
+		TransactionType: models.TRANSACTIONTYPEWITHDRAW,
+		Memo:            holdModel.Memo,
+		Counterparty:    holdModel.Counterparty,
+	}
+	ruleMatch, err := h.transactionService.categorize(ctx, userId, accountDetail.Bank, createReq)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	// open session tx pointer, to control from outside
+	sessionTx := h.repo.hold.PersistentRepo.BeginTx()
+
+	transactionDetail, asyncJobCreateTransaction, err := h.transactionService.useCase.transaction.Create.Execute(ctx, createReq, sessionTx)
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		return res.TransformToError(err)
+	}
+
+	capturedHold, asyncJobInvalidateAccount, err := h.useCase.hold.Capture.Execute(ctx, &holdusecase.CaptureHoldReq{
+		Hold:          holdModel,
+		Amount:        amount,
+		TransactionId: transactionDetail.Id,
+		Now:           now,
+	}, sessionTx)
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		abortJobs(asyncJobCreateTransaction)
+		return res.TransformToError(err)
+	}
+
+	events, err := h.transactionService.enqueueTransactionEvents(ctx, sessionTx, models.WEBHOOKEVENTTRANSACTIONCREATED, 0, transactionDetail)
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		abortJobs(asyncJobCreateTransaction, asyncJobInvalidateAccount)
+		return res.TransformToError(err)
+	}
+
+	err = sessionTx.Commit().Error
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		abortJobs(asyncJobCreateTransaction, asyncJobInvalidateAccount)
+		return res.TransformToError(err)
+	}
+
+	defer func(ctx context.Context) {
+		asyncJobCreateTransaction.Commit()
+		asyncJobInvalidateAccount.Commit()
+		h.transactionService.publishEvents(ctx, transactionDetail.UserId, events)
+	}(ctx)
+
+	transactionDetail.RuleMatch = ruleMatch
+	return res.TransformToCreatedSuccess(&CaptureHoldResult{
+		Hold:        capturedHold,
+		Transaction: transactionDetail,
+	})
+}
+
+func (h *HoldService) voidHold(ctx context.Context, holdId uint32) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	userId := getUserIdFromContext(ctx)
+
+	holdModel, err := h.useCase.hold.GetById.Execute(ctx, &holdusecase.GetHoldByIdReq{
+		UserId: userId,
+		HoldId: holdId,
+	})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	voidedHold, err := h.releaseHold(ctx, holdModel, models.HOLDSTATUSVOIDED, time.Now())
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToUpdatedSuccess(voidedHold)
+}
+
+func (h *HoldService) getHold(ctx context.Context, holdId uint32) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	holdModel, err := h.useCase.hold.GetById.Execute(ctx, &holdusecase.GetHoldByIdReq{
+		UserId: getUserIdFromContext(ctx),
+		HoldId: holdId,
+	})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToSuccessOk(holdModel)
+}
+
+func (h *HoldService) getHolds(ctx context.Context, query *repo.Query) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	holds, err := h.useCase.hold.GetByUserId.Execute(ctx, &holdusecase.GetHoldsByUserIdReq{
+		UserId: getUserIdFromContext(ctx),
+		Query:  query,
+	})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToSuccessOk(holds)
+}
+
+// expireHolds is called by worker, release every authorized hold past expires_at
+func (h *HoldService) expireHolds(ctx context.Context, now time.Time, limit int) int {
+	holds, err := h.useCase.hold.GetExpired.Execute(ctx, &holdusecase.GetExpiredHoldsReq{
+		Now:   now,
+		Limit: limit,
+	})
+	if err != nil {
+		logging.FromContext(ctx, h.logger).Error("[HoldService-expireHolds]", zap.String("Error", err.Error()))
+		return 0
+	}
+
+	expired := 0
+	for _, holdModel := range holds {
+		_, err = h.releaseHold(ctx, holdModel, models.HOLDSTATUSEXPIRED, now)
+		if err != nil {
+			// captured or voided meanwhile
+			logging.FromContext(ctx, h.logger).Info("[HoldService-expireHolds]", zap.Uint32("hold_id", holdModel.ID), zap.String("Skip", err.Error()))
+			continue
+		}
+		expired++
+	}
+	return expired
+}
+
+func (h *HoldService) releaseHold(ctx context.Context, holdModel *models.Hold, status string, now time.Time) (*models.Hold, error) {
+	// open session tx pointer, to control from outside
+	sessionTx := h.repo.hold.PersistentRepo.BeginTx()
+	releasedHold, asyncJobInvalidateAccount, err := h.useCase.hold.Release.Execute(ctx, &holdusecase.ReleaseHoldReq{
+		Hold:   holdModel,
+		Status: status,
+		Now:    now,
+	}, sessionTx)
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		return nil, err
+	}
+
+	err = sessionTx.Commit().Error
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		abortJobs(asyncJobInvalidateAccount)
+		return nil, err
+	}
+
+	defer func(ctx context.Context) {
+		asyncJobInvalidateAccount.Commit()
+	}(ctx)
+
+	return releasedHold, nil
+}
@@ -0,0 +1,44 @@
+package monolithic
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+// HoldExpiryWorker release authorized holds past expires_at
+// safe to run more than one worker, each hold is moved out of authorized only once
+type HoldExpiryWorker struct {
+	service  *HoldService
+	logger   *zap.Logger
+	interval time.Duration
+	// max holds per tick
+	batchSize int
+}
+
+func InitHoldExpiryWorker(logger *zap.Logger, appServerConfig *AppConfigServer) *HoldExpiryWorker {
+	return &HoldExpiryWorker{
+		service:   appServerConfig.getHoldService(),
+		logger:    logger,
+		interval:  appServerConfig.config.Workers.Hold.Interval,
+		batchSize: appServerConfig.config.Workers.Hold.BatchSize,
+	}
+}
+
+func (w *HoldExpiryWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		expired := w.service.expireHolds(ctx, time.Now(), w.batchSize)
+		if expired > 0 {
+			w.logger.Info("[HoldExpiryWorker-Run]", zap.Int("expired", expired))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package monolithic
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/composite"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	interestusecase "money_forward_code_challenge/internal/domain/transaction/usecase/interest"
+	"money_forward_code_challenge/internal/infrastructure/data-provider/mysql"
+	"net/http"
+)
+
+type InterestProductHandler struct {
+	routerGroup     *gin.RouterGroup
+	appServerConfig *AppConfigServer
+	service         *InterestService
+	logger          *zap.Logger
+}
+
+func InitInterestProductRouter(logger *zap.Logger, routerGroup *gin.RouterGroup, appServerConfig *AppConfigServer) {
+	i := &InterestProductHandler{
+		routerGroup:     routerGroup,
+		appServerConfig: appServerConfig,
+		logger:          logger,
+	}
+	i.service = appServerConfig.getInterestService()
+	i.InitRouter()
+}
+
+// getInterestService is shared by rest api and interest worker
+func (a *AppConfigServer) getInterestService() *InterestService {
+	if a.interestService != nil {
+		return a.interestService
+	}
+
+	interestRepoComposite := &composite.InterestRepoComposite{
+		PersistentRepo: mysql.NewMysqlInterestRepo(a.gormDB, a.logger),
+	}
+
+	a.interestService = NewInterestService(interestRepoComposite, a.getTransactionService(), a.getOverdraftService(), a.logger)
+	return a.interestService
+}
+
+func (i *InterestProductHandler) InitRouter() {
+	i.routerGroup.POST("", i.createProduct)
+	i.routerGroup.GET("", i.getProducts)
+}
+
+func (i *InterestProductHandler) createProduct(ginCtx *gin.Context) {
+	var req interestusecase.CreateProductReq
+	err := ginCtx.ShouldBindJSON(&req)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := i.service.createProduct(ginCtx, &req)
+	writeResponse(ginCtx, response)
+}
+
+func (i *InterestProductHandler) getProducts(ginCtx *gin.Context) {
+	type QueryOption struct {
+		Limit  int `form:"limit"`
+		Offset int `form:"offset"`
+	}
+	var queryOption QueryOption
+	err := ginCtx.ShouldBindQuery(&queryOption)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := i.service.getProducts(ginCtx, &repo.Query{
+		Limit:  queryOption.Limit,
+		Offset: queryOption.Offset,
+	})
+	writeResponse(ginCtx, response)
+}
@@ -0,0 +1,270 @@
+package monolithic
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/composite"
+	"money_forward_code_challenge/internal/common/httpresponse"
+	"money_forward_code_challenge/internal/common/logging"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	interestusecase "money_forward_code_challenge/internal/domain/transaction/usecase/interest"
+	"money_forward_code_challenge/internal/domain/transaction/usecase/transaction"
+	webhookusecase "money_forward_code_challenge/internal/domain/transaction/usecase/webhook"
+	"money_forward_code_challenge/pkgs/repo_pool_async"
+	"time"
+)
+
+type InterestService struct {
+	repo struct {
+		interest *composite.InterestRepoComposite
+	}
+	useCase struct {
+		interest *composite.InterestUseCaseComposite
+	}
+	// interest is posted through same transaction and balance use cases as rest api
+	transactionService *TransactionService
+	// ownership check of rest api
+	overdraftService *OverdraftService
+	logger           *zap.Logger
+}
+
+type AssignInterestProductReq struct {
+	ProductId uint32 `json:"product_id"`
+}
+
+func NewInterestService(interestRepoComposite *composite.InterestRepoComposite, transactionService *TransactionService, overdraftService *OverdraftService, logger *zap.Logger) *InterestService {
+	return &InterestService{
+		logger:             logger,
+		transactionService: transactionService,
+		overdraftService:   overdraftService,
+		repo: struct {
+			interest *composite.InterestRepoComposite
+		}{
+			interest: interestRepoComposite,
+		},
+		useCase: struct {
+			interest *composite.InterestUseCaseComposite
+		}{
+			interest: &composite.InterestUseCaseComposite{
+				CreateProduct:       interestusecase.NewCreateProductUseCase(interestRepoComposite.PersistentRepo, logger),
+				GetProducts:         interestusecase.NewGetProducts(interestRepoComposite.PersistentRepo, logger),
+				AssignProduct:       interestusecase.NewAssignProductUseCase(interestRepoComposite.PersistentRepo, logger),
+				GetAccountInterests: interestusecase.NewGetAccountInterests(interestRepoComposite.PersistentRepo, logger),
+				GetHistory:          interestusecase.NewGetInterestHistory(interestRepoComposite.PersistentRepo, logger),
+				Accrue:              interestusecase.NewAccrueAccountUseCase(interestRepoComposite.PersistentRepo, logger),
+				GetUnposted:         interestusecase.NewGetUnpostedAccruals(interestRepoComposite.PersistentRepo, logger),
+				Post:                interestusecase.NewPostAccrualsUseCase(interestRepoComposite.PersistentRepo, logger),
+			},
+		},
+	}
+}
+
+func (i *InterestService) createProduct(ctx context.Context, req *interestusecase.CreateProductReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	product, err := i.useCase.interest.CreateProduct.Execute(ctx, req, nil)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToCreatedSuccess(product)
+}
+
+func (i *InterestService) getProducts(ctx context.Context, query *repo.Query) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	products, err := i.useCase.interest.GetProducts.Execute(ctx, &interestusecase.GetProductsReq{
+		Query: query,
+	})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToSuccessOk(products)
+}
+
+// assignProduct start earning interest from today, changing product keep accrued interest
+func (i *InterestService) assignProduct(ctx context.Context, accountId uint32, req *AssignInterestProductReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	_, errResponse := i.overdraftService.getOwnAccount(ctx, accountId)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	accountInterest, err := i.useCase.interest.AssignProduct.Execute(ctx, &interestusecase.AssignProductReq{
+		AccountId: accountId,
+		ProductId: req.ProductId,
+		Now:       time.Now(),
+	}, nil)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToUpdatedSuccess(accountInterest)
+}
+
+func (i *InterestService) getHistory(ctx context.Context, accountId uint32, query *repo.Query) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	_, errResponse := i.overdraftService.getOwnAccount(ctx, accountId)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	history, err := i.useCase.interest.GetHistory.Execute(ctx, &interestusecase.GetInterestHistoryReq{
+		AccountId: accountId,
+		Query:     query,
+	})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToSuccessOk(history)
+}
+
+// runInterest is called by worker, accrue every missing day until yesterday then post closed months
+// both steps are idempotent, a day or a month already done is skipped
+func (i *InterestService) runInterest(ctx context.Context, now time.Time, batchSize int) (int, int) {
+	accrued, posted := 0, 0
+	products := make(map[uint32]*models.InterestProduct)
+	afterAccountId := uint32(0)
+
+	for {
+		accountInterests, err := i.useCase.interest.GetAccountInterests.Execute(ctx, &interestusecase.GetAccountInterestsReq{
+			AfterAccountId: afterAccountId,
+			Limit:          batchSize,
+		})
+		if err != nil {
+			logging.FromContext(ctx, i.logger).Error("[InterestService-runInterest]", zap.String("Error", err.Error()))
+			return accrued, posted
+		}
+
+		for _, accountInterest := range accountInterests {
+			product, ok := products[accountInterest.ProductId]
+			if !ok {
+				product, err = i.repo.interest.PersistentRepo.GetProductById(ctx, accountInterest.ProductId)
+				if err != nil {
+					logging.FromContext(ctx, i.logger).Error("[InterestService-runInterest]", zap.Uint32("product_id", accountInterest.ProductId), zap.String("Error", err.Error()))
+					continue
+				}
+				products[accountInterest.ProductId] = product
+			}
+
+			days, err := i.accrue(ctx, accountInterest, product, now)
+			if err != nil {
+				logging.FromContext(ctx, i.logger).Info("[InterestService-runInterest]", zap.Uint32("account_id", accountInterest.AccountId), zap.String("Skip", err.Error()))
+				continue
+			}
+			accrued += days
+
+			months, err := i.post(ctx, accountInterest, now)
+			if err != nil {
+				logging.FromContext(ctx, i.logger).Info("[InterestService-runInterest]", zap.Uint32("account_id", accountInterest.AccountId), zap.String("Skip", err.Error()))
+			}
+			posted += months
+		}
+
+		if len(accountInterests) < batchSize {
+			return accrued, posted
+		}
+		afterAccountId = accountInterests[len(accountInterests)-1].AccountId
+	}
+}
+
+func (i *InterestService) accrue(ctx context.Context, accountInterest *models.AccountInterest, product *models.InterestProduct, now time.Time) (int, error) {
+	// open session tx pointer, to control from outside
+	sessionTx := i.repo.interest.PersistentRepo.BeginTx()
+	days, err := i.useCase.interest.Accrue.Execute(ctx, &interestusecase.AccrueAccountReq{
+		AccountInterest: accountInterest,
+		Product:         product,
+		Now:             now,
+	}, sessionTx)
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		return 0, err
+	}
+
+	err = sessionTx.Commit().Error
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		return 0, err
+	}
+	return days, nil
+}
+
+// post credit one interest transaction per closed month, posting row and transaction share one tx
+func (i *InterestService) post(ctx context.Context, accountInterest *models.AccountInterest, now time.Time) (int, error) {
+	months, accrualsByMonth, err := i.useCase.interest.GetUnposted.Execute(ctx, &interestusecase.GetUnpostedAccrualsReq{
+		AccountId:  accountInterest.AccountId,
+		BeforeDate: interestusecase.FirstOfMonth(now),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	posted := 0
+	for _, month := range months {
+		err = i.postMonth(ctx, accountInterest, month, accrualsByMonth[month])
+		if err != nil {
+			return posted, err
+		}
+		posted++
+	}
+	return posted, nil
+}
+
+func (i *InterestService) postMonth(ctx context.Context, accountInterest *models.AccountInterest, month string, accruals []*models.InterestAccrual) error {
+	amount := interestusecase.MicroToAmount(interestusecase.SumMicro(accruals))
+
+	// open session tx pointer, to control from outside
+	sessionTx := i.repo.interest.PersistentRepo.BeginTx()
+
+	transactionId := uint32(0)
+	var asyncJobs []*repo_pool_async.Job
+	var events []*webhookusecase.Event
+	userId := uint32(0)
+	if amount > 0 {
+		transactionDetail, jobs, transactionEvents, err := i.transactionService.createSystemTransaction(ctx, &transaction.CreateReq{
+			AccountId:       accountInterest.AccountId,
+			Amount:          amount,
+			TransactionType: models.TRANSACTIONTYPEINTEREST,
+			Memo:            fmt.Sprintf("interest %s", month),
+		}, false, sessionTx)
+		if err != nil {
+			_ = sessionTx.Rollback().Error
+			return err
+		}
+		transactionId = transactionDetail.Id
+		asyncJobs = jobs
+		events = transactionEvents
+		userId = transactionDetail.UserId
+	}
+
+	// unique (account_id, month), fail when month already posted
+	_, err := i.useCase.interest.Post.Execute(ctx, &interestusecase.PostAccrualsReq{
+		AccountInterest: accountInterest,
+		Month:           month,
+		Accruals:        accruals,
+		TransactionId:   transactionId,
+	}, sessionTx)
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		abortJobs(asyncJobs...)
+		return err
+	}
+
+	err = sessionTx.Commit().Error
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		abortJobs(asyncJobs...)
+		return err
+	}
+
+	defer func(ctx context.Context) {
+		for _, asyncJob := range asyncJobs {
+			asyncJob.Commit()
+		}
+		i.transactionService.publishEvents(ctx, userId, events)
+	}(ctx)
+
+	return nil
+}
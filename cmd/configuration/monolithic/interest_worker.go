@@ -0,0 +1,44 @@
+package monolithic
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+// InterestWorker accrue daily interest and post it monthly
+// it tick more than once a day, each (account, day) is accrued once and each (account, month) posted once
+type InterestWorker struct {
+	service  *InterestService
+	logger   *zap.Logger
+	interval time.Duration
+	// accounts per page
+	batchSize int
+}
+
+func InitInterestWorker(logger *zap.Logger, appServerConfig *AppConfigServer) *InterestWorker {
+	return &InterestWorker{
+		service:   appServerConfig.getInterestService(),
+		logger:    logger,
+		interval:  appServerConfig.config.Workers.Interest.Interval,
+		batchSize: appServerConfig.config.Workers.Interest.BatchSize,
+	}
+}
+
+func (w *InterestWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		accrued, posted := w.service.runInterest(ctx, time.Now(), w.batchSize)
+		if accrued > 0 || posted > 0 {
+			w.logger.Info("[InterestWorker-Run]", zap.Int("accrued", accrued), zap.Int("posted", posted))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package monolithic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"money_forward_code_challenge/migration"
+	"money_forward_code_challenge/pkgs/migrate"
+
+	"go.uber.org/zap"
+)
+
+// MigrationRunner apply embedded migration/mysql files to mysql of app
+func (a *AppConfigServer) MigrationRunner() (*migrate.Runner, error) {
+	migrations, err := migrate.Load(migration.Schema())
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := a.gormDB.DB()
+	if err != nil {
+		return nil, err
+	}
+	return migrate.NewRunner(sqlDB, migrations, a.logger, migrate.Option{}), nil
+}
+
+// legacyBaselineVersion is init, only version which every old docker init script and AutoMigrate build created
+// builds in between may have created part of later tables and columns, later versions add only what is missing
+const legacyBaselineVersion uint64 = 20240527094108
+
+// MigrateUp apply pending migrations, database created before migrations is baselined first
+func (a *AppConfigServer) MigrateUp(ctx context.Context) ([]*migrate.Migration, error) {
+	runner, err := a.MigrationRunner()
+	if err != nil {
+		return nil, err
+	}
+	baselined, err := runner.BaselineLegacy(ctx, legacyBaselineVersion, "accounts")
+	if err != nil {
+		return nil, err
+	}
+	if baselined {
+		a.logger.Warn("[AppConfigServer-MigrateUp]", zap.String("Baselined", "tables exist without schema_migrations"), zap.Uint64("Version", legacyBaselineVersion))
+	}
+	err = a.checkOrphanTransactions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Up(ctx)
+}
+
+// ErrOrphanTransactions transactions of missing account, foreign key of constraints version can not be added
+var ErrOrphanTransactions = errors.New("transactions reference missing accounts")
+
+// checkOrphanTransactions fail before any version runs, so constraints version is not left dirty by a failed foreign key
+// nothing to check on new database or when foreign key already exists
+func (a *AppConfigServer) checkOrphanTransactions(ctx context.Context) error {
+	sqlDB, err := a.gormDB.DB()
+	if err != nil {
+		return err
+	}
+
+	var tables, constraints int
+	err = sqlDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'transactions'").Scan(&tables)
+	if err != nil || tables == 0 {
+		return err
+	}
+	err = sqlDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM information_schema.referential_constraints "+
+		"WHERE constraint_schema = DATABASE() AND table_name = 'transactions' AND constraint_name = 'fk_transactions_account_id'").Scan(&constraints)
+	if err != nil || constraints != 0 {
+		return err
+	}
+
+	var orphans int
+	var sampleIds sql.NullString
+	err = sqlDB.QueryRowContext(ctx, "SELECT COUNT(*), SUBSTRING_INDEX(GROUP_CONCAT(`transactions`.`id` ORDER BY `transactions`.`id`), ',', 10) "+
+		"FROM `transactions` LEFT JOIN `accounts` ON `accounts`.`id` = `transactions`.`account_id` WHERE `accounts`.`id` IS NULL").Scan(&orphans, &sampleIds)
+	if err != nil {
+		return err
+	}
+	if orphans != 0 {
+		return fmt.Errorf("%w: %d transactions (ids %s), restore their accounts or delete them, then restart to add fk_transactions_account_id",
+			ErrOrphanTransactions, orphans, sampleIds.String)
+	}
+	return nil
+}
+
+// InitDB apply pending migrations, safe on every boot and with many instances (lock)
+// seed is separate step, only demo environments enable it
+func (a *AppConfigServer) InitDB(ctx context.Context, seedDemoData bool) error {
+	applied, err := a.MigrateUp(ctx)
+	if err != nil {
+		return err
+	}
+	a.logger.Info("[AppConfigServer-InitDB]", zap.Int("Applied", len(applied)))
+
+	if !seedDemoData {
+		return nil
+	}
+	return a.SeedDemoData(ctx)
+}
+
+// SeedDemoData run embedded migration/seed files in name order, they are safe to run again
+func (a *AppConfigServer) SeedDemoData(ctx context.Context) error {
+	sqlDB, err := a.gormDB.DB()
+	if err != nil {
+		return err
+	}
+
+	seedFS := migration.Seed()
+	names, err := fs.Glob(seedFS, "*.sql")
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		script, err := fs.ReadFile(seedFS, name)
+		if err != nil {
+			return err
+		}
+		err = migrate.RunScript(ctx, sqlDB, string(script))
+		if err != nil {
+			return err
+		}
+		a.logger.Info("[AppConfigServer-SeedDemoData]", zap.String("File", name))
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package monolithic
+
+import (
+	"money_forward_code_challenge/api/openapi"
+	"money_forward_code_challenge/internal/common/config"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/httpresponse"
+	"money_forward_code_challenge/internal/domain/transaction/aggregate"
+	"money_forward_code_challenge/internal/domain/transaction/categorization"
+	"money_forward_code_challenge/internal/domain/transaction/fee"
+	feeusecase "money_forward_code_challenge/internal/domain/transaction/usecase/fee"
+	holdusecase "money_forward_code_challenge/internal/domain/transaction/usecase/hold"
+	interestusecase "money_forward_code_challenge/internal/domain/transaction/usecase/interest"
+	ruleusecase "money_forward_code_challenge/internal/domain/transaction/usecase/rule"
+	scheduleusecase "money_forward_code_challenge/internal/domain/transaction/usecase/schedule"
+	"money_forward_code_challenge/internal/domain/transaction/usecase/transaction"
+	webhookusecase "money_forward_code_challenge/internal/domain/transaction/usecase/webhook"
+	"money_forward_code_challenge/pkgs/health"
+	"money_forward_code_challenge/pkgs/repo_pool_async"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// schemas of spec and go types they are bound to or rendered from
+var openAPISchemaTypes = map[string]any{
+	"CreateTransactionRequest":     transaction.CreateReq{},
+	"DeleteTransactionRequest":     transaction.DeleteReq{},
+	"CreateRuleRequest":            ruleusecase.CreateRuleReq{},
+	"DryRunRuleRequest":            DryRunRuleReq{},
+	"CreateScheduleRequest":        scheduleusecase.CreateScheduleReq{},
+	"SetOverdraftRequest":          SetOverdraftReq{},
+	"AssignInterestProductRequest": AssignInterestProductReq{},
+	"AuthorizeHoldRequest":         holdusecase.AuthorizeHoldReq{},
+	"CaptureHoldRequest":           CaptureHoldReq{},
+	"CreateWebhookRequest":         webhookusecase.CreateSubscriptionReq{},
+	"CreateInterestProductRequest": interestusecase.CreateProductReq{},
+	"CreateFeeScheduleRequest":     feeusecase.CreateFeeScheduleReq{},
+	"Transaction":                  aggregate.TransactionByDetails{},
+	"Account":                      aggregate.AccountByDetails{},
+	"FeeQuote":                     fee.Quote{},
+	"RuleMatch":                    categorization.Explanation{},
+	"HealthReport":                 health.Report{},
+	"HealthCheck":                  health.Result{},
+	"Response":                     httpresponse.Response{},
+	"Problem":                      httpresponse.Problem{},
+	"FieldError":                   exception.FieldError{},
+	"DeadLetter":                   repo_pool_async.DeadLetter{},
+	"DeadLetterReplay":             DeadLetterReplay{},
+}
+
+var ginPathParam = regexp.MustCompile(`:(\w+)`)
+
+// TestOpenAPIRoutes fail when route is added or removed without updating spec
+func TestOpenAPIRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	a := &AppConfigServer{logger: zap.NewNop(), config: config.Default()}
+	err := a.InitRouter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := openapi.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	served := map[string]bool{}
+	for _, route := range a.server.Routes() {
+		served[route.Method+" "+ginPathParam.ReplaceAllString(route.Path, "{$1}")] = true
+	}
+
+	documented := map[string]bool{}
+	for path, pathItem := range doc.Paths.Map() {
+		for method := range pathItem.Operations() {
+			documented[method+" "+path] = true
+		}
+	}
+
+	for _, route := range sortedKeys(served) {
+		if !documented[route] {
+			t.Errorf("route %s is not in openapi.yaml", route)
+		}
+	}
+	for _, route := range sortedKeys(documented) {
+		if !served[route] {
+			t.Errorf("openapi.yaml document %s which is not served", route)
+		}
+	}
+}
+
+// TestOpenAPISchemas fail when json field of request or response is not same as spec
+func TestOpenAPISchemas(t *testing.T) {
+	doc, err := openapi.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, value := range openAPISchemaTypes {
+		schemaRef, ok := doc.Components.Schemas[name]
+		if !ok {
+			t.Errorf("schema %s is not in openapi.yaml", name)
+			continue
+		}
+
+		fields := jsonFields(reflect.TypeOf(value))
+		for field := range fields {
+			if _, ok := schemaRef.Value.Properties[field]; !ok {
+				t.Errorf("%s: field %q of %T is not in spec", name, field, value)
+			}
+		}
+		for property := range schemaRef.Value.Properties {
+			if !fields[property] {
+				t.Errorf("%s: property %q is not a json field of %T", name, property, value)
+			}
+		}
+	}
+}
+
+func jsonFields(typ reflect.Type) map[string]bool {
+	fields := map[string]bool{}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = true
+	}
+	return fields
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
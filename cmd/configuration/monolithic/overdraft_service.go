@@ -0,0 +1,241 @@
+package monolithic
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/composite"
+	"money_forward_code_challenge/internal/common/httpresponse"
+	"money_forward_code_challenge/internal/common/logging"
+	"money_forward_code_challenge/internal/common/validation"
+	"money_forward_code_challenge/internal/domain/transaction/aggregate"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	overdraftusecase "money_forward_code_challenge/internal/domain/transaction/usecase/overdraft"
+	"money_forward_code_challenge/internal/domain/transaction/usecase/transaction"
+	userusecase "money_forward_code_challenge/internal/domain/transaction/usecase/user"
+	"time"
+)
+
+type OverdraftService struct {
+	repo struct {
+		overdraft *composite.OverdraftRepoComposite
+	}
+	useCase struct {
+		overdraft *composite.OverdraftUseCaseComposite
+	}
+	// account reads and daily interest charges go through transaction and balance use cases
+	transactionService *TransactionService
+	logger             *zap.Logger
+}
+
+type SetOverdraftReq struct {
+	Limit float32 `json:"limit"`
+	Rate  float32 `json:"rate"`
+}
+
+func (r *SetOverdraftReq) Validate() error {
+	return validation.New().
+		Field("limit", r.Limit, validation.Range[float32](0, overdraftusecase.MaxOverdraftLimit)).
+		Field("rate", r.Rate, validation.Range[float32](0, overdraftusecase.MaxOverdraftRate)).
+		Err()
+}
+
+func NewOverdraftService(overdraftRepoComposite *composite.OverdraftRepoComposite, userRepoComposite *composite.UserRepoComposite, transactionService *TransactionService, logger *zap.Logger, poolSizeWorkerUseCase int) *OverdraftService {
+	return &OverdraftService{
+		logger:             logger,
+		transactionService: transactionService,
+		repo: struct {
+			overdraft *composite.OverdraftRepoComposite
+		}{
+			overdraft: overdraftRepoComposite,
+		},
+		useCase: struct {
+			overdraft *composite.OverdraftUseCaseComposite
+		}{
+			overdraft: &composite.OverdraftUseCaseComposite{
+				Update:               overdraftusecase.NewUpdateOverdraftUseCase(userRepoComposite.PersistentRepo, userRepoComposite.CacheRepo, logger, poolSizeWorkerUseCase),
+				Approve:              overdraftusecase.NewApproveOverdraftUseCase(userRepoComposite.PersistentRepo, userRepoComposite.CacheRepo, logger, poolSizeWorkerUseCase),
+				GetOverdrawnAccounts: overdraftusecase.NewGetOverdrawnAccounts(userRepoComposite.PersistentRepo, logger),
+				CreateInterest:       overdraftusecase.NewCreateInterestPostingUseCase(overdraftRepoComposite.PersistentRepo, logger),
+				GetInterestPostings:  overdraftusecase.NewGetInterestPostings(overdraftRepoComposite.PersistentRepo, logger),
+			},
+		},
+	}
+}
+
+// setOverdraft request limit and rate, approval is reset until approveOverdraft
+func (o *OverdraftService) setOverdraft(ctx context.Context, accountId uint32, req *SetOverdraftReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	err := req.Validate()
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	_, errResponse := o.getOwnAccount(ctx, accountId)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	return o.updateOverdraft(ctx, &overdraftusecase.UpdateOverdraftReq{
+		AccountId: accountId,
+		Limit:     req.Limit,
+		Rate:      req.Rate,
+		Approved:  false,
+	}, res)
+}
+
+// approveOverdraft make limit admin reviewed usable, approved false revoke it
+// it is called by admin api, owner of account cannot approve its own limit
+// approval is conditional on limit in db, 409 when owner requested another limit since review
+func (o *OverdraftService) approveOverdraft(ctx context.Context, req *overdraftusecase.ApproveOverdraftReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	_, err := o.transactionService.useCase.user.GetAccountByAccountId.Execute(ctx, &userusecase.GetAccountByAccountIdReq{
+		AccountId: req.AccountId,
+	})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	asyncJobInvalidateAccount, err := o.useCase.overdraft.Approve.Execute(ctx, req, nil)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	defer func(ctx context.Context) {
+		asyncJobInvalidateAccount.Commit()
+	}(ctx)
+
+	return res.TransformToUpdatedSuccess(req)
+}
+
+func (o *OverdraftService) updateOverdraft(ctx context.Context, req *overdraftusecase.UpdateOverdraftReq, res *httpresponse.Response) *httpresponse.Response {
+	asyncJobInvalidateAccount, err := o.useCase.overdraft.Update.Execute(ctx, req, nil)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	defer func(ctx context.Context) {
+		asyncJobInvalidateAccount.Commit()
+	}(ctx)
+
+	return res.TransformToUpdatedSuccess(req)
+}
+
+func (o *OverdraftService) getInterestPostings(ctx context.Context, accountId uint32, query *repo.Query) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	_, errResponse := o.getOwnAccount(ctx, accountId)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	postings, err := o.useCase.overdraft.GetInterestPostings.Execute(ctx, &overdraftusecase.GetInterestPostingsReq{
+		AccountId: accountId,
+		Query:     query,
+	})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToSuccessOk(postings)
+}
+
+// postDailyInterest is called by worker, charge interest of today on every overdrawn account
+// a day already charged is skipped, so it is safe to call many times a day
+func (o *OverdraftService) postDailyInterest(ctx context.Context, now time.Time, batchSize int) int {
+	accrualDate := overdraftusecase.AccrualDate(now)
+	posted := 0
+	afterId := uint32(0)
+
+	for {
+		accounts, err := o.useCase.overdraft.GetOverdrawnAccounts.Execute(ctx, &overdraftusecase.GetOverdrawnAccountsReq{
+			AfterId: afterId,
+			Limit:   batchSize,
+		})
+		if err != nil {
+			logging.FromContext(ctx, o.logger).Error("[OverdraftService-postDailyInterest]", zap.String("Error", err.Error()))
+			return posted
+		}
+
+		for _, account := range accounts {
+			err = o.postInterest(ctx, account, accrualDate)
+			if err != nil {
+				logging.FromContext(ctx, o.logger).Info("[OverdraftService-postDailyInterest]", zap.Uint32("account_id", account.ID), zap.String("Skip", err.Error()))
+				continue
+			}
+			posted++
+		}
+
+		if len(accounts) < batchSize {
+			return posted
+		}
+		afterId = accounts[len(accounts)-1].ID
+	}
+}
+
+func (o *OverdraftService) postInterest(ctx context.Context, account *models.Account, accrualDate string) error {
+	amount := overdraftusecase.DailyInterest(account.Balance, account.OverdraftRate)
+	if amount == 0 {
+		return fmt.Errorf("no interest on balance %v with rate %v", account.Balance, account.OverdraftRate)
+	}
+
+	// open session tx pointer, to control from outside
+	sessionTx := o.repo.overdraft.PersistentRepo.BeginTx()
+
+	// interest is charged even when it pass overdraft limit
+	transactionDetail, asyncJobs, events, err := o.transactionService.createSystemTransaction(ctx, &transaction.CreateReq{
+		AccountId:       account.ID,
+		Amount:          amount,
+		TransactionType: models.TRANSACTIONTYPEOVERDRAFTINTEREST,
+		Memo:            fmt.Sprintf("overdraft interest %s", accrualDate),
+	}, true, sessionTx)
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		return err
+	}
+
+	// unique (account_id, accrual_date), fail when day already charged
+	_, err = o.useCase.overdraft.CreateInterest.Execute(ctx, &overdraftusecase.CreateInterestPostingReq{
+		Account:       account,
+		AccrualDate:   accrualDate,
+		Amount:        amount,
+		TransactionId: transactionDetail.Id,
+	}, sessionTx)
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		abortJobs(asyncJobs...)
+		return err
+	}
+
+	err = sessionTx.Commit().Error
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		abortJobs(asyncJobs...)
+		return err
+	}
+
+	defer func(ctx context.Context) {
+		for _, asyncJob := range asyncJobs {
+			asyncJob.Commit()
+		}
+		o.transactionService.publishEvents(ctx, transactionDetail.UserId, events)
+	}(ctx)
+
+	return nil
+}
+
+func (o *OverdraftService) getOwnAccount(ctx context.Context, accountId uint32) (*aggregate.AccountByDetails, *httpresponse.Response) {
+	res := &httpresponse.Response{}
+	accountDetail, err := o.transactionService.useCase.user.GetAccountByAccountId.Execute(ctx, &userusecase.GetAccountByAccountIdReq{
+		AccountId: accountId,
+	})
+	if err != nil {
+		return nil, res.TransformToError(err)
+	}
+
+	if accountDetail.UserId != getUserIdFromContext(ctx) {
+		// user account owner is not same as url param <user_id>
+		return nil, res.TransformToError(userusecase.ErrAccountOwner)
+	}
+	return accountDetail, nil
+}
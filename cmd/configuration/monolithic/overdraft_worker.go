@@ -0,0 +1,44 @@
+package monolithic
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+// OverdraftInterestWorker charge interest of today on overdrawn accounts
+// it tick more than once a day, each (account, day) is charged once
+type OverdraftInterestWorker struct {
+	service  *OverdraftService
+	logger   *zap.Logger
+	interval time.Duration
+	// accounts per page
+	batchSize int
+}
+
+func InitOverdraftInterestWorker(logger *zap.Logger, appServerConfig *AppConfigServer) *OverdraftInterestWorker {
+	return &OverdraftInterestWorker{
+		service:   appServerConfig.getOverdraftService(),
+		logger:    logger,
+		interval:  appServerConfig.config.Workers.Overdraft.Interval,
+		batchSize: appServerConfig.config.Workers.Overdraft.BatchSize,
+	}
+}
+
+func (w *OverdraftInterestWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		posted := w.service.postDailyInterest(ctx, time.Now(), w.batchSize)
+		if posted > 0 {
+			w.logger.Info("[OverdraftInterestWorker-Run]", zap.Int("posted", posted))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
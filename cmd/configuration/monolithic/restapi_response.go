@@ -0,0 +1,36 @@
+package monolithic
+
+import (
+	"github.com/gin-gonic/gin"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/httpresponse"
+	"money_forward_code_challenge/internal/common/logging"
+	"net/http"
+)
+
+// writeResponse write envelope returned by service, errors carry X-Request-ID of request
+// and are problem+json when client accept it
+func writeResponse(ginCtx *gin.Context, response *httpresponse.Response) {
+	httpresponse.Write(ginCtx, response)
+}
+
+// writeError reject request before service is called, request is invalid unless code is 5xx
+func writeError(ginCtx *gin.Context, code int, message string) {
+	errorCode := exception.Validation
+	if code >= http.StatusInternalServerError {
+		errorCode = exception.Internal
+	}
+
+	ginCtx.Header("Vary", "Accept")
+	if httpresponse.AcceptProblem(ginCtx) {
+		res := (&httpresponse.Response{}).TransformToError(exception.New(errorCode, "", message))
+		res.Code = code
+		httpresponse.WriteProblem(ginCtx, res.WithRequestId(logging.RequestId(ginCtx)).Problem(ginCtx.Request.URL.Path))
+		return
+	}
+	ginCtx.JSON(code, &gin.H{
+		"error":      message,
+		"error_code": errorCode,
+		"request_id": logging.RequestId(ginCtx),
+	})
+}
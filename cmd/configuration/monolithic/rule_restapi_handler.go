@@ -0,0 +1,137 @@
+package monolithic
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/composite"
+	ruleusecase "money_forward_code_challenge/internal/domain/transaction/usecase/rule"
+	"money_forward_code_challenge/internal/infrastructure/data-provider/mysql"
+	"money_forward_code_challenge/internal/infrastructure/data-provider/redis"
+	"net/http"
+	"strconv"
+)
+
+type RuleHandler struct {
+	routerGroup     *gin.RouterGroup
+	appServerConfig *AppConfigServer
+	service         *RuleService
+	logger          *zap.Logger
+}
+
+func InitRuleRouter(logger *zap.Logger, routerGroup *gin.RouterGroup, appServerConfig *AppConfigServer) {
+	r := &RuleHandler{
+		routerGroup:     routerGroup,
+		appServerConfig: appServerConfig,
+		logger:          logger,
+	}
+	transactionRepoComposite := &composite.TransactionRepoComposite{
+		PersistentRepo: mysql.NewMysqlTransactionRepo(appServerConfig.gormDB, logger),
+		CacheRepo:      redis.NewRedisTransactionCacheRepo(appServerConfig.redisDB, logger),
+	}
+
+	userRepoComposite := &composite.UserRepoComposite{
+		PersistentRepo: mysql.NewMysqlUserRepo(appServerConfig.gormDB, logger),
+		CacheRepo:      redis.NewRedisUserCacheRepo(appServerConfig.redisDB, logger),
+	}
+
+	ruleRepoComposite := &composite.RuleRepoComposite{
+		PersistentRepo: mysql.NewMysqlCategorizationRuleRepo(appServerConfig.gormDB, logger),
+	}
+
+	r.service = NewRuleService(transactionRepoComposite, userRepoComposite, ruleRepoComposite, logger)
+	r.InitRouter()
+}
+
+func (r *RuleHandler) InitRouter() {
+	r.routerGroup.POST("/", r.createRule)
+	r.routerGroup.GET("/", r.getRules)
+	r.routerGroup.DELETE("/:rule_id", r.deleteRule)
+	// explain which rule match one transaction without create
+	r.routerGroup.POST("/dry-run", r.dryRun)
+	// batch re-run over history, ?dry_run=true only explain
+	r.routerGroup.POST("/apply", r.applyRules)
+}
+
+func (r *RuleHandler) createRule(ginCtx *gin.Context) {
+	userIdParam, err := getUserIdURLParam(ginCtx, "id")
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	setUserIdToContext(ginCtx, userIdParam)
+	var req ruleusecase.CreateRuleReq
+	err = ginCtx.ShouldBindJSON(&req)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := r.service.createRule(ginCtx, &req)
+	writeResponse(ginCtx, response)
+}
+
+func (r *RuleHandler) getRules(ginCtx *gin.Context) {
+	userIdParam, err := getUserIdURLParam(ginCtx, "id")
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	setUserIdToContext(ginCtx, userIdParam)
+	response := r.service.getRules(ginCtx)
+	writeResponse(ginCtx, response)
+}
+
+func (r *RuleHandler) deleteRule(ginCtx *gin.Context) {
+	userIdParam, err := getUserIdURLParam(ginCtx, "id")
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ruleIdParam, err := strconv.Atoi(ginCtx.Param("rule_id"))
+	if err != nil || ruleIdParam <= 0 {
+		writeError(ginCtx, http.StatusBadRequest, "rule_id must be greater than 0")
+		return
+	}
+
+	setUserIdToContext(ginCtx, userIdParam)
+	response := r.service.deleteRule(ginCtx, &ruleusecase.DeleteRuleReq{
+		RuleId: uint32(ruleIdParam),
+	})
+	writeResponse(ginCtx, response)
+}
+
+func (r *RuleHandler) dryRun(ginCtx *gin.Context) {
+	userIdParam, err := getUserIdURLParam(ginCtx, "id")
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	setUserIdToContext(ginCtx, userIdParam)
+	var req DryRunRuleReq
+	err = ginCtx.ShouldBindJSON(&req)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := r.service.dryRun(ginCtx, &req)
+	writeResponse(ginCtx, response)
+}
+
+func (r *RuleHandler) applyRules(ginCtx *gin.Context) {
+	userIdParam, err := getUserIdURLParam(ginCtx, "id")
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	setUserIdToContext(ginCtx, userIdParam)
+	response := r.service.applyRules(ginCtx, &ruleusecase.ApplyRulesReq{
+		DryRun: ginCtx.Query("dry_run") == "true",
+	})
+	writeResponse(ginCtx, response)
+}
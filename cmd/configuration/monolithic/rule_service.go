@@ -0,0 +1,200 @@
+package monolithic
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/composite"
+	"money_forward_code_challenge/internal/common/httpresponse"
+	"money_forward_code_challenge/internal/common/validation"
+	"money_forward_code_challenge/internal/domain/transaction/categorization"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	ruleusecase "money_forward_code_challenge/internal/domain/transaction/usecase/rule"
+	userusecase "money_forward_code_challenge/internal/domain/transaction/usecase/user"
+	"time"
+)
+
+// batch size of transactions on re-run rules over history
+var applyRulesBatchSize = 100
+
+type RuleService struct {
+	repo struct {
+		transaction *composite.TransactionRepoComposite
+		user        *composite.UserRepoComposite
+		rule        *composite.RuleRepoComposite
+	}
+	useCase struct {
+		user *composite.UserUseCaseComposite
+		rule *composite.RuleUseCaseComposite
+	}
+	logger *zap.Logger
+}
+
+func newRuleUseCaseComposite(ruleRepoComposite *composite.RuleRepoComposite, transactionRepoComposite *composite.TransactionRepoComposite, logger *zap.Logger) *composite.RuleUseCaseComposite {
+	return &composite.RuleUseCaseComposite{
+		Create:         ruleusecase.NewCreateRuleUseCase(ruleRepoComposite.PersistentRepo, logger),
+		Delete:         ruleusecase.NewDeleteRuleUseCase(ruleRepoComposite.PersistentRepo, logger),
+		GetByUserId:    ruleusecase.NewGetRulesByUserId(ruleRepoComposite.PersistentRepo, logger),
+		Categorize:     ruleusecase.NewCategorizeUseCase(ruleRepoComposite.PersistentRepo, logger),
+		ApplyToHistory: ruleusecase.NewApplyRulesToHistory(ruleRepoComposite.PersistentRepo, transactionRepoComposite.PersistentRepo, logger, applyRulesBatchSize),
+	}
+}
+
+func NewRuleService(transactionRepoComposite *composite.TransactionRepoComposite, userRepoComposite *composite.UserRepoComposite, ruleRepoComposite *composite.RuleRepoComposite, logger *zap.Logger) *RuleService {
+	return &RuleService{
+		logger: logger,
+		repo: struct {
+			transaction *composite.TransactionRepoComposite
+			user        *composite.UserRepoComposite
+			rule        *composite.RuleRepoComposite
+		}{
+			transaction: transactionRepoComposite,
+			user:        userRepoComposite,
+			rule:        ruleRepoComposite,
+		},
+		useCase: struct {
+			user *composite.UserUseCaseComposite
+			rule *composite.RuleUseCaseComposite
+		}{
+			user: &composite.UserUseCaseComposite{
+				GetUserById:           userusecase.NewGetUserByIdUseCase(userRepoComposite.PersistentRepo, userRepoComposite.CacheRepo, logger),
+				GetAccountByAccountId: userusecase.NewGetAccountByAccountId(userRepoComposite.PersistentRepo, userRepoComposite.CacheRepo, logger),
+			},
+			rule: newRuleUseCaseComposite(ruleRepoComposite, transactionRepoComposite, logger),
+		},
+	}
+}
+
+func (r *RuleService) createRule(ctx context.Context, req *ruleusecase.CreateRuleReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	req.UserId = getUserIdFromContext(ctx)
+
+	// rule match any transaction type or bank when it is not set
+	err := validation.New().
+		OptionalField("transaction_type", req.TransactionType, validation.TransactionType(models.TRANSACTIONTYPEEXPECTS)).
+		OptionalField("bank", req.Bank, validation.BankType(models.BANKEXPECTEDS)).
+		Err()
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	_, err = r.useCase.user.GetUserById.Execute(ctx, &userusecase.GetUserByIdReq{UserId: req.UserId})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	ruleModel, err := r.useCase.rule.Create.Execute(ctx, req, nil)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToCreatedSuccess(ruleModel)
+}
+
+func (r *RuleService) getRules(ctx context.Context) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	userId := getUserIdFromContext(ctx)
+
+	rules, err := r.useCase.rule.GetByUserId.Execute(ctx, &ruleusecase.GetRulesByUserIdReq{UserId: userId})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToSuccessOk(rules)
+}
+
+func (r *RuleService) deleteRule(ctx context.Context, req *ruleusecase.DeleteRuleReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	req.UserId = getUserIdFromContext(ctx)
+
+	ruleModel, err := r.useCase.rule.Delete.Execute(ctx, req, nil)
+	if err != nil {
+		// not found rule or rule not owned by user
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToDeletedSuccess(ruleModel)
+}
+
+type DryRunRuleReq struct {
+	AccountId       uint32    `json:"account_id"`
+	Amount          float32   `json:"amount"`
+	TransactionType string    `json:"transaction_type"`
+	Memo            string    `json:"memo"`
+	Counterparty    string    `json:"counterparty"`
+	At              time.Time `json:"at"`
+}
+
+// dryRun explain which rule will match one transaction, nothing be created
+func (r *RuleService) dryRun(ctx context.Context, req *DryRunRuleReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	userId := getUserIdFromContext(ctx)
+
+	accountDetail, err := r.useCase.user.GetAccountByAccountId.Execute(ctx, &userusecase.GetAccountByAccountIdReq{
+		AccountId: req.AccountId,
+	})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	if accountDetail.UserId != userId {
+		// user account owner is not same as url param <user_id>
+		return res.TransformToError(userusecase.ErrAccountOwner)
+	}
+
+	if req.At.IsZero() {
+		req.At = time.Now()
+	}
+
+	explanation, err := r.useCase.rule.Categorize.Execute(ctx, &ruleusecase.CategorizeReq{
+		UserId: userId,
+		Subject: &categorization.Subject{
+			Amount:          req.Amount,
+			TransactionType: req.TransactionType,
+			Bank:            accountDetail.Bank,
+			Memo:            req.Memo,
+			Counterparty:    req.Counterparty,
+			At:              req.At,
+		},
+	})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToSuccessOk(explanation)
+}
+
+// applyRules re-run rules over history of user (batch command)
+func (r *RuleService) applyRules(ctx context.Context, req *ruleusecase.ApplyRulesReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	req.UserId = getUserIdFromContext(ctx)
+
+	_, err := r.useCase.user.GetUserById.Execute(ctx, &userusecase.GetUserByIdReq{UserId: req.UserId})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	// open session tx pointer, to control from outside
+	sessionTx := r.repo.transaction.PersistentRepo.BeginTx()
+	results, err := r.useCase.rule.ApplyToHistory.Execute(ctx, req, sessionTx)
+	if err != nil {
+		sessionTx.Rollback()
+		return res.TransformToError(err)
+	}
+
+	err = sessionTx.Commit().Error
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		return res.TransformToError(err)
+	}
+
+	if !req.DryRun {
+		// cached details of changed transaction are stale now
+		for _, result := range results {
+			if result.Changed {
+				_ = r.repo.transaction.CacheRepo.Delete(ctx, result.TransactionId)
+			}
+		}
+	}
+
+	return res.TransformToUpdatedSuccess(results)
+}
@@ -0,0 +1,168 @@
+package monolithic
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/composite"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	scheduleusecase "money_forward_code_challenge/internal/domain/transaction/usecase/schedule"
+	"money_forward_code_challenge/internal/infrastructure/data-provider/mysql"
+	"money_forward_code_challenge/internal/infrastructure/data-provider/redis"
+	"net/http"
+	"strconv"
+)
+
+type ScheduleHandler struct {
+	routerGroup     *gin.RouterGroup
+	appServerConfig *AppConfigServer
+	service         *ScheduleService
+	logger          *zap.Logger
+}
+
+func InitScheduleRouter(logger *zap.Logger, routerGroup *gin.RouterGroup, appServerConfig *AppConfigServer) {
+	s := &ScheduleHandler{
+		routerGroup:     routerGroup,
+		appServerConfig: appServerConfig,
+		logger:          logger,
+	}
+	s.service = appServerConfig.getScheduleService()
+	s.InitRouter()
+}
+
+// getScheduleService is shared by rest api and schedule worker
+func (a *AppConfigServer) getScheduleService() *ScheduleService {
+	if a.scheduleService != nil {
+		return a.scheduleService
+	}
+
+	scheduleRepoComposite := &composite.ScheduleRepoComposite{
+		PersistentRepo: mysql.NewMysqlScheduleRepo(a.gormDB, a.logger),
+	}
+
+	userRepoComposite := &composite.UserRepoComposite{
+		PersistentRepo: mysql.NewMysqlUserRepo(a.gormDB, a.logger),
+		CacheRepo:      redis.NewRedisUserCacheRepo(a.redisDB, a.logger),
+	}
+
+	a.scheduleService = NewScheduleService(scheduleRepoComposite, userRepoComposite, a.getTransactionService(), a.logger)
+	return a.scheduleService
+}
+
+func (s *ScheduleHandler) InitRouter() {
+	s.routerGroup.POST("/", s.createSchedule)
+	s.routerGroup.GET("/", s.getSchedules)
+	// run history, one row per occurrence
+	s.routerGroup.GET("/:schedule_id/runs", s.getScheduleRuns)
+	s.routerGroup.POST("/:schedule_id/pause", s.controlSchedule(scheduleusecase.SCHEDULEACTIONPAUSE))
+	s.routerGroup.POST("/:schedule_id/resume", s.controlSchedule(scheduleusecase.SCHEDULEACTIONRESUME))
+	s.routerGroup.POST("/:schedule_id/skip-next", s.controlSchedule(scheduleusecase.SCHEDULEACTIONSKIPNEXT))
+}
+
+type scheduleQueryOption struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
+
+func (s *ScheduleHandler) createSchedule(ginCtx *gin.Context) {
+	userIdParam, err := getUserIdURLParam(ginCtx, "id")
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	setUserIdToContext(ginCtx, userIdParam)
+	var req scheduleusecase.CreateScheduleReq
+	err = ginCtx.ShouldBindJSON(&req)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := s.service.createSchedule(ginCtx, &req)
+	writeResponse(ginCtx, response)
+}
+
+func (s *ScheduleHandler) getSchedules(ginCtx *gin.Context) {
+	userIdParam, err := getUserIdURLParam(ginCtx, "id")
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var queryOption scheduleQueryOption
+	err = ginCtx.ShouldBindQuery(&queryOption)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	setUserIdToContext(ginCtx, userIdParam)
+	response := s.service.getSchedules(ginCtx, &repo.Query{
+		Limit:  queryOption.Limit,
+		Offset: queryOption.Offset,
+	})
+	writeResponse(ginCtx, response)
+}
+
+func (s *ScheduleHandler) getScheduleRuns(ginCtx *gin.Context) {
+	userIdParam, err := getUserIdURLParam(ginCtx, "id")
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	scheduleIdParam, err := getScheduleIdURLParam(ginCtx)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var queryOption scheduleQueryOption
+	err = ginCtx.ShouldBindQuery(&queryOption)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	setUserIdToContext(ginCtx, userIdParam)
+	response := s.service.getScheduleRuns(ginCtx, &scheduleusecase.GetScheduleRunsReq{
+		ScheduleId: scheduleIdParam,
+		Query: &repo.Query{
+			Limit:  queryOption.Limit,
+			Offset: queryOption.Offset,
+		},
+	})
+	writeResponse(ginCtx, response)
+}
+
+func (s *ScheduleHandler) controlSchedule(action string) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		userIdParam, err := getUserIdURLParam(ginCtx, "id")
+		if err != nil {
+			writeError(ginCtx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		scheduleIdParam, err := getScheduleIdURLParam(ginCtx)
+		if err != nil {
+			writeError(ginCtx, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		setUserIdToContext(ginCtx, userIdParam)
+		response := s.service.controlSchedule(ginCtx, &scheduleusecase.ControlScheduleReq{
+			ScheduleId: scheduleIdParam,
+			Action:     action,
+		})
+		writeResponse(ginCtx, response)
+	}
+}
+
+func getScheduleIdURLParam(c *gin.Context) (uint32, error) {
+	scheduleIdParam, err := strconv.Atoi(c.Param("schedule_id"))
+	if err != nil || scheduleIdParam <= 0 {
+		return 0, fmt.Errorf("schedule_id must be greater than 0")
+	}
+	return uint32(scheduleIdParam), nil
+}
@@ -0,0 +1,287 @@
+package monolithic
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"money_forward_code_challenge/internal/common/composite"
+	"money_forward_code_challenge/internal/common/httpresponse"
+	"money_forward_code_challenge/internal/common/logging"
+	"money_forward_code_challenge/internal/common/validation"
+	"money_forward_code_challenge/internal/domain/transaction/aggregate"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	scheduleusecase "money_forward_code_challenge/internal/domain/transaction/usecase/schedule"
+	"money_forward_code_challenge/internal/domain/transaction/usecase/transaction"
+	userusecase "money_forward_code_challenge/internal/domain/transaction/usecase/user"
+	webhookusecase "money_forward_code_challenge/internal/domain/transaction/usecase/webhook"
+	"money_forward_code_challenge/pkgs/repo_pool_async"
+	"time"
+)
+
+type ScheduleService struct {
+	repo struct {
+		schedule *composite.ScheduleRepoComposite
+	}
+	useCase struct {
+		user     *composite.UserUseCaseComposite
+		schedule *composite.ScheduleUseCaseComposite
+	}
+	// every run go through same path as rest api
+	transactionService *TransactionService
+	logger             *zap.Logger
+}
+
+func NewScheduleService(scheduleRepoComposite *composite.ScheduleRepoComposite, userRepoComposite *composite.UserRepoComposite, transactionService *TransactionService, logger *zap.Logger) *ScheduleService {
+	return &ScheduleService{
+		logger:             logger,
+		transactionService: transactionService,
+		repo: struct {
+			schedule *composite.ScheduleRepoComposite
+		}{
+			schedule: scheduleRepoComposite,
+		},
+		useCase: struct {
+			user     *composite.UserUseCaseComposite
+			schedule *composite.ScheduleUseCaseComposite
+		}{
+			user: &composite.UserUseCaseComposite{
+				GetAccountByAccountId: userusecase.NewGetAccountByAccountId(userRepoComposite.PersistentRepo, userRepoComposite.CacheRepo, logger),
+			},
+			schedule: &composite.ScheduleUseCaseComposite{
+				Create:      scheduleusecase.NewCreateScheduleUseCase(scheduleRepoComposite.PersistentRepo, logger),
+				GetByUserId: scheduleusecase.NewGetSchedulesByUserId(scheduleRepoComposite.PersistentRepo, logger),
+				GetRuns:     scheduleusecase.NewGetScheduleRuns(scheduleRepoComposite.PersistentRepo, logger),
+				Control:     scheduleusecase.NewControlScheduleUseCase(scheduleRepoComposite.PersistentRepo, logger),
+				GetDue:      scheduleusecase.NewGetDueSchedules(scheduleRepoComposite.PersistentRepo, logger),
+				ClaimRun:    scheduleusecase.NewClaimScheduleRunUseCase(scheduleRepoComposite.PersistentRepo, logger),
+				FinishRun:   scheduleusecase.NewFinishScheduleRunUseCase(scheduleRepoComposite.PersistentRepo, logger),
+				RecoverRuns: scheduleusecase.NewRecoverStaleRunsUseCase(scheduleRepoComposite.PersistentRepo, logger),
+			},
+		},
+	}
+}
+
+func (s *ScheduleService) createSchedule(ctx context.Context, req *scheduleusecase.CreateScheduleReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	req.UserId = getUserIdFromContext(ctx)
+
+	err := validation.New().
+		Field("transaction_type", req.TransactionType, validation.TransactionType(models.SCHEDULETRANSACTIONTYPEEXPECTS)).
+		Field("amount", req.Amount, validation.Amount(models.TRANSACTIONAMOUNTMIN, models.TRANSACTIONAMOUNTMAX)).
+		Err()
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	accountDetail, err := s.useCase.user.GetAccountByAccountId.Execute(ctx, &userusecase.GetAccountByAccountIdReq{
+		AccountId: req.AccountId,
+	})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	if accountDetail.UserId != req.UserId {
+		// user account owner is not same as url param <user_id>
+		return res.TransformToError(userusecase.ErrAccountOwner)
+	}
+
+	if req.TransactionType == models.TRANSACTIONTYPETRANSFER {
+		_, err = s.useCase.user.GetAccountByAccountId.Execute(ctx, &userusecase.GetAccountByAccountIdReq{
+			AccountId: req.TargetAccountId,
+		})
+		if err != nil {
+			return res.TransformToError(err)
+		}
+	}
+
+	scheduleModel, err := s.useCase.schedule.Create.Execute(ctx, req, nil)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToCreatedSuccess(scheduleModel)
+}
+
+func (s *ScheduleService) getSchedules(ctx context.Context, query *repo.Query) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	userId := getUserIdFromContext(ctx)
+
+	schedules, err := s.useCase.schedule.GetByUserId.Execute(ctx, &scheduleusecase.GetSchedulesByUserIdReq{
+		UserId: userId,
+		Query:  query,
+	})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToSuccessOk(schedules)
+}
+
+func (s *ScheduleService) getScheduleRuns(ctx context.Context, req *scheduleusecase.GetScheduleRunsReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	req.UserId = getUserIdFromContext(ctx)
+
+	runs, err := s.useCase.schedule.GetRuns.Execute(ctx, req)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToSuccessOk(runs)
+}
+
+// controlSchedule pause, resume or skip next occurrence
+func (s *ScheduleService) controlSchedule(ctx context.Context, req *scheduleusecase.ControlScheduleReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	req.UserId = getUserIdFromContext(ctx)
+	req.Now = time.Now()
+
+	scheduleModel, err := s.useCase.schedule.Control.Execute(ctx, req, nil)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToUpdatedSuccess(scheduleModel)
+}
+
+// runDueSchedules is called by worker, one occurrence per due schedule each call
+// missed occurrences (downtime) are caught up on next calls
+func (s *ScheduleService) runDueSchedules(ctx context.Context, now time.Time, limit int) int {
+	schedules, err := s.useCase.schedule.GetDue.Execute(ctx, &scheduleusecase.GetDueSchedulesReq{
+		Now:   now,
+		Limit: limit,
+	})
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("[ScheduleService-runDueSchedules]", zap.String("Error", err.Error()))
+		return 0
+	}
+
+	ran := 0
+	for _, scheduleModel := range schedules {
+		if s.runSchedule(ctx, scheduleModel) {
+			ran++
+		}
+	}
+	return ran
+}
+
+// recoverStaleRuns is called by worker, runs left started by builds which committed claim before execute are failed
+func (s *ScheduleService) recoverStaleRuns(ctx context.Context, now time.Time) int64 {
+	recovered, err := s.useCase.schedule.RecoverRuns.Execute(ctx, &scheduleusecase.RecoverStaleRunsReq{
+		Now: now,
+	})
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("[ScheduleService-recoverStaleRuns]", zap.String("Error", err.Error()))
+		return 0
+	}
+	return recovered
+}
+
+func (s *ScheduleService) runSchedule(ctx context.Context, scheduleModel *models.Schedule) bool {
+	// claim moves schedule forward, copy of what was read is claimed again when execute fail
+	readSchedule := *scheduleModel
+	userCtx := context.WithValue(ctx, ContextUserIdKey, scheduleModel.UserId)
+
+	// claim occurrence, create its transaction and save result in one tx
+	// crash before commit leave occurrence due with nothing created, it run on next tick, never twice
+	sessionTx := s.repo.schedule.PersistentRepo.BeginTx()
+	run, err := s.useCase.schedule.ClaimRun.Execute(ctx, &scheduleusecase.ClaimScheduleRunReq{
+		Schedule: scheduleModel,
+	}, sessionTx)
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		logging.FromContext(ctx, s.logger).Info("[ScheduleService-runSchedule]", zap.Uint32("schedule_id", scheduleModel.ID), zap.String("Skip", err.Error()))
+		return false
+	}
+
+	legs, asyncJobs, legEvents, err := s.execute(userCtx, scheduleModel, sessionTx)
+	if err != nil {
+		// writes of execute are rolled back with claim, failed run is saved by its own claim
+		_ = sessionTx.Rollback().Error
+		response := (&httpresponse.Response{}).TransformToError(err)
+		s.failRun(ctx, &readSchedule, fmt.Errorf("[%d] %s", response.Code, response.ErrCodeString))
+		return false
+	}
+
+	_, err = s.useCase.schedule.FinishRun.Execute(ctx, &scheduleusecase.FinishScheduleRunReq{
+		Run:           run,
+		TransactionId: legs[0].Id,
+	}, sessionTx)
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		abortJobs(asyncJobs...)
+		logging.FromContext(ctx, s.logger).Error("[ScheduleService-runSchedule]", zap.String("idempotency_key", run.IdempotencyKey), zap.String("Error", err.Error()))
+		return false
+	}
+
+	err = sessionTx.Commit().Error
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		abortJobs(asyncJobs...)
+		logging.FromContext(ctx, s.logger).Error("[ScheduleService-runSchedule]", zap.String("idempotency_key", run.IdempotencyKey), zap.String("Error", err.Error()))
+		return false
+	}
+
+	defer func(ctx context.Context) {
+		for _, asyncJob := range asyncJobs {
+			asyncJob.Commit()
+		}
+		for i, legDetail := range legs {
+			s.transactionService.publishEvents(ctx, legDetail.UserId, legEvents[i])
+		}
+	}(ctx)
+
+	return true
+}
+
+// failRun claim occurrence again and save it failed in same tx, failed run is never retried
+func (s *ScheduleService) failRun(ctx context.Context, scheduleModel *models.Schedule, runErr error) {
+	sessionTx := s.repo.schedule.PersistentRepo.BeginTx()
+	run, err := s.useCase.schedule.ClaimRun.Execute(ctx, &scheduleusecase.ClaimScheduleRunReq{
+		Schedule: scheduleModel,
+	}, sessionTx)
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		logging.FromContext(ctx, s.logger).Info("[ScheduleService-failRun]", zap.Uint32("schedule_id", scheduleModel.ID), zap.String("Skip", err.Error()))
+		return
+	}
+
+	_, err = s.useCase.schedule.FinishRun.Execute(ctx, &scheduleusecase.FinishScheduleRunReq{
+		Run: run,
+		Err: runErr,
+	}, sessionTx)
+	if err == nil {
+		err = sessionTx.Commit().Error
+	}
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		logging.FromContext(ctx, s.logger).Error("[ScheduleService-failRun]", zap.String("idempotency_key", run.IdempotencyKey), zap.String("Error", err.Error()))
+	}
+}
+
+// execute run schedule through TransactionService in sessionTx of claim, legs[0] is created transaction
+// (withdraw leg of transfer), caller commit then commit jobs and publish events of each leg
+func (s *ScheduleService) execute(ctx context.Context, scheduleModel *models.Schedule, sessionTx *gorm.DB) ([]*aggregate.TransactionByDetails, []*repo_pool_async.Job, [][]*webhookusecase.Event, error) {
+	if scheduleModel.TransactionType == models.TRANSACTIONTYPETRANSFER {
+		return s.transactionService.transferInTx(ctx, &TransferReq{
+			AccountId:       scheduleModel.AccountId,
+			TargetAccountId: scheduleModel.TargetAccountId,
+			Amount:          scheduleModel.Amount,
+			Memo:            scheduleModel.Memo,
+			Counterparty:    scheduleModel.Counterparty,
+		}, sessionTx)
+	}
+
+	transactionDetail, asyncJobs, events, err := s.transactionService.createTransactionInTx(ctx, &transaction.CreateReq{
+		AccountId:       scheduleModel.AccountId,
+		Amount:          scheduleModel.Amount,
+		TransactionType: scheduleModel.TransactionType,
+		Memo:            scheduleModel.Memo,
+		Counterparty:    scheduleModel.Counterparty,
+	}, sessionTx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return []*aggregate.TransactionByDetails{transactionDetail}, asyncJobs, [][]*webhookusecase.Event{events}, nil
+}
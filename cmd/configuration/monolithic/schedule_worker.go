@@ -0,0 +1,49 @@
+package monolithic
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+// ScheduleWorker poll due schedules and run them through ScheduleService
+// safe to run more than one worker, each occurrence is claimed by unique idempotency key
+type ScheduleWorker struct {
+	service  *ScheduleService
+	logger   *zap.Logger
+	interval time.Duration
+	// max schedules per tick
+	batchSize int
+}
+
+func InitScheduleWorker(logger *zap.Logger, appServerConfig *AppConfigServer) *ScheduleWorker {
+	return &ScheduleWorker{
+		service:   appServerConfig.getScheduleService(),
+		logger:    logger,
+		interval:  appServerConfig.config.Workers.Schedule.Interval,
+		batchSize: appServerConfig.config.Workers.Schedule.BatchSize,
+	}
+}
+
+func (w *ScheduleWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		recovered := w.service.recoverStaleRuns(ctx, time.Now())
+		if recovered > 0 {
+			w.logger.Warn("[ScheduleWorker-Run]", zap.Int64("stale_runs_failed", recovered))
+		}
+
+		ran := w.service.runDueSchedules(ctx, time.Now(), w.batchSize)
+		if ran > 0 {
+			w.logger.Info("[ScheduleWorker-Run]", zap.Int("ran", ran))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
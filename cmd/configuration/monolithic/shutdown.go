@@ -0,0 +1,185 @@
+package monolithic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"money_forward_code_challenge/pkgs/repo_pool_async"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// Serve run rest api, grpc api and background workers until ctx is done (SIGINT/SIGTERM)
+// or a server fail, then shutdown everything within http.shutdown_timeout
+func (a *AppConfigServer) Serve(ctx context.Context) error {
+	grpcListener, err := net.Listen("tcp", a.config.GRPC.Addr)
+	if err != nil {
+		return err
+	}
+	grpcServer := InitGrpcServer(a.logger, a)
+	httpServer := &http.Server{
+		Addr:    a.config.HTTP.Addr,
+		Handler: a.server,
+	}
+	// Shutdown does not cancel request context, streams are ended here
+	// otherwise Shutdown wait for them until timeout
+	httpServer.RegisterOnShutdown(a.closeStreams)
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	workers := a.runWorkers(workerCtx)
+
+	serveErr := make(chan error, 2)
+	go func() {
+		err := grpcServer.Serve(grpcListener)
+		if err != nil {
+			serveErr <- fmt.Errorf("grpc server: %w", err)
+		}
+	}()
+	go func() {
+		err := httpServer.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("http server: %w", err)
+		}
+	}()
+	a.logger.Info("[AppConfigServer-Serve]", zap.String("HttpAddr", a.config.HTTP.Addr), zap.String("GrpcAddr", a.config.GRPC.Addr))
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		a.logger.Info("[AppConfigServer-Serve]", zap.String("Shutdown", "signal received"))
+	case runErr = <-serveErr:
+		a.logger.Error("[AppConfigServer-Serve]", zap.String("Error", runErr.Error()))
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), a.config.HTTP.ShutdownTimeout)
+	defer cancel()
+	return errors.Join(runErr, a.shutdown(shutdownCtx, httpServer, grpcServer, stopWorkers, workers))
+}
+
+// runWorkers start background workers, they return when ctx is done
+func (a *AppConfigServer) runWorkers(ctx context.Context) *sync.WaitGroup {
+	workers := []interface{ Run(ctx context.Context) }{
+		// run due schedules through same transaction service
+		InitScheduleWorker(a.logger, a),
+		// release holds which are not captured before expires_at
+		InitHoldExpiryWorker(a.logger, a),
+		// charge daily interest on negative balances
+		InitOverdraftInterestWorker(a.logger, a),
+		// accrue daily interest on positive balances, post it monthly
+		InitInterestWorker(a.logger, a),
+		// send and retry webhook deliveries
+		InitWebhookDeliveryWorker(a.logger, a),
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(len(workers))
+	for _, worker := range workers {
+		go func(worker interface{ Run(ctx context.Context) }) {
+			defer wg.Done()
+			worker.Run(ctx)
+		}(worker)
+	}
+	return wg
+}
+
+// shutdown order: servers stop accepting and finish in-flight requests, workers finish batch,
+// async cache jobs pushed by both are drained, then redis and mysql are closed
+// steps keep going after timeout so connections are always released
+func (a *AppConfigServer) shutdown(ctx context.Context, httpServer *http.Server, grpcServer *grpc.Server, stopWorkers context.CancelFunc, workers *sync.WaitGroup) error {
+	var errs []error
+
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	err := httpServer.Shutdown(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("http shutdown: %w", err))
+	}
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+		errs = append(errs, fmt.Errorf("grpc shutdown: %w", ctx.Err()))
+	}
+
+	stopWorkers()
+	err = waitGroupContext(ctx, workers)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("workers: %w", err))
+	}
+
+	abandoned, err := repo_pool_async.CloseAll(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("async jobs: %d abandoned: %w", abandoned, err))
+	}
+	a.logger.Info("[AppConfigServer-shutdown]", zap.Int("AbandonedJobs", abandoned))
+
+	err = a.Close()
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	shutdownErr := errors.Join(errs...)
+	if shutdownErr != nil {
+		a.logger.Error("[AppConfigServer-shutdown]", zap.String("Error", shutdownErr.Error()))
+	} else {
+		a.logger.Info("[AppConfigServer-shutdown]", zap.String("Shutdown", "completed"))
+	}
+	return shutdownErr
+}
+
+// Close redis then mysql, call after nothing use them anymore
+func (a *AppConfigServer) Close() error {
+	var errs []error
+	if a.redisDB != nil {
+		err := a.redisDB.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("close redis: %w", err))
+		}
+	}
+	if a.gormDB != nil {
+		sqlDB, err := a.gormDB.DB()
+		if err == nil {
+			err = sqlDB.Close()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("close mysql: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// shuttingDown is closed when http server start shutdown
+func (a *AppConfigServer) shuttingDown() <-chan struct{} {
+	a.shutdownOnce.Do(func() {
+		a.shutdownCh = make(chan struct{})
+	})
+	return a.shutdownCh
+}
+
+func (a *AppConfigServer) closeStreams() {
+	a.shuttingDown()
+	a.closeOnce.Do(func() {
+		close(a.shutdownCh)
+	})
+}
+
+func waitGroupContext(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,139 @@
+package monolithic
+
+import (
+	"fmt"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/pkgs/pubsub"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type StreamHandler struct {
+	routerGroup     *gin.RouterGroup
+	appServerConfig *AppConfigServer
+	service         *StreamService
+	logger          *zap.Logger
+}
+
+func InitStreamRouter(logger *zap.Logger, routerGroup *gin.RouterGroup, appServerConfig *AppConfigServer) {
+	s := &StreamHandler{
+		routerGroup:     routerGroup,
+		appServerConfig: appServerConfig,
+		logger:          logger,
+	}
+	s.service = NewStreamService(appServerConfig.getStreamBroker(), logger, appServerConfig.config.Stream.Heartbeat)
+	s.InitRouter()
+}
+
+// getStreamBroker is shared by publisher (TransactionService) and stream
+// redis share events between instances, in process broker only when redis is not configured
+func (a *AppConfigServer) getStreamBroker() pubsub.Broker {
+	if a.streamBroker != nil {
+		return a.streamBroker
+	}
+
+	if a.redisDB != nil {
+		a.streamBroker = pubsub.NewRedisBroker(a.redisDB, a.config.Stream.HistorySize, a.config.Stream.HistoryTTL)
+	} else {
+		a.streamBroker = pubsub.NewMemoryBroker(a.config.Stream.HistorySize)
+	}
+	return a.streamBroker
+}
+
+func (s *StreamHandler) InitRouter() {
+	s.routerGroup.GET("/stream", s.streamTransactions)
+}
+
+// streamTransactions push events as server sent events until client disconnect
+// client (EventSource) reconnect with Last-Event-ID header to receive missed events
+func (s *StreamHandler) streamTransactions(ginCtx *gin.Context) {
+	userIdParam, err := getUserIdURLParam(ginCtx, "id")
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// query param for clients which can not set header
+	lastEventIdValue := ginCtx.GetHeader("Last-Event-ID")
+	if lastEventIdValue == "" {
+		lastEventIdValue = ginCtx.Query("last_event_id")
+	}
+	lastEventId := uint64(0)
+	if lastEventIdValue != "" {
+		lastEventId, err = strconv.ParseUint(lastEventIdValue, 10, 64)
+		if err != nil {
+			writeError(ginCtx, http.StatusBadRequest, fmt.Sprintf("invalid Last-Event-ID %q", lastEventIdValue))
+			return
+		}
+	}
+
+	setUserIdToContext(ginCtx, userIdParam)
+	subscription, replay, err := s.service.subscribe(ginCtx, lastEventId)
+	if err != nil {
+		writeError(ginCtx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer subscription.Close()
+
+	header := ginCtx.Writer.Header()
+	header.Set("Content-Type", sse.ContentType)
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	ginCtx.Status(http.StatusOK)
+	_, _ = fmt.Fprint(ginCtx.Writer, "retry: 3000\n\n")
+	ginCtx.Writer.Flush()
+
+	write := func(msg *pubsub.Message) bool {
+		err := sse.Encode(ginCtx.Writer, sse.Event{
+			Id:    strconv.FormatUint(msg.Id, 10),
+			Event: msg.Type,
+			Data:  string(msg.Data),
+		})
+		if err != nil {
+			return false
+		}
+		ginCtx.Writer.Flush()
+		// live messages already sent by replay are skipped
+		lastEventId = msg.Id
+		return true
+	}
+
+	for _, msg := range replay {
+		if !write(msg) {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(s.service.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ginCtx.Request.Context().Done():
+			return
+		case <-s.appServerConfig.shuttingDown():
+			// server is stopping, client reconnect to another instance with Last-Event-ID
+			return
+		case msg, ok := <-subscription.C:
+			if !ok {
+				// dropped as slow subscriber, client reconnect and resume
+				return
+			}
+			if msg.Id <= lastEventId {
+				continue
+			}
+			if !write(msg) {
+				return
+			}
+		case <-ticker.C:
+			_, err = fmt.Fprint(ginCtx.Writer, ": ping\n\n")
+			if err != nil {
+				return
+			}
+			ginCtx.Writer.Flush()
+		}
+	}
+}
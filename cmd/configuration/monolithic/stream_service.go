@@ -0,0 +1,49 @@
+package monolithic
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/logging"
+	"money_forward_code_challenge/pkgs/pubsub"
+	"time"
+)
+
+// StreamService serve live events of user published by TransactionService after commit
+type StreamService struct {
+	broker pubsub.Broker
+	// comment line sent when idle, keep proxies from closing stream
+	heartbeat time.Duration
+	logger    *zap.Logger
+}
+
+func NewStreamService(broker pubsub.Broker, logger *zap.Logger, heartbeat time.Duration) *StreamService {
+	return &StreamService{
+		broker:    broker,
+		heartbeat: heartbeat,
+		logger:    logger,
+	}
+}
+
+func streamTopic(userId uint32) string {
+	return fmt.Sprintf("transactions:user:%d", userId)
+}
+
+// subscribe return live subscription of user and kept events after lastEventId
+// lastEventId = 0 only receive new events
+func (s *StreamService) subscribe(ctx context.Context, lastEventId uint64) (*pubsub.Subscription, []*pubsub.Message, error) {
+	userId := getUserIdFromContext(ctx)
+	topic := streamTopic(userId)
+
+	if lastEventId == 0 {
+		subscription, err := s.broker.Subscribe(ctx, topic)
+		return subscription, nil, err
+	}
+
+	subscription, replay, err := pubsub.Resume(ctx, s.broker, topic, lastEventId)
+	if err != nil {
+		logging.FromContext(ctx, s.logger).Error("[StreamService-subscribe]", zap.Uint32("user_id", userId), zap.String("Error", err.Error()))
+		return nil, nil, err
+	}
+	return subscription, replay, nil
+}
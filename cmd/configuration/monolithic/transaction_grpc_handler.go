@@ -0,0 +1,222 @@
+package monolithic
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"money_forward_code_challenge/api/proto/transactionpb"
+	"money_forward_code_challenge/internal/common/httpresponse"
+	"money_forward_code_challenge/internal/domain/transaction/aggregate"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"money_forward_code_challenge/internal/domain/transaction/usecase/transaction"
+	userusecase "money_forward_code_challenge/internal/domain/transaction/usecase/user"
+	"net/http"
+)
+
+// grpc api go through same TransactionService as rest api
+// user_id of request is same as url param <user_id> of rest api, it is trusted (no authentication)
+
+type TransactionGrpcHandler struct {
+	transactionpb.UnimplementedTransactionServiceServer
+	service *TransactionService
+	logger  *zap.Logger
+}
+
+type AccountGrpcHandler struct {
+	transactionpb.UnimplementedAccountServiceServer
+	service *TransactionService
+	logger  *zap.Logger
+}
+
+func InitGrpcServer(logger *zap.Logger, appServerConfig *AppConfigServer) *grpc.Server {
+	server := grpc.NewServer()
+	service := appServerConfig.getTransactionService()
+	transactionpb.RegisterTransactionServiceServer(server, &TransactionGrpcHandler{
+		service: service,
+		logger:  logger,
+	})
+	transactionpb.RegisterAccountServiceServer(server, &AccountGrpcHandler{
+		service: service,
+		logger:  logger,
+	})
+	return server
+}
+
+func (t *TransactionGrpcHandler) CreateTransaction(ctx context.Context, req *transactionpb.CreateTransactionRequest) (*transactionpb.Transaction, error) {
+	ctx, err := withGrpcUserId(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	response := t.service.createTransactionByUser(ctx, &transaction.CreateReq{
+		AccountId:       req.GetAccountId(),
+		Amount:          req.GetAmount(),
+		TransactionType: req.GetTransactionType(),
+		Memo:            req.GetMemo(),
+		Counterparty:    req.GetCounterparty(),
+		Category:        req.GetCategory(),
+		Tags:            req.GetTags(),
+	})
+	if err := grpcStatusFromResponse(response); err != nil {
+		return nil, err
+	}
+	return toProtoTransaction(response.Data.(*aggregate.TransactionByDetails)), nil
+}
+
+func (t *TransactionGrpcHandler) ListTransactions(req *transactionpb.ListTransactionsRequest, stream transactionpb.TransactionService_ListTransactionsServer) error {
+	ctx, err := withGrpcUserId(stream.Context(), req.GetUserId())
+	if err != nil {
+		return err
+	}
+
+	// same default as rest api
+	query := &repo.Query{
+		Limit:  int(req.GetLimit()),
+		Offset: int(req.GetOffset()),
+	}
+	if query.Limit == query.Offset {
+		query.Limit = 10
+		query.Offset = 0
+	}
+
+	var response *httpresponse.Response
+	if req.GetAccountId() == 0 {
+		response = t.service.getTransactionsByUserId(ctx, &transaction.GetTransactionByUserIdReq{
+			UserId: req.GetUserId(),
+			Query:  query,
+		})
+	} else {
+		response = t.service.getTransactionsByAccountId(ctx, &transaction.GetTransactionByAccountIdReq{
+			AccountId: req.GetAccountId(),
+			Query:     query,
+		})
+	}
+	if err := grpcStatusFromResponse(response); err != nil {
+		return err
+	}
+
+	for _, transactionDetail := range response.Data.([]*aggregate.TransactionByDetails) {
+		err = stream.Send(toProtoTransaction(transactionDetail))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TransactionGrpcHandler) GetTransaction(ctx context.Context, req *transactionpb.GetTransactionRequest) (*transactionpb.Transaction, error) {
+	ctx, err := withGrpcUserId(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	response := t.service.getTransaction(ctx, &transaction.GetTransactionByIdReq{
+		Id: req.GetTransactionId(),
+	})
+	if err := grpcStatusFromResponse(response); err != nil {
+		return nil, err
+	}
+	return toProtoTransaction(response.Data.(*aggregate.TransactionByDetails)), nil
+}
+
+func (t *TransactionGrpcHandler) ReverseTransaction(ctx context.Context, req *transactionpb.ReverseTransactionRequest) (*transactionpb.Transaction, error) {
+	ctx, err := withGrpcUserId(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	response := t.service.deleteTransactionByUser(ctx, &transaction.DeleteReq{
+		AccountId:     req.GetAccountId(),
+		TransactionId: req.GetTransactionId(),
+	})
+	if err := grpcStatusFromResponse(response); err != nil {
+		return nil, err
+	}
+	return toProtoTransaction(response.Data.(*aggregate.TransactionByDetails)), nil
+}
+
+func (a *AccountGrpcHandler) GetAccount(ctx context.Context, req *transactionpb.GetAccountRequest) (*transactionpb.Account, error) {
+	ctx, err := withGrpcUserId(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	response := a.service.getAccount(ctx, &userusecase.GetAccountByAccountIdReq{
+		AccountId: req.GetAccountId(),
+	})
+	if err := grpcStatusFromResponse(response); err != nil {
+		return nil, err
+	}
+	return toProtoAccount(response.Data.(*aggregate.AccountByDetails)), nil
+}
+
+func withGrpcUserId(ctx context.Context, userId uint32) (context.Context, error) {
+	if userId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is fake = 0, not valid")
+	}
+	return context.WithValue(ctx, ContextUserIdKey, userId), nil
+}
+
+// grpcStatusFromResponse map status code of service response, nil on success
+func grpcStatusFromResponse(response *httpresponse.Response) error {
+	var code codes.Code
+	switch response.Code {
+	case http.StatusOK, http.StatusCreated, http.StatusAccepted:
+		return nil
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.AlreadyExists
+	case http.StatusUnprocessableEntity:
+		// insufficient funds, state of account must change before retry
+		code = codes.FailedPrecondition
+	case http.StatusServiceUnavailable:
+		code = codes.Unavailable
+	default:
+		code = codes.Internal
+	}
+	return status.Error(code, response.ErrCodeString)
+}
+
+func toProtoTransaction(transactionDetail *aggregate.TransactionByDetails) *transactionpb.Transaction {
+	if transactionDetail == nil {
+		return nil
+	}
+	return &transactionpb.Transaction{
+		Id:                  transactionDetail.Id,
+		UserId:              transactionDetail.UserId,
+		AccountId:           transactionDetail.AccountId,
+		Bank:                transactionDetail.Bank,
+		Amount:              transactionDetail.Amount,
+		TransactionType:     transactionDetail.TransactionType,
+		Memo:                transactionDetail.Memo,
+		Counterparty:        transactionDetail.Counterparty,
+		Category:            transactionDetail.Category,
+		Tags:                transactionDetail.Tags,
+		ParentTransactionId: transactionDetail.ParentTransactionId,
+		FeeTransaction:      toProtoTransaction(transactionDetail.FeeTransaction),
+		CreatedAt:           transactionDetail.CreatedAt,
+	}
+}
+
+func toProtoAccount(accountDetail *aggregate.AccountByDetails) *transactionpb.Account {
+	return &transactionpb.Account{
+		Id:                accountDetail.Id,
+		UserId:            accountDetail.UserId,
+		Name:              accountDetail.AccountName,
+		Bank:              accountDetail.Bank,
+		Balance:           accountDetail.Balance,
+		HeldAmount:        accountDetail.HeldAmount,
+		OverdraftLimit:    accountDetail.OverdraftLimit,
+		OverdraftApproved: accountDetail.OverdraftApproved,
+		OverdraftRate:     accountDetail.OverdraftRate,
+		AvailableBalance:  accountDetail.AvailableBalance,
+		CreatedAt:         accountDetail.CreatedAt,
+	}
+}
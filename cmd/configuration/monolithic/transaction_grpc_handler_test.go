@@ -0,0 +1,69 @@
+package monolithic
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/httpresponse"
+	"money_forward_code_challenge/internal/domain/transaction/aggregate"
+	userusecase "money_forward_code_challenge/internal/domain/transaction/usecase/user"
+	"testing"
+)
+
+func TestGrpcStatusFromResponse(t *testing.T) {
+	cases := []struct {
+		response *httpresponse.Response
+		code     codes.Code
+	}{
+		{(&httpresponse.Response{}).TransformToSuccessOk(nil), codes.OK},
+		{(&httpresponse.Response{}).TransformToCreatedSuccess(nil), codes.OK},
+		{(&httpresponse.Response{}).TransformToDeletedSuccess(nil), codes.OK},
+		{(&httpresponse.Response{}).TransformToBadRequest("bad"), codes.InvalidArgument},
+		{(&httpresponse.Response{}).TransformToNotFound("missing"), codes.NotFound},
+		{(&httpresponse.Response{}).TransformToConflictUniqueResourceError("conflict"), codes.AlreadyExists},
+		{(&httpresponse.Response{}).TransformToInternalServerError("boom"), codes.Internal},
+		{(&httpresponse.Response{}).TransformToError(userusecase.ErrAccountOwner), codes.PermissionDenied},
+		{(&httpresponse.Response{}).TransformToError(userusecase.ErrInsufficientBalance), codes.FailedPrecondition},
+		{(&httpresponse.Response{}).TransformToError(exception.New(exception.Unavailable, "", "down")), codes.Unavailable},
+	}
+
+	for _, c := range cases {
+		err := grpcStatusFromResponse(c.response)
+		if status.Code(err) != c.code {
+			t.Errorf("code %d mapped to %v, want %v", c.response.Code, status.Code(err), c.code)
+		}
+		if err != nil && status.Convert(err).Message() != c.response.ErrCodeString {
+			t.Errorf("message = %q, want %q", status.Convert(err).Message(), c.response.ErrCodeString)
+		}
+	}
+}
+
+func TestWithGrpcUserId(t *testing.T) {
+	_, err := withGrpcUserId(context.Background(), 0)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("user_id 0 code = %v, want InvalidArgument", status.Code(err))
+	}
+
+	ctx, err := withGrpcUserId(context.Background(), 7)
+	if err != nil || getUserIdFromContext(ctx) != 7 {
+		t.Fatalf("user id not set, err %v", err)
+	}
+}
+
+func TestToProtoTransaction(t *testing.T) {
+	transaction := toProtoTransaction(&aggregate.TransactionByDetails{
+		Id:     2,
+		Amount: 100000,
+		FeeTransaction: &aggregate.TransactionByDetails{
+			Id:                  3,
+			ParentTransactionId: 2,
+		},
+	})
+	if transaction.GetId() != 2 || transaction.GetFeeTransaction().GetParentTransactionId() != 2 {
+		t.Fatalf("unexpected transaction %v", transaction)
+	}
+	if toProtoTransaction(&aggregate.TransactionByDetails{}).GetFeeTransaction() != nil {
+		t.Fatal("fee transaction must be nil without fee")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"go.uber.org/zap"
 	"money_forward_code_challenge/internal/common/composite"
 	"money_forward_code_challenge/internal/common/httpresponse"
+	"money_forward_code_challenge/internal/common/logging"
 	"money_forward_code_challenge/internal/domain/transaction/repo"
 	"money_forward_code_challenge/internal/domain/transaction/usecase/transaction"
 	transactionusecase "money_forward_code_challenge/internal/domain/transaction/usecase/transaction"
@@ -32,24 +33,48 @@ func InitTransactionRouter(logger *zap.Logger, routerGroup *gin.RouterGroup, app
 		appServerConfig: appServerConfig,
 		logger:          logger,
 	}
+	t.service = appServerConfig.getTransactionService()
+	t.InitRouter()
+}
+
+// getTransactionService is shared by rest api and background workers (scheduler)
+func (a *AppConfigServer) getTransactionService() *TransactionService {
+	if a.transactionService != nil {
+		return a.transactionService
+	}
+
 	transactionRepoComposite := &composite.TransactionRepoComposite{
-		PersistentRepo: mysql.NewMysqlTransactionRepo(appServerConfig.gormDB, logger),
-		CacheRepo:      redis.NewRedisTransactionCacheRepo(appServerConfig.redisDB, logger),
+		PersistentRepo: mysql.NewMysqlTransactionRepo(a.gormDB, a.logger),
+		CacheRepo:      redis.NewRedisTransactionCacheRepo(a.redisDB, a.logger),
 	}
 
 	userRepoComposite := &composite.UserRepoComposite{
-		PersistentRepo: mysql.NewMysqlUserRepo(appServerConfig.gormDB, logger),
-		CacheRepo:      redis.NewRedisUserCacheRepo(appServerConfig.redisDB, logger),
+		PersistentRepo: mysql.NewMysqlUserRepo(a.gormDB, a.logger),
+		CacheRepo:      redis.NewRedisUserCacheRepo(a.redisDB, a.logger),
 	}
 
-	t.service = NewTransactionService(transactionRepoComposite, userRepoComposite, logger, 10)
-	t.InitRouter()
+	ruleRepoComposite := &composite.RuleRepoComposite{
+		PersistentRepo: mysql.NewMysqlCategorizationRuleRepo(a.gormDB, a.logger),
+	}
+
+	feeScheduleRepoComposite := &composite.FeeScheduleRepoComposite{
+		PersistentRepo: mysql.NewMysqlFeeScheduleRepo(a.gormDB, a.logger),
+	}
+
+	webhookRepoComposite := &composite.WebhookRepoComposite{
+		PersistentRepo: mysql.NewMysqlWebhookRepo(a.gormDB, a.logger),
+	}
+
+	a.transactionService = NewTransactionService(transactionRepoComposite, userRepoComposite, ruleRepoComposite, feeScheduleRepoComposite, webhookRepoComposite, a.getStreamBroker(), a.logger, a.config.Pool.Size)
+	return a.transactionService
 }
 
 func (t *TransactionHandler) InitRouter() {
 	t.routerGroup.POST("/", t.createTransactionByUser)   // 1 api
 	t.routerGroup.GET("/", t.getTransactions)            // 2 api in one
 	t.routerGroup.DELETE("/", t.deleteTransactionByUser) // 1 api
+	// fee createTransactionByUser would charge, same body
+	t.routerGroup.POST("/quote", t.quoteFee)
 
 	// TODO
 	// I need discuss a little
@@ -65,18 +90,39 @@ func (t *TransactionHandler) createTransactionByUser(ginCtx *gin.Context) {
 	userIdParam, err := getUserIdURLParam(ginCtx, "id")
 
 	if err != nil {
-		ginCtx.JSON(http.StatusBadRequest, &gin.H{
-			"error": err.Error(),
-		})
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	setUserIdToContext(ginCtx, userIdParam)
 	var req transaction.CreateReq
 	err = ginCtx.ShouldBindJSON(&req)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	response := t.service.createTransactionByUser(ginCtx, &req)
-	ginCtx.JSON(http.StatusOK, response)
+	writeResponse(ginCtx, response)
+}
+
+func (t *TransactionHandler) quoteFee(ginCtx *gin.Context) {
+	userIdParam, err := getUserIdURLParam(ginCtx, "id")
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	setUserIdToContext(ginCtx, userIdParam)
+	var req transaction.CreateReq
+	err = ginCtx.ShouldBindJSON(&req)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := t.service.quoteFee(ginCtx, &req)
+	writeResponse(ginCtx, response)
 }
 
 func (t *TransactionHandler) getTransactions(ginCtx *gin.Context) {
@@ -88,9 +134,7 @@ func (t *TransactionHandler) getTransactions(ginCtx *gin.Context) {
 
 	userIdParam, err := getUserIdURLParam(ginCtx, "id")
 	if err != nil {
-		ginCtx.JSON(http.StatusBadRequest, &gin.H{
-			"error": err.Error(),
-		})
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -104,16 +148,12 @@ func (t *TransactionHandler) getTransactions(ginCtx *gin.Context) {
 	// 2. client don't enter one account_id (mean that query transaction with user_id)
 	// but case 2 is passed
 	if err != nil && !getByUserId {
-		ginCtx.JSON(http.StatusBadRequest, &gin.H{
-			"error": err.Error(),
-		})
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	if accountIdValue == "0" {
-		ginCtx.JSON(http.StatusBadRequest, &gin.H{
-			"error": "account_id must be greater than 0",
-		})
+		writeError(ginCtx, http.StatusBadRequest, "account_id must be greater than 0")
 		return
 	}
 
@@ -140,29 +180,31 @@ func (t *TransactionHandler) getTransactions(ginCtx *gin.Context) {
 			AccountId: queryOption.AccountId,
 			Query:     repoQuery,
 		}
-		fmt.Println("GET TRANSACTIONS BY ACCOUNT ID: ", *req)
+		logging.FromContext(ginCtx, t.logger).Debug("[TransactionHandler-getTransactions]", zap.Uint32("account_id", req.AccountId))
 		response = t.service.getTransactionsByAccountId(ginCtx, req)
 	}
 
-	ginCtx.JSON(response.Code, response)
+	writeResponse(ginCtx, response)
 }
 
 func (t *TransactionHandler) deleteTransactionByUser(ginCtx *gin.Context) {
 	userIdParam, err := getUserIdURLParam(ginCtx, "id")
 
 	if err != nil {
-		ginCtx.JSON(http.StatusBadRequest, &gin.H{
-			"error": err.Error(),
-		})
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	setUserIdToContext(ginCtx, userIdParam)
 	var req transaction.DeleteReq
 	err = ginCtx.ShouldBindJSON(&req)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	response := t.service.deleteTransactionByUser(ginCtx, &req)
-	ginCtx.JSON(http.StatusOK, response)
+	writeResponse(ginCtx, response)
 }
 
 func getUserIdURLParam(c *gin.Context, nameParam string) (uint32, error) {
@@ -2,42 +2,75 @@ package monolithic
 
 import (
 	"context"
-	"github.com/gin-gonic/gin"
+	"encoding/json"
+	"fmt"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 	"money_forward_code_challenge/internal/common/composite"
 	exception "money_forward_code_challenge/internal/common/exception"
 	"money_forward_code_challenge/internal/common/httpresponse"
+	"money_forward_code_challenge/internal/common/logging"
+	"money_forward_code_challenge/internal/common/metrics"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/common/validation"
+	"money_forward_code_challenge/internal/domain/transaction/aggregate"
+	"money_forward_code_challenge/internal/domain/transaction/categorization"
 	"money_forward_code_challenge/internal/domain/transaction/models"
+	feeusecase "money_forward_code_challenge/internal/domain/transaction/usecase/fee"
+	ruleusecase "money_forward_code_challenge/internal/domain/transaction/usecase/rule"
 	"money_forward_code_challenge/internal/domain/transaction/usecase/transaction"
 	transactionusecase "money_forward_code_challenge/internal/domain/transaction/usecase/transaction"
 	userusecase "money_forward_code_challenge/internal/domain/transaction/usecase/user"
+	webhookusecase "money_forward_code_challenge/internal/domain/transaction/usecase/webhook"
+	"money_forward_code_challenge/pkgs/pubsub"
+	"money_forward_code_challenge/pkgs/repo_pool_async"
+	"strings"
+	"time"
 )
 
 type TransactionService struct {
 	repo struct {
 		transaction *composite.TransactionRepoComposite
 		user        *composite.UserRepoComposite
+		rule        *composite.RuleRepoComposite
+		fee         *composite.FeeScheduleRepoComposite
+		webhook     *composite.WebhookRepoComposite
 	}
 	useCase struct {
 		transaction *composite.TransactionUseCaseComposite
 		user        *composite.UserUseCaseComposite
+		rule        *composite.RuleUseCaseComposite
+		fee         *composite.FeeUseCaseComposite
+		webhook     *composite.WebhookUseCaseComposite
 	}
+	// live stream of committed events, see StreamService
+	broker pubsub.Broker
 	logger *zap.Logger
 }
 
-func NewTransactionService(transactionRepoComposite *composite.TransactionRepoComposite, userRepoComposite *composite.UserRepoComposite, logger *zap.Logger, poolSizeWorkerUseCase int) *TransactionService {
+func NewTransactionService(transactionRepoComposite *composite.TransactionRepoComposite, userRepoComposite *composite.UserRepoComposite, ruleRepoComposite *composite.RuleRepoComposite, feeScheduleRepoComposite *composite.FeeScheduleRepoComposite, webhookRepoComposite *composite.WebhookRepoComposite, broker pubsub.Broker, logger *zap.Logger, poolSizeWorkerUseCase int) *TransactionService {
 	return &TransactionService{
+		broker: broker,
 		logger: logger,
 		repo: struct {
 			transaction *composite.TransactionRepoComposite
 			user        *composite.UserRepoComposite
+			rule        *composite.RuleRepoComposite
+			fee         *composite.FeeScheduleRepoComposite
+			webhook     *composite.WebhookRepoComposite
 		}{
 			transaction: transactionRepoComposite,
 			user:        userRepoComposite,
+			rule:        ruleRepoComposite,
+			fee:         feeScheduleRepoComposite,
+			webhook:     webhookRepoComposite,
 		},
 		useCase: struct {
 			transaction *composite.TransactionUseCaseComposite
 			user        *composite.UserUseCaseComposite
+			rule        *composite.RuleUseCaseComposite
+			fee         *composite.FeeUseCaseComposite
+			webhook     *composite.WebhookUseCaseComposite
 		}{
 			transaction: &composite.TransactionUseCaseComposite{
 				Create:             transactionusecase.NewCreateUseCase(transactionRepoComposite.PersistentRepo, transactionRepoComposite.CacheRepo, logger, poolSizeWorkerUseCase),
@@ -51,25 +84,56 @@ func NewTransactionService(transactionRepoComposite *composite.TransactionRepoCo
 				GetAccountByAccountId: userusecase.NewGetAccountByAccountId(userRepoComposite.PersistentRepo, userRepoComposite.CacheRepo, logger),
 				UpdateBalanceAccount:  userusecase.NewUpdateBalanceAccountUseCase(userRepoComposite.PersistentRepo, userRepoComposite.CacheRepo, logger, poolSizeWorkerUseCase),
 			},
+			rule:    newRuleUseCaseComposite(ruleRepoComposite, transactionRepoComposite, logger),
+			fee:     newFeeUseCaseComposite(feeScheduleRepoComposite, logger),
+			webhook: newWebhookUseCaseComposite(webhookRepoComposite, logger),
 		},
 	}
 }
 
 func (t *TransactionService) createTransactionByUser(ctx context.Context, req *transaction.CreateReq) *httpresponse.Response {
-	res := &httpresponse.Response{}
-	userId := getUserIdFromContext(ctx)
+	ctx, span := tracing.Start(ctx, "TransactionService.createTransactionByUser")
+	defer span.End()
 
-	transactionTypeErrCheck := exception.NewCheckExceptionTransactionType([]string{"deposit", "withdraw"})
+	res := &httpresponse.Response{}
+	// open session tx pointer, to control from outside
+	sessionTx := t.repo.transaction.PersistentRepo.BeginTx()
+	transactionDetail, asyncJobs, events, err := t.createTransactionInTx(ctx, req, sessionTx)
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		return res.TransformToError(err)
+	}
 
-	transactionTypeErrCheck.Check(string(req.TransactionType))
-	if transactionTypeErrCheck.Error() != "" {
-		return res.TransformToBadRequest(transactionTypeErrCheck.Error())
+	err = sessionTx.Commit().Error
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		abortJobs(asyncJobs...)
+		return res.TransformToError(err)
 	}
 
-	transactionMinMaxAmountErrCheck := exception.NewCheckErrAmountValue(10000, 20000000)
-	transactionMinMaxAmountErrCheck.Check(req.Amount)
-	if transactionMinMaxAmountErrCheck.Error() != "" {
-		return res.TransformToBadRequest(transactionMinMaxAmountErrCheck.Error())
+	defer func(ctx context.Context) {
+		// run all update async for create transaction and update balance
+		// run method
+		// get response time from async job
+		// it not called then, it simplify don't process from own pool
+		// this is because can panic before reach code here
+		for _, asyncJob := range asyncJobs {
+			asyncJob.Commit()
+		}
+		t.publishEvents(ctx, transactionDetail.UserId, events)
+	}(ctx)
+
+	return res.TransformToCreatedSuccess(transactionDetail)
+}
+
+// createTransactionInTx create transaction of user, its fee and balance update in sessionTx
+// caller commit or roll back, then commit jobs and publish events (scheduled runs share sessionTx of claim)
+func (t *TransactionService) createTransactionInTx(ctx context.Context, req *transaction.CreateReq, sessionTx *gorm.DB) (*aggregate.TransactionByDetails, []*repo_pool_async.Job, []*webhookusecase.Event, error) {
+	userId := getUserIdFromContext(ctx)
+
+	err := req.Validate()
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
 	// get account check balance
@@ -79,18 +143,17 @@ func (t *TransactionService) createTransactionByUser(ctx context.Context, req *t
 
 	if err != nil {
 		// account not found or user account owner is not same as url param <user_id>
-		return res.TransformToNotFound(err.Error())
+		return nil, nil, nil, err
 	}
 
 	if accountDetail.UserId != userId {
 		// user account owner is not same as url param <user_id>
-		return res.TransformToBadRequest("user account owner is not same as url param <user_id>")
+		return nil, nil, nil, userusecase.ErrAccountOwner
 	}
 
-	if req.TransactionType == models.TRANSACTIONTYPEWITHDRAW {
-		if accountDetail.Balance < req.Amount {
-			return res.TransformToBadRequest("balance is not enough")
-		}
+	ruleMatch, err := t.categorize(ctx, userId, accountDetail.Bank, req)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
 	// pass into user_id,bank_type to update detail transaction if save success
@@ -101,65 +164,173 @@ func (t *TransactionService) createTransactionByUser(ctx context.Context, req *t
 	// on create
 	req.UserId = userId
 	req.BankType = accountDetail.Bank
-	// open session tx pointer, to control from outside
-	sessionTx := t.repo.transaction.PersistentRepo.BeginTx()
+
+	// quote in sessionTx, free quota used this month is counted with account locked
+	// so concurrent creates on same account can not both take last free one
+	feeQuote, err := t.useCase.fee.Quote.Execute(ctx, &feeusecase.QuoteFeeReq{
+		AccountId:       accountDetail.Id,
+		Bank:            accountDetail.Bank,
+		TransactionType: req.TransactionType,
+		Amount:          req.Amount,
+		Now:             time.Now(),
+	}, sessionTx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if req.TransactionType == models.TRANSACTIONTYPEWITHDRAW {
+		// authorized holds are reserved, only available balance can be withdrawn
+		if accountDetail.AvailableBalance < req.Amount+feeQuote.Fee {
+			return nil, nil, nil, userusecase.ErrInsufficientBalance
+		}
+	}
 
 	// create transaction and return detail model
 	transactionDetail, asyncJobCreateTransaction, err := t.useCase.transaction.Create.Execute(ctx, req, sessionTx)
 	if err != nil {
-		sessionTx.Rollback()
-		return res.TransformToInternalServerError(err.Error())
+		return nil, nil, nil, err
+	}
+	asyncJobs := []*repo_pool_async.Job{asyncJobCreateTransaction}
+
+	// fee is one more transaction linked to this one, in same sessionTx
+	if feeQuote.Charged {
+		var asyncJobCreateFee *repo_pool_async.Job
+		transactionDetail.FeeTransaction, asyncJobCreateFee, err = t.useCase.transaction.Create.Execute(ctx, &transaction.CreateReq{
+			UserId:              userId,
+			BankType:            accountDetail.Bank,
+			AccountId:           req.AccountId,
+			Amount:              feeQuote.Fee,
+			TransactionType:     models.TRANSACTIONTYPEFEE,
+			Memo:                fmt.Sprintf("fee of transaction %d (%s)", transactionDetail.Id, feeQuote.ScheduleName),
+			Category:            models.TRANSACTIONTYPEFEE,
+			ParentTransactionId: transactionDetail.Id,
+		}, sessionTx)
+		if err != nil {
+			abortJobs(asyncJobs...)
+			return nil, nil, nil, err
+		}
+		asyncJobs = append(asyncJobs, asyncJobCreateFee)
 	}
 
-	// update balance
+	// update balance, amount and fee together
 	asyncJobUpdateBalance, err := t.useCase.user.UpdateBalanceAccount.Execute(ctx, &userusecase.UpdateBalanceAccountReq{
 		AccountId:       req.AccountId,
 		OldBalance:      accountDetail.Balance,
 		Amount:          req.Amount,
 		TransactionType: req.TransactionType,
+		Fee:             feeQuote.Fee,
 	}, sessionTx)
 
 	if err != nil {
-		_ = sessionTx.Rollback().Error
-		return res.TransformToInternalServerError(err.Error())
+		abortJobs(asyncJobs...)
+		return nil, nil, nil, err
 	}
+	asyncJobs = append(asyncJobs, asyncJobUpdateBalance)
 
-	err = sessionTx.Commit().Error
+	events, err := t.enqueueTransactionEvents(ctx, sessionTx, models.WEBHOOKEVENTTRANSACTIONCREATED, feeQuote.Fee, transactionDetail)
+	if err != nil {
+		abortJobs(asyncJobs...)
+		return nil, nil, nil, err
+	}
 
+	transactionDetail.RuleMatch = ruleMatch
+	return transactionDetail, asyncJobs, events, nil
+}
+
+// quoteFee show fee createTransactionByUser would charge, nothing is created
+func (t *TransactionService) quoteFee(ctx context.Context, req *transaction.CreateReq) *httpresponse.Response {
+	ctx, span := tracing.Start(ctx, "TransactionService.quoteFee")
+	defer span.End()
+
+	res := &httpresponse.Response{}
+	userId := getUserIdFromContext(ctx)
+
+	// fee of any amount can be quoted
+	err := validation.New().
+		Field("transaction_type", req.TransactionType, validation.TransactionType(models.TRANSACTIONTYPEEXPECTS)).
+		Err()
 	if err != nil {
-		_ = sessionTx.Rollback().Error
-		return res.TransformToInternalServerError(err.Error())
+		return res.TransformToError(err)
 	}
 
-	defer func(ctx context.Context) {
-		// run all update async for create transaction and update balance
-		// run method
-		// get response time from async job
-		// it not called then, it simplify don't process from own pool
-		// this is because can panic before reach code here
-		asyncJobCreateTransaction.Run(ctx)
-		asyncJobUpdateBalance.Run(ctx)
-	}(ctx)
+	accountDetail, err := t.useCase.user.GetAccountByAccountId.Execute(ctx, &userusecase.GetAccountByAccountIdReq{
+		AccountId: req.AccountId,
+	})
+	if err != nil {
+		return res.TransformToError(err)
+	}
 
-	return res.TransformToCreatedSuccess(transactionDetail)
+	if accountDetail.UserId != userId {
+		// user account owner is not same as url param <user_id>
+		return res.TransformToError(userusecase.ErrAccountOwner)
+	}
+
+	feeQuote, err := t.useCase.fee.Quote.Execute(ctx, &feeusecase.QuoteFeeReq{
+		AccountId:       accountDetail.Id,
+		Bank:            accountDetail.Bank,
+		TransactionType: req.TransactionType,
+		Amount:          req.Amount,
+		Now:             time.Now(),
+	}, nil)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToSuccessOk(feeQuote)
+}
+
+// categorize run categorization rules of user on req
+// category from client is kept, tags are merged
+func (t *TransactionService) categorize(ctx context.Context, userId uint32, bank string, req *transaction.CreateReq) (*categorization.Explanation, error) {
+	ctx, span := tracing.Start(ctx, "TransactionService.categorize")
+	defer span.End()
+
+	ruleMatch, err := t.useCase.rule.Categorize.Execute(ctx, &ruleusecase.CategorizeReq{
+		UserId: userId,
+		Subject: &categorization.Subject{
+			Amount:          req.Amount,
+			TransactionType: req.TransactionType,
+			Bank:            bank,
+			Memo:            req.Memo,
+			Counterparty:    req.Counterparty,
+			At:              time.Now(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if ruleMatch.Matched {
+		if req.Category == "" {
+			req.Category = ruleMatch.Category
+		}
+		req.Tags = append(req.Tags, ruleMatch.Tags...)
+	}
+	return ruleMatch, nil
 }
 
 func (t *TransactionService) getTransactionsByUserId(ctx context.Context, req *transactionusecase.GetTransactionByUserIdReq) *httpresponse.Response {
+	ctx, span := tracing.Start(ctx, "TransactionService.getTransactionsByUserId")
+	defer span.End()
+
 	res := &httpresponse.Response{}
 	_, err := t.useCase.user.GetUserById.Execute(ctx, &userusecase.GetUserByIdReq{UserId: req.UserId})
 	if err != nil {
-		return res.TransformToNotFound(err.Error())
+		return res.TransformToError(err)
 	}
 
 	transactionModels, err := t.useCase.transaction.GetByUserId.Execute(ctx, req)
 	if err != nil {
-		return res.TransformToInternalServerError(err.Error())
+		return res.TransformToError(err)
 	}
 
 	return res.TransformToSuccessOk(transactionModels)
 }
 
 func (t *TransactionService) getTransactionsByAccountId(ctx context.Context, req *transactionusecase.GetTransactionByAccountIdReq) *httpresponse.Response {
+	ctx, span := tracing.Start(ctx, "TransactionService.getTransactionsByAccountId")
+	defer span.End()
+
 	res := &httpresponse.Response{}
 	userId := getUserIdFromContext(ctx)
 	accountDetail, err := t.useCase.user.GetAccountByAccountId.Execute(ctx, &userusecase.GetAccountByAccountIdReq{
@@ -168,37 +339,68 @@ func (t *TransactionService) getTransactionsByAccountId(ctx context.Context, req
 	if err != nil {
 		// not found account before get transactions
 		// or found account but user owner is not right
-		return res.TransformToNotFound(err.Error())
+		return res.TransformToError(err)
 	}
 
 	if accountDetail.UserId != userId {
 		// user account owner is not same as url param <user_id>
-		return res.TransformToBadRequest("user account owner is not same as url param <user_id>")
+		return res.TransformToError(userusecase.ErrAccountOwner)
 	}
 	transactionModels, err := t.useCase.transaction.GetByAccountId.Execute(ctx, req)
 	if err != nil {
-		return res.TransformToInternalServerError(err.Error())
+		return res.TransformToError(err)
 	}
 
 	return res.TransformToSuccessOk(transactionModels)
 }
 
-func (t *TransactionService) deleteTransactionByUser(ctx *gin.Context, req *transactionusecase.DeleteReq) interface{} {
+// getTransaction return one transaction of user
+func (t *TransactionService) getTransaction(ctx context.Context, req *transactionusecase.GetTransactionByIdReq) *httpresponse.Response {
+	ctx, span := tracing.Start(ctx, "TransactionService.getTransaction")
+	defer span.End()
+
 	res := &httpresponse.Response{}
 	userId := getUserIdFromContext(ctx)
 
+	transactionDetail, err := t.useCase.transaction.GetByTransactionId.Execute(ctx, req)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	if transactionDetail.UserId != userId {
+		// same as not found, other users transaction ids are not revealed
+		return res.TransformToError(exception.Newf(exception.NotFound, "TRANSACTION_NOT_FOUND", "transaction %d not found", req.Id))
+	}
+
+	return res.TransformToSuccessOk(transactionDetail)
+}
+
+// deleteTransactionByUser reverse transaction and its fee, they are removed and their effect on balance is undone
+// used by rest delete and grpc ReverseTransaction
+func (t *TransactionService) deleteTransactionByUser(ctx context.Context, req *transactionusecase.DeleteReq) *httpresponse.Response {
+	ctx, span := tracing.Start(ctx, "TransactionService.deleteTransactionByUser")
+	defer span.End()
+
+	res := &httpresponse.Response{}
+	userId := getUserIdFromContext(ctx)
+
+	err := req.Validate()
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
 	accountDetail, err := t.useCase.user.GetAccountByAccountId.Execute(ctx, &userusecase.GetAccountByAccountIdReq{
 		AccountId: req.AccountId,
 	})
 
 	if err != nil {
 		// account not found or user account owner is not same as url param <user_id>
-		return res.TransformToNotFound(err.Error())
+		return res.TransformToError(err)
 	}
 
 	if accountDetail.UserId != userId {
 		// user account owner is not same as url param <user_id>
-		return res.TransformToBadRequest("user account owner is not same as url param <user_id>")
+		return res.TransformToError(userusecase.ErrAccountOwner)
 	}
 
 	// open session tx pointer, to control from outside
@@ -208,27 +410,54 @@ func (t *TransactionService) deleteTransactionByUser(ctx *gin.Context, req *tran
 	transactionDetail, asyncJobDeleteTransaction, err := t.useCase.transaction.Delete.Execute(ctx, req, sessionTx)
 	if err != nil {
 		sessionTx.Rollback()
-		return res.TransformToInternalServerError(err.Error())
+		return res.TransformToError(err)
 	}
 
-	// update balance
+	if transactionDetail.AccountId != req.AccountId {
+		_ = sessionTx.Rollback().Error
+		abortJobs(asyncJobDeleteTransaction)
+		return res.TransformToError(exception.Newf(exception.NotFound, "TRANSACTION_NOT_FOUND", "transaction %d not found in account %d", req.TransactionId, req.AccountId))
+	}
+
+	fee := float32(0)
+	if transactionDetail.FeeTransaction != nil {
+		fee = transactionDetail.FeeTransaction.Amount
+	}
+
+	// update balance, deposit is taken back, withdraw and fee are given back
 	asyncJobUpdateBalance, err := t.useCase.user.UpdateBalanceAccount.Execute(ctx, &userusecase.UpdateBalanceAccountReq{
 		AccountId:       req.AccountId,
 		OldBalance:      accountDetail.Balance,
 		Amount:          transactionDetail.Amount,
 		TransactionType: transactionDetail.TransactionType,
+		Fee:             fee,
+		Reverse:         true,
 	}, sessionTx)
 
 	if err != nil {
 		sessionTx.Rollback()
-		return res.TransformToInternalServerError(err.Error())
+		abortJobs(asyncJobDeleteTransaction)
+		return res.TransformToError(err)
 	}
 
-	sessionTx.Commit()
+	events, err := t.enqueueTransactionEvents(ctx, sessionTx, models.WEBHOOKEVENTTRANSACTIONDELETED, fee, transactionDetail)
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		abortJobs(asyncJobDeleteTransaction, asyncJobUpdateBalance)
+		return res.TransformToError(err)
+	}
+
+	err = sessionTx.Commit().Error
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		abortJobs(asyncJobDeleteTransaction, asyncJobUpdateBalance)
+		return res.TransformToError(err)
+	}
 
 	defer func(ctx context.Context) {
-		asyncJobDeleteTransaction.Run(ctx)
-		asyncJobUpdateBalance.Run(ctx)
+		asyncJobDeleteTransaction.Commit()
+		asyncJobUpdateBalance.Commit()
+		t.publishEvents(ctx, transactionDetail.UserId, events)
 	}(ctx)
 
 	// delete should return status code
@@ -237,3 +466,295 @@ func (t *TransactionService) deleteTransactionByUser(ctx *gin.Context, req *tran
 	// status accepted
 	return res.TransformToDeletedSuccess(transactionDetail)
 }
+
+type TransferReq struct {
+	AccountId       uint32  `json:"account_id"`
+	TargetAccountId uint32  `json:"target_account_id"`
+	Amount          float32 `json:"amount"`
+	Memo            string  `json:"memo"`
+	Counterparty    string  `json:"counterparty"`
+}
+
+func (r *TransferReq) Validate() error {
+	return validation.New().
+		Field("account_id", r.AccountId, validation.Required()).
+		Field("target_account_id", r.TargetAccountId, validation.Required(), validation.Custom("not_equal", func(value any) string {
+			if value == r.AccountId {
+				return "target_account_id must be other than account_id"
+			}
+			return ""
+		})).
+		Field("amount", r.Amount, validation.Amount(models.TRANSACTIONAMOUNTMIN, models.TRANSACTIONAMOUNTMAX)).
+		Err()
+}
+
+// transferBetweenAccounts run one withdraw on account and one deposit on target account
+// both legs in same sessionTx, data is [withdraw, deposit]
+func (t *TransactionService) transferBetweenAccounts(ctx context.Context, req *TransferReq) *httpresponse.Response {
+	ctx, span := tracing.Start(ctx, "TransactionService.transferBetweenAccounts")
+	defer span.End()
+
+	res := &httpresponse.Response{}
+	// open session tx pointer, to control from outside
+	sessionTx := t.repo.transaction.PersistentRepo.BeginTx()
+	legs, asyncJobs, legEvents, err := t.transferInTx(ctx, req, sessionTx)
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		return res.TransformToError(err)
+	}
+
+	err = sessionTx.Commit().Error
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		abortJobs(asyncJobs...)
+		return res.TransformToError(err)
+	}
+
+	defer func(ctx context.Context) {
+		for _, asyncJob := range asyncJobs {
+			asyncJob.Commit()
+		}
+		for i, legDetail := range legs {
+			t.publishEvents(ctx, legDetail.UserId, legEvents[i])
+		}
+	}(ctx)
+
+	return res.TransformToCreatedSuccess(legs)
+}
+
+// transferInTx create both legs of transfer and their balance updates in sessionTx, legs are [withdraw, deposit]
+// events of each leg go to its owner, target account can be of other user
+func (t *TransactionService) transferInTx(ctx context.Context, req *TransferReq, sessionTx *gorm.DB) ([]*aggregate.TransactionByDetails, []*repo_pool_async.Job, [][]*webhookusecase.Event, error) {
+	userId := getUserIdFromContext(ctx)
+
+	err := req.Validate()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	accountDetail, err := t.useCase.user.GetAccountByAccountId.Execute(ctx, &userusecase.GetAccountByAccountIdReq{
+		AccountId: req.AccountId,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if accountDetail.UserId != userId {
+		// user account owner is not same as url param <user_id>
+		return nil, nil, nil, userusecase.ErrAccountOwner
+	}
+
+	targetAccountDetail, err := t.useCase.user.GetAccountByAccountId.Execute(ctx, &userusecase.GetAccountByAccountIdReq{
+		AccountId: req.TargetAccountId,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if accountDetail.AvailableBalance < req.Amount {
+		return nil, nil, nil, userusecase.ErrInsufficientBalance
+	}
+
+	withdrawDetail, asyncJobWithdraw, err := t.useCase.transaction.Create.Execute(ctx, &transaction.CreateReq{
+		UserId:          userId,
+		BankType:        accountDetail.Bank,
+		AccountId:       accountDetail.Id,
+		Amount:          req.Amount,
+		TransactionType: models.TRANSACTIONTYPEWITHDRAW,
+		Memo:            req.Memo,
+		Counterparty:    req.Counterparty,
+	}, sessionTx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	asyncJobUpdateBalance, err := t.useCase.user.UpdateBalanceAccount.Execute(ctx, &userusecase.UpdateBalanceAccountReq{
+		AccountId:       accountDetail.Id,
+		OldBalance:      accountDetail.Balance,
+		Amount:          req.Amount,
+		TransactionType: models.TRANSACTIONTYPEWITHDRAW,
+	}, sessionTx)
+	if err != nil {
+		abortJobs(asyncJobWithdraw)
+		return nil, nil, nil, err
+	}
+
+	depositDetail, asyncJobDeposit, err := t.useCase.transaction.Create.Execute(ctx, &transaction.CreateReq{
+		UserId:          targetAccountDetail.UserId,
+		BankType:        targetAccountDetail.Bank,
+		AccountId:       targetAccountDetail.Id,
+		Amount:          req.Amount,
+		TransactionType: models.TRANSACTIONTYPEDEPOSIT,
+		Memo:            req.Memo,
+		Counterparty:    req.Counterparty,
+	}, sessionTx)
+	if err != nil {
+		abortJobs(asyncJobWithdraw, asyncJobUpdateBalance)
+		return nil, nil, nil, err
+	}
+
+	asyncJobUpdateTargetBalance, err := t.useCase.user.UpdateBalanceAccount.Execute(ctx, &userusecase.UpdateBalanceAccountReq{
+		AccountId:       targetAccountDetail.Id,
+		OldBalance:      targetAccountDetail.Balance,
+		Amount:          req.Amount,
+		TransactionType: models.TRANSACTIONTYPEDEPOSIT,
+	}, sessionTx)
+	if err != nil {
+		abortJobs(asyncJobWithdraw, asyncJobUpdateBalance, asyncJobDeposit)
+		return nil, nil, nil, err
+	}
+	asyncJobs := []*repo_pool_async.Job{asyncJobWithdraw, asyncJobUpdateBalance, asyncJobDeposit, asyncJobUpdateTargetBalance}
+
+	legs := []*aggregate.TransactionByDetails{withdrawDetail, depositDetail}
+	legEvents := make([][]*webhookusecase.Event, len(legs))
+	for i, legDetail := range legs {
+		legEvents[i], err = t.enqueueTransactionEvents(ctx, sessionTx, models.WEBHOOKEVENTTRANSACTIONCREATED, 0, legDetail)
+		if err != nil {
+			abortJobs(asyncJobs...)
+			return nil, nil, nil, err
+		}
+	}
+
+	return legs, asyncJobs, legEvents, nil
+}
+
+// getAccount return balance and available balance (balance - authorized holds) of account
+func (t *TransactionService) getAccount(ctx context.Context, req *userusecase.GetAccountByAccountIdReq) *httpresponse.Response {
+	ctx, span := tracing.Start(ctx, "TransactionService.getAccount")
+	defer span.End()
+
+	res := &httpresponse.Response{}
+	userId := getUserIdFromContext(ctx)
+
+	err := req.Validate()
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	accountDetail, err := t.useCase.user.GetAccountByAccountId.Execute(ctx, req)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	if accountDetail.UserId != userId {
+		// user account owner is not same as url param <user_id>
+		return res.TransformToError(userusecase.ErrAccountOwner)
+	}
+
+	return res.TransformToSuccessOk(accountDetail)
+}
+
+// createSystemTransaction create transaction and update balance of account in sessionTx
+// for transactions posted by system (interest, charges), caller commit then commit jobs and publish events
+func (t *TransactionService) createSystemTransaction(ctx context.Context, req *transaction.CreateReq, allowOverLimit bool, sessionTx *gorm.DB) (*aggregate.TransactionByDetails, []*repo_pool_async.Job, []*webhookusecase.Event, error) {
+	ctx, span := tracing.Start(ctx, "TransactionService.createSystemTransaction")
+	defer span.End()
+
+	// balance use case update cache from cache entry, make sure it exist
+	accountDetail, err := t.useCase.user.GetAccountByAccountId.Execute(ctx, &userusecase.GetAccountByAccountIdReq{
+		AccountId: req.AccountId,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	req.UserId = accountDetail.UserId
+	req.BankType = accountDetail.Bank
+	transactionDetail, asyncJobCreateTransaction, err := t.useCase.transaction.Create.Execute(ctx, req, sessionTx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	asyncJobUpdateBalance, err := t.useCase.user.UpdateBalanceAccount.Execute(ctx, &userusecase.UpdateBalanceAccountReq{
+		AccountId:       req.AccountId,
+		OldBalance:      accountDetail.Balance,
+		Amount:          req.Amount,
+		TransactionType: req.TransactionType,
+		AllowOverLimit:  allowOverLimit,
+	}, sessionTx)
+	if err != nil {
+		abortJobs(asyncJobCreateTransaction)
+		return nil, nil, nil, err
+	}
+
+	events, err := t.enqueueTransactionEvents(ctx, sessionTx, models.WEBHOOKEVENTTRANSACTIONCREATED, 0, transactionDetail)
+	if err != nil {
+		abortJobs(asyncJobCreateTransaction, asyncJobUpdateBalance)
+		return nil, nil, nil, err
+	}
+
+	return transactionDetail, []*repo_pool_async.Job{asyncJobCreateTransaction, asyncJobUpdateBalance}, events, nil
+}
+
+// enqueueTransactionEvents write webhook deliveries of transaction and its balance change in sessionTx
+// fee transaction of detail is sent as its own event, fee is included in balance change
+// events are returned to be published by publishEvents after commit
+func (t *TransactionService) enqueueTransactionEvents(ctx context.Context, sessionTx *gorm.DB, eventType string, fee float32, transactionDetail *aggregate.TransactionByDetails) ([]*webhookusecase.Event, error) {
+	ctx, span := tracing.Start(ctx, "TransactionService.enqueueTransactionEvents")
+	defer span.End()
+
+	now := time.Now()
+	change := transactionDetail.Amount
+	if models.IsDebitTransactionType(transactionDetail.TransactionType) {
+		change = -change
+	}
+	if eventType == models.WEBHOOKEVENTTRANSACTIONDELETED {
+		change, fee = -change, -fee
+	}
+
+	events := []*webhookusecase.Event{webhookusecase.NewTransactionEvent(eventType, transactionDetail, now)}
+	if transactionDetail.FeeTransaction != nil {
+		events = append(events, webhookusecase.NewTransactionEvent(eventType, transactionDetail.FeeTransaction, now))
+	}
+	events = append(events, webhookusecase.NewBalanceEvent(&webhookusecase.BalanceChange{
+		AccountId:       transactionDetail.AccountId,
+		UserId:          transactionDetail.UserId,
+		TransactionId:   transactionDetail.Id,
+		TransactionType: transactionDetail.TransactionType,
+		Change:          change - fee,
+	}, now))
+
+	_, err := t.useCase.webhook.Enqueue.Execute(ctx, &webhookusecase.EnqueueEventsReq{
+		UserId: transactionDetail.UserId,
+		Events: events,
+		Now:    now,
+	}, sessionTx)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// publishEvents push committed events to live stream of user
+// stream is best effort, webhook deliveries are durable copy
+func (t *TransactionService) publishEvents(ctx context.Context, userId uint32, events []*webhookusecase.Event) {
+	ctx, span := tracing.Start(ctx, "TransactionService.publishEvents")
+	defer span.End()
+
+	for _, event := range events {
+		transactionDetail, ok := event.Data.(*aggregate.TransactionByDetails)
+		if ok {
+			metrics.Transactions.WithLabelValues(strings.TrimPrefix(event.Type, "transaction."), transactionDetail.TransactionType, transactionDetail.Bank).Inc()
+		}
+
+		data, err := json.Marshal(event)
+		if err != nil {
+			logging.FromContext(ctx, t.logger).Error("[TransactionService-publishEvents]", zap.String("Error", err.Error()))
+			continue
+		}
+		_, err = t.broker.Publish(ctx, streamTopic(userId), event.Type, data)
+		if err != nil {
+			logging.FromContext(ctx, t.logger).Error("[TransactionService-publishEvents]", zap.Uint32("user_id", userId), zap.String("Error", err.Error()))
+		}
+	}
+}
+
+// abortJobs pushed in rolled back sessionTx, they are discarded now instead of after commit timeout
+// jobs which are not pushed (nil) are skipped
+func abortJobs(jobs ...*repo_pool_async.Job) {
+	for _, job := range jobs {
+		if job != nil {
+			job.Abort()
+		}
+	}
+}
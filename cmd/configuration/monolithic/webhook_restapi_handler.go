@@ -0,0 +1,161 @@
+package monolithic
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/composite"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	webhookusecase "money_forward_code_challenge/internal/domain/transaction/usecase/webhook"
+	"money_forward_code_challenge/internal/infrastructure/data-provider/mysql"
+	"net/http"
+	"strconv"
+)
+
+type WebhookHandler struct {
+	routerGroup     *gin.RouterGroup
+	appServerConfig *AppConfigServer
+	service         *WebhookService
+	logger          *zap.Logger
+}
+
+func InitWebhookRouter(logger *zap.Logger, routerGroup *gin.RouterGroup, appServerConfig *AppConfigServer) {
+	w := &WebhookHandler{
+		routerGroup:     routerGroup,
+		appServerConfig: appServerConfig,
+		logger:          logger,
+	}
+	w.service = appServerConfig.getWebhookService()
+	w.InitRouter()
+}
+
+// getWebhookService is shared by rest api and webhook delivery worker
+func (a *AppConfigServer) getWebhookService() *WebhookService {
+	if a.webhookService != nil {
+		return a.webhookService
+	}
+
+	webhookRepoComposite := &composite.WebhookRepoComposite{
+		PersistentRepo: mysql.NewMysqlWebhookRepo(a.gormDB, a.logger),
+	}
+
+	a.webhookService = NewWebhookService(webhookRepoComposite, a.logger, a.config.Webhook.Timeout)
+	return a.webhookService
+}
+
+func (w *WebhookHandler) InitRouter() {
+	w.routerGroup.POST("/", w.createSubscription)
+	w.routerGroup.GET("/", w.getSubscriptions)
+	w.routerGroup.DELETE("/:webhook_id", w.deleteSubscription)
+	// delivery log
+	w.routerGroup.GET("/:webhook_id/deliveries", w.getDeliveries)
+	w.routerGroup.GET("/:webhook_id/deliveries/:delivery_id/attempts", w.getAttempts)
+}
+
+func (w *WebhookHandler) createSubscription(ginCtx *gin.Context) {
+	if !w.bindUserId(ginCtx) {
+		return
+	}
+
+	var req webhookusecase.CreateSubscriptionReq
+	err := ginCtx.ShouldBindJSON(&req)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := w.service.createSubscription(ginCtx, &req)
+	writeResponse(ginCtx, response)
+}
+
+func (w *WebhookHandler) getSubscriptions(ginCtx *gin.Context) {
+	if !w.bindUserId(ginCtx) {
+		return
+	}
+
+	response := w.service.getSubscriptions(ginCtx)
+	writeResponse(ginCtx, response)
+}
+
+func (w *WebhookHandler) deleteSubscription(ginCtx *gin.Context) {
+	subscriptionId, ok := w.bindIds(ginCtx)
+	if !ok {
+		return
+	}
+
+	response := w.service.deleteSubscription(ginCtx, &webhookusecase.DeleteSubscriptionReq{
+		SubscriptionId: subscriptionId,
+	})
+	writeResponse(ginCtx, response)
+}
+
+func (w *WebhookHandler) getDeliveries(ginCtx *gin.Context) {
+	subscriptionId, ok := w.bindIds(ginCtx)
+	if !ok {
+		return
+	}
+
+	type QueryOption struct {
+		Limit  int `form:"limit"`
+		Offset int `form:"offset"`
+	}
+	var queryOption QueryOption
+	err := ginCtx.ShouldBindQuery(&queryOption)
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := w.service.getDeliveries(ginCtx, &webhookusecase.GetDeliveriesReq{
+		SubscriptionId: subscriptionId,
+		Query: &repo.Query{
+			Limit:  queryOption.Limit,
+			Offset: queryOption.Offset,
+		},
+	})
+	writeResponse(ginCtx, response)
+}
+
+func (w *WebhookHandler) getAttempts(ginCtx *gin.Context) {
+	subscriptionId, ok := w.bindIds(ginCtx)
+	if !ok {
+		return
+	}
+
+	deliveryIdParam, err := strconv.Atoi(ginCtx.Param("delivery_id"))
+	if err != nil || deliveryIdParam <= 0 {
+		writeError(ginCtx, http.StatusBadRequest, "delivery_id must be greater than 0")
+		return
+	}
+
+	response := w.service.getAttempts(ginCtx, &webhookusecase.GetAttemptsReq{
+		SubscriptionId: subscriptionId,
+		DeliveryId:     uint32(deliveryIdParam),
+	})
+	writeResponse(ginCtx, response)
+}
+
+// bindUserId parse <user_id> into context, it write bad request when invalid
+func (w *WebhookHandler) bindUserId(ginCtx *gin.Context) bool {
+	userIdParam, err := getUserIdURLParam(ginCtx, "id")
+	if err != nil {
+		writeError(ginCtx, http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	setUserIdToContext(ginCtx, userIdParam)
+	return true
+}
+
+// bindIds parse <user_id> into context and return <webhook_id>, it write bad request when invalid
+func (w *WebhookHandler) bindIds(ginCtx *gin.Context) (uint32, bool) {
+	if !w.bindUserId(ginCtx) {
+		return 0, false
+	}
+
+	subscriptionIdParam, err := strconv.Atoi(ginCtx.Param("webhook_id"))
+	if err != nil || subscriptionIdParam <= 0 {
+		writeError(ginCtx, http.StatusBadRequest, "webhook_id must be greater than 0")
+		return 0, false
+	}
+	return uint32(subscriptionIdParam), true
+}
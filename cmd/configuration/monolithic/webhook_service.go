@@ -0,0 +1,186 @@
+package monolithic
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/composite"
+	"money_forward_code_challenge/internal/common/httpresponse"
+	"money_forward_code_challenge/internal/common/logging"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	webhookusecase "money_forward_code_challenge/internal/domain/transaction/usecase/webhook"
+	"money_forward_code_challenge/pkgs/webhook"
+	"time"
+)
+
+type WebhookService struct {
+	repo struct {
+		webhook *composite.WebhookRepoComposite
+	}
+	useCase struct {
+		webhook *composite.WebhookUseCaseComposite
+	}
+	sender *webhook.Sender
+	logger *zap.Logger
+}
+
+func newWebhookUseCaseComposite(webhookRepoComposite *composite.WebhookRepoComposite, logger *zap.Logger) *composite.WebhookUseCaseComposite {
+	return &composite.WebhookUseCaseComposite{
+		CreateSubscription: webhookusecase.NewCreateSubscriptionUseCase(webhookRepoComposite.PersistentRepo, logger),
+		DeleteSubscription: webhookusecase.NewDeleteSubscriptionUseCase(webhookRepoComposite.PersistentRepo, logger),
+		GetSubscriptions:   webhookusecase.NewGetSubscriptions(webhookRepoComposite.PersistentRepo, logger),
+		Enqueue:            webhookusecase.NewEnqueueEventsUseCase(webhookRepoComposite.PersistentRepo, logger),
+		GetDue:             webhookusecase.NewGetDueDeliveries(webhookRepoComposite.PersistentRepo, logger),
+		Claim:              webhookusecase.NewClaimDeliveryUseCase(webhookRepoComposite.PersistentRepo, logger),
+		RecordAttempt:      webhookusecase.NewRecordAttemptUseCase(webhookRepoComposite.PersistentRepo, logger),
+		GetDeliveries:      webhookusecase.NewGetDeliveries(webhookRepoComposite.PersistentRepo, logger),
+		GetAttempts:        webhookusecase.NewGetAttempts(webhookRepoComposite.PersistentRepo, logger),
+	}
+}
+
+func NewWebhookService(webhookRepoComposite *composite.WebhookRepoComposite, logger *zap.Logger, deliveryTimeout time.Duration) *WebhookService {
+	return &WebhookService{
+		logger: logger,
+		sender: webhook.NewSender(deliveryTimeout),
+		repo: struct {
+			webhook *composite.WebhookRepoComposite
+		}{
+			webhook: webhookRepoComposite,
+		},
+		useCase: struct {
+			webhook *composite.WebhookUseCaseComposite
+		}{
+			webhook: newWebhookUseCaseComposite(webhookRepoComposite, logger),
+		},
+	}
+}
+
+func (w *WebhookService) createSubscription(ctx context.Context, req *webhookusecase.CreateSubscriptionReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	req.UserId = getUserIdFromContext(ctx)
+
+	subscription, err := w.useCase.webhook.CreateSubscription.Execute(ctx, req, nil)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	// secret is only returned here
+	return res.TransformToCreatedSuccess(subscription)
+}
+
+func (w *WebhookService) getSubscriptions(ctx context.Context) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	subscriptions, err := w.useCase.webhook.GetSubscriptions.Execute(ctx, &webhookusecase.GetSubscriptionsReq{
+		UserId: getUserIdFromContext(ctx),
+	})
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToSuccessOk(subscriptions)
+}
+
+func (w *WebhookService) deleteSubscription(ctx context.Context, req *webhookusecase.DeleteSubscriptionReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	req.UserId = getUserIdFromContext(ctx)
+
+	err := w.useCase.webhook.DeleteSubscription.Execute(ctx, req, nil)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToDeletedSuccess(req)
+}
+
+func (w *WebhookService) getDeliveries(ctx context.Context, req *webhookusecase.GetDeliveriesReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	req.UserId = getUserIdFromContext(ctx)
+
+	deliveries, err := w.useCase.webhook.GetDeliveries.Execute(ctx, req)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToSuccessOk(deliveries)
+}
+
+func (w *WebhookService) getAttempts(ctx context.Context, req *webhookusecase.GetAttemptsReq) *httpresponse.Response {
+	res := &httpresponse.Response{}
+	req.UserId = getUserIdFromContext(ctx)
+
+	attempts, err := w.useCase.webhook.GetAttempts.Execute(ctx, req)
+	if err != nil {
+		return res.TransformToError(err)
+	}
+
+	return res.TransformToSuccessOk(attempts)
+}
+
+// deliverDue is called by worker, one attempt per due delivery each call
+// failed attempts are retried by later calls after backoff
+func (w *WebhookService) deliverDue(ctx context.Context, now time.Time, limit int) int {
+	deliveries, err := w.useCase.webhook.GetDue.Execute(ctx, &webhookusecase.GetDueDeliveriesReq{
+		Now:   now,
+		Limit: limit,
+	})
+	if err != nil {
+		logging.FromContext(ctx, w.logger).Error("[WebhookService-deliverDue]", zap.String("Error", err.Error()))
+		return 0
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		if w.deliver(ctx, delivery, now) {
+			delivered++
+		}
+	}
+	return delivered
+}
+
+func (w *WebhookService) deliver(ctx context.Context, delivery *models.WebhookDelivery, now time.Time) bool {
+	subscription, err := w.useCase.webhook.Claim.Execute(ctx, &webhookusecase.ClaimDeliveryReq{
+		Delivery: delivery,
+		Now:      now,
+	}, nil)
+
+	result := &webhook.Result{Err: err}
+	if err == nil {
+		result = w.sender.Send(ctx, &webhook.Request{
+			URL:        subscription.URL,
+			Secret:     subscription.Secret,
+			EventType:  delivery.EventType,
+			DeliveryId: delivery.ID,
+			Body:       []byte(delivery.Payload),
+			Now:        time.Now(),
+		})
+	} else if errors.Is(err, webhookusecase.ErrSubscriptionNotFound) {
+		// subscription is deleted, no receiver to retry
+		delivery.Attempts = webhookusecase.MaxAttempts - 1
+	} else {
+		// claimed by other worker
+		logging.FromContext(ctx, w.logger).Info("[WebhookService-deliver]", zap.Uint32("delivery_id", delivery.ID), zap.String("Skip", err.Error()))
+		return false
+	}
+
+	// attempt is logged in its own tx, delivery is not retried before this is saved
+	sessionTx := w.repo.webhook.PersistentRepo.BeginTx()
+	_, err = w.useCase.webhook.RecordAttempt.Execute(ctx, &webhookusecase.RecordAttemptReq{
+		Delivery: delivery,
+		Result:   result,
+		Now:      time.Now(),
+	}, sessionTx)
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		logging.FromContext(ctx, w.logger).Error("[WebhookService-deliver]", zap.Uint32("delivery_id", delivery.ID), zap.String("Error", err.Error()))
+		return false
+	}
+
+	err = sessionTx.Commit().Error
+	if err != nil {
+		_ = sessionTx.Rollback().Error
+		logging.FromContext(ctx, w.logger).Error("[WebhookService-deliver]", zap.Uint32("delivery_id", delivery.ID), zap.String("Error", err.Error()))
+		return false
+	}
+
+	return result.Delivered()
+}
@@ -0,0 +1,43 @@
+package monolithic
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+// WebhookDeliveryWorker send due webhook deliveries from persistent queue
+// failed deliveries are retried with exponential backoff until attempts are exhausted
+type WebhookDeliveryWorker struct {
+	service   *WebhookService
+	logger    *zap.Logger
+	interval  time.Duration
+	batchSize int
+}
+
+func InitWebhookDeliveryWorker(logger *zap.Logger, appServerConfig *AppConfigServer) *WebhookDeliveryWorker {
+	return &WebhookDeliveryWorker{
+		service:   appServerConfig.getWebhookService(),
+		logger:    logger,
+		interval:  appServerConfig.config.Workers.Webhook.Interval,
+		batchSize: appServerConfig.config.Workers.Webhook.BatchSize,
+	}
+}
+
+func (w *WebhookDeliveryWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		delivered := w.service.deliverDue(ctx, time.Now(), w.batchSize)
+		if delivered > 0 {
+			w.logger.Info("[WebhookDeliveryWorker-Run]", zap.Int("delivered", delivered))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
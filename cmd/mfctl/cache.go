@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/infrastructure/data-provider/mysql"
+	"money_forward_code_challenge/internal/infrastructure/data-provider/redis"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// keys of redis cache repos, they are dropped before reload
+var cacheKeys = []string{"accounts", "transactions_detail"}
+
+const cacheRebuildBatch = 500
+
+// forEachId page ids of query in id order, so rows inserted meanwhile do not shift pages
+func forEachId(ctx context.Context, query *gorm.DB, idColumn string, fn func(id uint32) error) error {
+	var afterId uint32
+	for {
+		var ids []uint32
+		err := query.Session(&gorm.Session{}).WithContext(ctx).
+			Where(idColumn+" > ?", afterId).
+			Order(idColumn).
+			Limit(cacheRebuildBatch).
+			Pluck(idColumn, &ids).Error
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			err = fn(id)
+			if err != nil {
+				return err
+			}
+		}
+		if len(ids) < cacheRebuildBatch {
+			return nil
+		}
+		afterId = ids[len(ids)-1]
+	}
+}
+
+func runCache(ctx context.Context, args []string) error {
+	if len(args) != 1 || args[0] != "rebuild" {
+		return fmt.Errorf("%w: cache need rebuild", errUsage)
+	}
+
+	app, err := newApp(true)
+	if err != nil {
+		return err
+	}
+	defer app.Close()
+
+	logger := zap.NewNop()
+	userRepo := mysql.NewMysqlUserRepo(app.GormDB(), logger)
+	transactionRepo := mysql.NewMysqlTransactionRepo(app.GormDB(), logger)
+	userCacheRepo := redis.NewRedisUserCacheRepo(app.RedisDB(), logger)
+	transactionCacheRepo := redis.NewRedisTransactionCacheRepo(app.RedisDB(), logger)
+
+	err = app.RedisDB().Del(ctx, cacheKeys...).Err()
+	if err != nil {
+		return err
+	}
+
+	accounts := 0
+	err = forEachId(ctx, app.GormDB().Table(models.ACCOUNTTABLE).Where(models.ACCOUNTCOLUMN_DELETED_AT+" IS NULL"), models.ACCOUNTCOLUMN_ID, func(id uint32) error {
+		accountDetail, err := userRepo.GetAccountByAccountId(ctx, id)
+		if err != nil {
+			return err
+		}
+		accounts++
+		return userCacheRepo.SetAccount(ctx, accountDetail)
+	})
+	if err != nil {
+		return err
+	}
+
+	transactions := 0
+	err = forEachId(ctx, app.GormDB().Table(models.TRANSACTIONTABLE).Where(models.TRANSACTIONCOLUMN_DELETED+" = ?", false), models.TRANSACTIONCOLUMN_ID, func(id uint32) error {
+		transactionDetail, err := transactionRepo.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+		transactions++
+		return transactionCacheRepo.Set(ctx, transactionDetail)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("cached %d accounts, %d transactions\n", accounts, transactions)
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"money_forward_code_challenge/cmd/configuration/monolithic"
+	"money_forward_code_challenge/internal/common/config"
+	"os"
+
+	"go.uber.org/zap"
+)
+
+// mfctl is admin tool of transaction service
+// it connect to same mysql and redis as server, with same config (CONFIG_FILE, MYSQL_*, REDIS_*)
+
+const usage = `usage: mfctl <command> [flags]
+
+commands:
+  migrate up|down|status   apply, revert or show versioned migrations
+  migrate baseline <v>     mark migrations up to v applied, for database created before migrations
+  migrate force <v>        clear dirty flag of v after fixing failed migration by hand
+  seed [--demo]            insert random users, accounts and transactions (or demo data)
+  cache rebuild            reload redis cache of accounts and transactions from mysql
+  reconcile                compare balances and held amounts with transactions and holds
+  user show <user_id>      print user with accounts
+
+run "mfctl <command> -h" for flags of command
+`
+
+// errUsage is returned for unknown command or wrong arguments, usage is printed
+var errUsage = errors.New("invalid usage")
+
+type command func(ctx context.Context, args []string) error
+
+var commands = map[string]command{
+	"migrate":   runMigrate,
+	"seed":      runSeed,
+	"cache":     runCache,
+	"reconcile": runReconcile,
+	"user":      runUser,
+}
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:]))
+}
+
+func run(ctx context.Context, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+
+	err := cmd(ctx, args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	if errors.Is(err, errUsage) {
+		fmt.Fprintf(os.Stderr, "%s\n\n%s", err, usage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		return 1
+	}
+	return 0
+}
+
+// newApp connect mysql, and redis when command need cache
+// config is same as server (CONFIG_FILE and environment), flags of mfctl are its own
+func newApp(withRedis bool) (*monolithic.AppConfigServer, error) {
+	cfg, err := config.Load("mfctl", nil, os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	// only warnings, output of command is on stdout
+	loggerConfig := zap.NewProductionConfig()
+	loggerConfig.Level = zap.NewAtomicLevelAt(zap.WarnLevel)
+	logger, err := loggerConfig.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	app := &monolithic.AppConfigServer{}
+	app.SetLogger(logger)
+	app.SetConfig(cfg)
+	err = app.CreateGormMysqlDB()
+	if err != nil {
+		return nil, fmt.Errorf("connect mysql: %w", err)
+	}
+	if withRedis {
+		err = app.CreateRedisDB()
+		if err != nil {
+			return nil, fmt.Errorf("connect redis: %w", err)
+		}
+	}
+	return app, nil
+}
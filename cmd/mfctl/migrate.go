@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+func runMigrate(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: migrate need up, down, status, baseline <version> or force <version>", errUsage)
+	}
+
+	flags := flag.NewFlagSet("migrate "+args[0], flag.ContinueOnError)
+	steps := flags.Int("steps", 1, "number of migrations reverted by down")
+	yes := flags.Bool("yes", false, "confirm migrate down, reverted tables are dropped")
+	err := flags.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	app, err := newApp(false)
+	if err != nil {
+		return err
+	}
+	defer app.Close()
+	runner, err := app.MigrationRunner()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := app.MigrateUp(ctx)
+		for _, migration := range applied {
+			fmt.Printf("applied %d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migrations applied\n", len(applied))
+		return nil
+	case "down":
+		if !*yes {
+			return fmt.Errorf("migrate down drop tables and columns of %d migrations, run again with --yes", *steps)
+		}
+		reverted, err := runner.Down(ctx, *steps)
+		for _, migration := range reverted {
+			fmt.Printf("reverted %d_%s\n", migration.Version, migration.Name)
+		}
+		return err
+	case "status":
+		statuses, err := runner.Status(ctx)
+		if err != nil {
+			return err
+		}
+		writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(writer, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "-"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(writer, "%d\t%s\t%s\t%s\n", status.Version, status.Name, status.State, appliedAt)
+		}
+		return writer.Flush()
+	case "baseline", "force":
+		if flags.NArg() != 1 {
+			return fmt.Errorf("%w: migrate %s need <version>", errUsage, args[0])
+		}
+		version, err := strconv.ParseUint(flags.Arg(0), 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: version %q is not valid", errUsage, flags.Arg(0))
+		}
+		if args[0] == "baseline" {
+			err = runner.Baseline(ctx, version)
+		} else {
+			err = runner.Force(ctx, version)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s %d done\n", args[0], version)
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown migrate command %q", errUsage, args[0])
+	}
+}
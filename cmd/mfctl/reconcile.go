@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"os"
+	"text/tabwriter"
+)
+
+// errMismatch make reconcile exit with 1, so it can run in cron or ci
+var errMismatch = errors.New("accounts do not reconcile")
+
+// accountLedger is one account with totals computed from its transactions and holds
+type accountLedger struct {
+	AccountId  uint32
+	UserId     uint32
+	Balance    float64
+	HeldAmount float64
+	// deposits and interest minus withdraws, fees and overdraft interest of not deleted transactions
+	LedgerBalance float64
+	// amount of authorized holds
+	AuthorizedHolds float64
+}
+
+type reconcileMismatch struct {
+	ledger *accountLedger
+	field  string
+	stored float64
+	want   float64
+}
+
+func runReconcile(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	tolerance := flags.Float64("tolerance", 0.01, "difference which is still reconciled, balances are float")
+	userId := flags.Uint("user", 0, "only accounts of user, 0 is all users")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	app, err := newApp(false)
+	if err != nil {
+		return err
+	}
+	defer app.Close()
+
+	debitTypes := models.TRANSACTIONDEBITTYPES
+	query := app.GormDB().WithContext(ctx).
+		Table(models.ACCOUNTTABLE).
+		Select(fmt.Sprintf(`%s AS account_id, %s AS user_id, %s AS balance, %s AS held_amount,
+			(SELECT COALESCE(SUM(CASE WHEN %s IN ? THEN -%s ELSE %s END), 0) FROM %s
+				WHERE %s = %s AND %s = false) AS ledger_balance,
+			(SELECT COALESCE(SUM(%s.amount), 0) FROM %s
+				WHERE %s = %s AND %s = ?) AS authorized_holds`,
+			models.ACCOUNTCOLUMN_ID, models.ACCOUNTCOLUMN_USER_ID, models.ACCOUNTCOLUMN_BALANCE, models.ACCOUNTCOLUMN_HELD,
+			models.TRANSACTIONCOLUMN_TRANSACTION_TYPE, models.TRANSACTIONCOLUMN_AMOUNT, models.TRANSACTIONCOLUMN_AMOUNT, models.TRANSACTIONTABLE,
+			models.TRANSACTIONCOLUMN_ACCOUNT_ID, models.ACCOUNTCOLUMN_ID, models.TRANSACTIONCOLUMN_DELETED,
+			models.HOLDTABLE, models.HOLDTABLE,
+			models.HOLDCOLUMN_ACCOUNT_ID, models.ACCOUNTCOLUMN_ID, models.HOLDCOLUMN_STATUS,
+		), debitTypes, models.HOLDSTATUSAUTHORIZED).
+		Where(models.ACCOUNTCOLUMN_DELETED_AT + " IS NULL").
+		Order(models.ACCOUNTCOLUMN_ID)
+	if *userId != 0 {
+		query = query.Where(models.ACCOUNTCOLUMN_USER_ID+" = ?", *userId)
+	}
+
+	var ledgers []*accountLedger
+	err = query.Scan(&ledgers).Error
+	if err != nil {
+		return err
+	}
+
+	mismatches := reconcileAccounts(ledgers, *tolerance)
+	if len(mismatches) == 0 {
+		fmt.Printf("%d accounts reconciled\n", len(ledgers))
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "ACCOUNT\tUSER\tFIELD\tSTORED\tEXPECTED\tDIFF")
+	for _, mismatch := range mismatches {
+		fmt.Fprintf(writer, "%d\t%d\t%s\t%.2f\t%.2f\t%.2f\n", mismatch.ledger.AccountId, mismatch.ledger.UserId,
+			mismatch.field, mismatch.stored, mismatch.want, mismatch.stored-mismatch.want)
+	}
+	err = writer.Flush()
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %d of %d accounts", errMismatch, countAccounts(mismatches), len(ledgers))
+}
+
+// reconcileAccounts compare stored balance and held amount with what transactions and holds say
+// opening balance which is not a transaction (old hard coded seed) is reported as balance mismatch
+func reconcileAccounts(ledgers []*accountLedger, tolerance float64) []*reconcileMismatch {
+	mismatches := []*reconcileMismatch{}
+	for _, ledger := range ledgers {
+		if math.Abs(ledger.Balance-ledger.LedgerBalance) > tolerance {
+			mismatches = append(mismatches, &reconcileMismatch{
+				ledger: ledger,
+				field:  "balance",
+				stored: ledger.Balance,
+				want:   ledger.LedgerBalance,
+			})
+		}
+		if math.Abs(ledger.HeldAmount-ledger.AuthorizedHolds) > tolerance {
+			mismatches = append(mismatches, &reconcileMismatch{
+				ledger: ledger,
+				field:  "held_amount",
+				stored: ledger.HeldAmount,
+				want:   ledger.AuthorizedHolds,
+			})
+		}
+	}
+	return mismatches
+}
+
+func countAccounts(mismatches []*reconcileMismatch) int {
+	accounts := map[uint32]bool{}
+	for _, mismatch := range mismatches {
+		accounts[mismatch.ledger.AccountId] = true
+	}
+	return len(accounts)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	seedFirstNames = []string{"AN", "BINH", "CHI", "DUNG", "HA", "KHOA", "LAN", "MINH", "NAM", "THAO"}
+	seedLastNames  = []string{"LE", "NGUYEN", "PHAM", "TRAN", "VO"}
+	seedMemos      = []string{"salary", "coffee", "grocery", "rent", "electricity bill", "taxi", "restaurant", "book store"}
+)
+
+type seedAccount struct {
+	account      *models.Account
+	transactions []*models.Transaction
+}
+
+type seedUser struct {
+	user     *models.User
+	accounts []*seedAccount
+}
+
+func runSeed(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	users := flags.Int("users", 1, "number of users")
+	accounts := flags.Int("accounts", 3, "number of accounts of each user")
+	transactions := flags.Int("tx", 10, "number of transactions of each account")
+	randomSeed := flags.Int64("seed", 0, "random seed, same seed insert same data, 0 is random")
+	demo := flags.Bool("demo", false, "only run demo seed files of migration/seed (user 1 with three accounts)")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if *demo {
+		app, err := newApp(false)
+		if err != nil {
+			return err
+		}
+		defer app.Close()
+		err = app.SeedDemoData(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Println("seeded demo data")
+		return nil
+	}
+	if *users < 1 || *accounts < 0 || *transactions < 0 {
+		return fmt.Errorf("%w: --users must be > 0, --accounts and --tx must be >= 0", errUsage)
+	}
+
+	if *randomSeed == 0 {
+		*randomSeed = time.Now().UnixNano()
+	}
+
+	app, err := newApp(false)
+	if err != nil {
+		return err
+	}
+	defer app.Close()
+
+	plan := planSeed(rand.New(rand.NewSource(*randomSeed)), *users, *accounts, *transactions, time.Now())
+	err = app.GormDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return insertSeed(tx, plan)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("seeded %d users, %d accounts, %d transactions (seed %d)\n",
+		*users, *users**accounts, *users**accounts**transactions, *randomSeed)
+	return nil
+}
+
+// planSeed build users with accounts and their transactions
+// balance of every account is sum of its transactions and never negative, so seed pass reconcile
+func planSeed(r *rand.Rand, users int, accounts int, transactions int, now time.Time) []*seedUser {
+	plan := make([]*seedUser, 0, users)
+	for i := 0; i < users; i++ {
+		user := &seedUser{
+			user: &models.User{
+				FirstName: seedFirstNames[r.Intn(len(seedFirstNames))],
+				LastName:  seedLastNames[r.Intn(len(seedLastNames))],
+			},
+		}
+
+		for j := 0; j < accounts; j++ {
+			bank := models.BANKEXPECTEDS[r.Intn(len(models.BANKEXPECTEDS))]
+			account := &seedAccount{
+				account: &models.Account{
+					Bank: bank,
+					Name: fmt.Sprintf("%s %d", bank, j+1),
+				},
+			}
+
+			// one transaction every few hours up to now, oldest first
+			createdAt := now.Add(-time.Duration(transactions*6) * time.Hour)
+			var balance float32
+			for k := 0; k < transactions; k++ {
+				// whole thousands, float32 sum stay exact
+				amount := float32(r.Intn(100)+1) * 1000
+				transactionType := models.TRANSACTIONTYPEDEPOSIT
+				if balance > 0 && r.Intn(10) < 3 {
+					transactionType = models.TRANSACTIONTYPEWITHDRAW
+					if amount > balance {
+						amount = balance
+					}
+					balance -= amount
+				} else {
+					balance += amount
+				}
+
+				createdAt = createdAt.Add(time.Duration(r.Intn(6*60)+1) * time.Minute)
+				account.transactions = append(account.transactions, &models.Transaction{
+					Amount:          amount,
+					TransactionType: transactionType,
+					Memo:            seedMemos[r.Intn(len(seedMemos))],
+					CreatedAt:       createdAt,
+				})
+			}
+			account.account.Balance = balance
+			user.accounts = append(user.accounts, account)
+		}
+		plan = append(plan, user)
+	}
+	return plan
+}
+
+func insertSeed(tx *gorm.DB, plan []*seedUser) error {
+	for _, user := range plan {
+		err := tx.Create(user.user).Error
+		if err != nil {
+			return err
+		}
+
+		for _, account := range user.accounts {
+			account.account.UserId = user.user.ID
+			err = tx.Create(account.account).Error
+			if err != nil {
+				return err
+			}
+			if len(account.transactions) == 0 {
+				continue
+			}
+
+			for _, transaction := range account.transactions {
+				transaction.AccountID = account.account.ID
+			}
+			err = tx.CreateInBatches(account.transactions, 500).Error
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
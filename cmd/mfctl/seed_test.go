@@ -0,0 +1,88 @@
+package main
+
+import (
+	"math/rand"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"testing"
+	"time"
+)
+
+func ledgerOf(account *seedAccount) *accountLedger {
+	ledger := &accountLedger{Balance: float64(account.account.Balance)}
+	for _, transaction := range account.transactions {
+		if models.IsDebitTransactionType(transaction.TransactionType) {
+			ledger.LedgerBalance -= float64(transaction.Amount)
+		} else {
+			ledger.LedgerBalance += float64(transaction.Amount)
+		}
+	}
+	return ledger
+}
+
+func TestPlanSeedReconcile(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	plan := planSeed(rand.New(rand.NewSource(42)), 3, 2, 50, now)
+
+	if len(plan) != 3 {
+		t.Fatalf("got %d users want 3", len(plan))
+	}
+
+	ledgers := []*accountLedger{}
+	for _, user := range plan {
+		if len(user.accounts) != 2 {
+			t.Fatalf("got %d accounts want 2", len(user.accounts))
+		}
+		for _, account := range user.accounts {
+			if len(account.transactions) != 50 {
+				t.Fatalf("got %d transactions want 50", len(account.transactions))
+			}
+
+			var balance float32
+			var lastCreatedAt time.Time
+			for _, transaction := range account.transactions {
+				if models.IsDebitTransactionType(transaction.TransactionType) {
+					balance -= transaction.Amount
+				} else {
+					balance += transaction.Amount
+				}
+				if balance < 0 {
+					t.Fatalf("balance go negative: %v", balance)
+				}
+				if !transaction.CreatedAt.After(lastCreatedAt) || transaction.CreatedAt.After(now) {
+					t.Fatalf("created_at %v is not increasing up to now", transaction.CreatedAt)
+				}
+				lastCreatedAt = transaction.CreatedAt
+			}
+			ledgers = append(ledgers, ledgerOf(account))
+		}
+	}
+
+	mismatches := reconcileAccounts(ledgers, 0.01)
+	if len(mismatches) != 0 {
+		t.Fatalf("seed do not reconcile: %+v", mismatches[0].ledger)
+	}
+}
+
+func TestReconcileAccounts(t *testing.T) {
+	ledgers := []*accountLedger{
+		{AccountId: 1, Balance: 100, LedgerBalance: 100, HeldAmount: 20, AuthorizedHolds: 20},
+		{AccountId: 2, Balance: 100.005, LedgerBalance: 100},
+		// opening balance without transaction
+		{AccountId: 3, Balance: 500, LedgerBalance: 0},
+		{AccountId: 4, Balance: 50, LedgerBalance: 50, HeldAmount: 10, AuthorizedHolds: 0},
+	}
+
+	mismatches := reconcileAccounts(ledgers, 0.01)
+	if len(mismatches) != 2 {
+		t.Fatalf("got %d mismatches want 2", len(mismatches))
+	}
+	if mismatches[0].ledger.AccountId != 3 || mismatches[0].field != "balance" {
+		t.Errorf("unexpected mismatch %+v", mismatches[0])
+	}
+	if mismatches[1].ledger.AccountId != 4 || mismatches[1].field != "held_amount" {
+		t.Errorf("unexpected mismatch %+v", mismatches[1])
+	}
+	if countAccounts(mismatches) != 2 {
+		t.Errorf("got %d accounts want 2", countAccounts(mismatches))
+	}
+}
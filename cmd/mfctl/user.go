@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"money_forward_code_challenge/internal/domain/transaction/aggregate"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"money_forward_code_challenge/internal/infrastructure/data-provider/mysql"
+	"os"
+	"strconv"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type userView struct {
+	Id                 uint32                            `json:"id"`
+	FirstName          string                            `json:"first_name"`
+	LastName           string                            `json:"last_name"`
+	CreatedAt          string                            `json:"created_at"`
+	Accounts           []*aggregate.AccountByDetails     `json:"accounts"`
+	RecentTransactions []*aggregate.TransactionByDetails `json:"recent_transactions"`
+}
+
+func runUser(ctx context.Context, args []string) error {
+	if len(args) != 2 || args[0] != "show" {
+		return fmt.Errorf("%w: user need show <user_id>", errUsage)
+	}
+	userId, err := strconv.ParseUint(args[1], 10, 32)
+	if err != nil || userId == 0 {
+		return fmt.Errorf("%w: user_id %q is not valid", errUsage, args[1])
+	}
+
+	app, err := newApp(false)
+	if err != nil {
+		return err
+	}
+	defer app.Close()
+
+	var user models.User
+	err = app.GormDB().WithContext(ctx).Table(models.USERTABLE).
+		Where(models.USERCOLUMN_ID+" = ?", userId).
+		Take(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("user %d not found", userId)
+	}
+	if err != nil {
+		return err
+	}
+
+	var accountIds []uint32
+	err = app.GormDB().WithContext(ctx).Table(models.ACCOUNTTABLE).
+		Where(fmt.Sprintf("%s = ? AND %s IS NULL", models.ACCOUNTCOLUMN_USER_ID, models.ACCOUNTCOLUMN_DELETED_AT), userId).
+		Order(models.ACCOUNTCOLUMN_ID).
+		Pluck(models.ACCOUNTCOLUMN_ID, &accountIds).Error
+	if err != nil {
+		return err
+	}
+
+	logger := zap.NewNop()
+	userRepo := mysql.NewMysqlUserRepo(app.GormDB(), logger)
+	transactionRepo := mysql.NewMysqlTransactionRepo(app.GormDB(), logger)
+
+	view := &userView{
+		Id:        user.ID,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		CreatedAt: user.CreatedAt.String(),
+		Accounts:  []*aggregate.AccountByDetails{},
+	}
+	for _, accountId := range accountIds {
+		accountDetail, err := userRepo.GetAccountByAccountId(ctx, accountId)
+		if err != nil {
+			return err
+		}
+		view.Accounts = append(view.Accounts, accountDetail)
+	}
+
+	view.RecentTransactions, err = transactionRepo.GetByUserId(ctx, user.ID, &repo.Query{Limit: 10})
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(view)
+}
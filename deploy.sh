@@ -5,13 +5,13 @@ ENV_DEV="deploy/monolithic/.env.dev"
 ENV_PROD="deploy/monolithic/.env.prod"
 
 run_dev() {
-ENV_FILE="deploy/monolithic/.env.dev"
+export ENV_FILE="$ENV_DEV"
 docker compose -f docker_compose.yaml --env-file "$ENV_FILE" up
 }
 
 run_prod() {
-// TODO ADD .env.prod
-ENV_FILE="deploy/monolithic/.env.prod"
+# fill credentials of .env.prod before run
+export ENV_FILE="$ENV_PROD"
 docker compose -f docker_compose.yaml --env-file "$ENV_FILE" up
 }
 
@@ -3,8 +3,15 @@ package composite
 import (
 	"gorm.io/gorm"
 	"money_forward_code_challenge/internal/domain/transaction/repo"
+	fee_usecase "money_forward_code_challenge/internal/domain/transaction/usecase/fee"
+	hold_usecase "money_forward_code_challenge/internal/domain/transaction/usecase/hold"
+	interest_usecase "money_forward_code_challenge/internal/domain/transaction/usecase/interest"
+	overdraft_usecase "money_forward_code_challenge/internal/domain/transaction/usecase/overdraft"
+	rule_usecase "money_forward_code_challenge/internal/domain/transaction/usecase/rule"
+	schedule_usecase "money_forward_code_challenge/internal/domain/transaction/usecase/schedule"
 	transaction_usecase "money_forward_code_challenge/internal/domain/transaction/usecase/transaction"
 	user_usecase "money_forward_code_challenge/internal/domain/transaction/usecase/user"
+	webhook_usecase "money_forward_code_challenge/internal/domain/transaction/usecase/webhook"
 )
 
 type TransactionRepoComposite struct {
@@ -30,3 +37,97 @@ type TransactionUseCaseComposite struct {
 	GetByTransactionId transaction_usecase.GetTransactionById[*gorm.DB]
 	GetByUserId        transaction_usecase.GetTransactionsByUserId[*gorm.DB]
 }
+
+type RuleRepoComposite struct {
+	PersistentRepo repo.CategorizationRuleRepo[*gorm.DB]
+}
+
+type RuleUseCaseComposite struct {
+	Create         rule_usecase.CreateRuleUseCase[*gorm.DB]
+	Delete         rule_usecase.DeleteRuleUseCase[*gorm.DB]
+	GetByUserId    rule_usecase.GetRulesByUserId[*gorm.DB]
+	Categorize     rule_usecase.CategorizeUseCase[*gorm.DB]
+	ApplyToHistory rule_usecase.ApplyRulesToHistory[*gorm.DB]
+}
+
+type ScheduleRepoComposite struct {
+	PersistentRepo repo.ScheduleRepo[*gorm.DB]
+}
+
+type ScheduleUseCaseComposite struct {
+	Create      schedule_usecase.CreateScheduleUseCase[*gorm.DB]
+	GetByUserId schedule_usecase.GetSchedulesByUserId[*gorm.DB]
+	GetRuns     schedule_usecase.GetScheduleRuns[*gorm.DB]
+	Control     schedule_usecase.ControlScheduleUseCase[*gorm.DB]
+	GetDue      schedule_usecase.GetDueSchedules[*gorm.DB]
+	ClaimRun    schedule_usecase.ClaimScheduleRunUseCase[*gorm.DB]
+	FinishRun   schedule_usecase.FinishScheduleRunUseCase[*gorm.DB]
+	RecoverRuns schedule_usecase.RecoverStaleRunsUseCase[*gorm.DB]
+}
+
+type HoldRepoComposite struct {
+	PersistentRepo repo.HoldRepo[*gorm.DB]
+}
+
+type HoldUseCaseComposite struct {
+	Authorize   hold_usecase.AuthorizeHoldUseCase[*gorm.DB]
+	Capture     hold_usecase.CaptureHoldUseCase[*gorm.DB]
+	Release     hold_usecase.ReleaseHoldUseCase[*gorm.DB]
+	GetById     hold_usecase.GetHoldById[*gorm.DB]
+	GetByUserId hold_usecase.GetHoldsByUserId[*gorm.DB]
+	GetExpired  hold_usecase.GetExpiredHolds[*gorm.DB]
+}
+
+type OverdraftRepoComposite struct {
+	PersistentRepo repo.OverdraftRepo[*gorm.DB]
+}
+
+type OverdraftUseCaseComposite struct {
+	Update               overdraft_usecase.UpdateOverdraftUseCase[*gorm.DB]
+	Approve              overdraft_usecase.ApproveOverdraftUseCase[*gorm.DB]
+	GetOverdrawnAccounts overdraft_usecase.GetOverdrawnAccounts[*gorm.DB]
+	CreateInterest       overdraft_usecase.CreateInterestPostingUseCase[*gorm.DB]
+	GetInterestPostings  overdraft_usecase.GetInterestPostings[*gorm.DB]
+}
+
+type InterestRepoComposite struct {
+	PersistentRepo repo.InterestRepo[*gorm.DB]
+}
+
+type InterestUseCaseComposite struct {
+	CreateProduct       interest_usecase.CreateProductUseCase[*gorm.DB]
+	GetProducts         interest_usecase.GetProducts[*gorm.DB]
+	AssignProduct       interest_usecase.AssignProductUseCase[*gorm.DB]
+	GetAccountInterests interest_usecase.GetAccountInterests[*gorm.DB]
+	GetHistory          interest_usecase.GetInterestHistory[*gorm.DB]
+	Accrue              interest_usecase.AccrueAccountUseCase[*gorm.DB]
+	GetUnposted         interest_usecase.GetUnpostedAccruals[*gorm.DB]
+	Post                interest_usecase.PostAccrualsUseCase[*gorm.DB]
+}
+
+type FeeScheduleRepoComposite struct {
+	PersistentRepo repo.FeeScheduleRepo[*gorm.DB]
+}
+
+type FeeUseCaseComposite struct {
+	Create     fee_usecase.CreateFeeScheduleUseCase[*gorm.DB]
+	GetAll     fee_usecase.GetFeeSchedules[*gorm.DB]
+	Deactivate fee_usecase.DeactivateFeeScheduleUseCase[*gorm.DB]
+	Quote      fee_usecase.QuoteFeeUseCase[*gorm.DB]
+}
+
+type WebhookRepoComposite struct {
+	PersistentRepo repo.WebhookRepo[*gorm.DB]
+}
+
+type WebhookUseCaseComposite struct {
+	CreateSubscription webhook_usecase.CreateSubscriptionUseCase[*gorm.DB]
+	DeleteSubscription webhook_usecase.DeleteSubscriptionUseCase[*gorm.DB]
+	GetSubscriptions   webhook_usecase.GetSubscriptions[*gorm.DB]
+	Enqueue            webhook_usecase.EnqueueEventsUseCase[*gorm.DB]
+	GetDue             webhook_usecase.GetDueDeliveries[*gorm.DB]
+	Claim              webhook_usecase.ClaimDeliveryUseCase[*gorm.DB]
+	RecordAttempt      webhook_usecase.RecordAttemptUseCase[*gorm.DB]
+	GetDeliveries      webhook_usecase.GetDeliveries[*gorm.DB]
+	GetAttempts        webhook_usecase.GetAttempts[*gorm.DB]
+}
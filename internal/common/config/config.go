@@ -0,0 +1,246 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Config of server and mfctl, loaded by Load in layers
+// defaults < yaml file < environment variables < flags
+// env tag is environment variable, flag name is same in lower case with - (MYSQL_HOST = --mysql-host)
+type Config struct {
+	Environment string `yaml:"environment" env:"ENVIRONMENT"`
+	LogLevel    string `yaml:"log_level" env:"LOG_LEVEL"`
+
+	HTTP      HTTP      `yaml:"http"`
+	GRPC      GRPC      `yaml:"grpc"`
+	MySQL     MySQL     `yaml:"mysql"`
+	Redis     Redis     `yaml:"redis"`
+	Migration Migration `yaml:"migration"`
+	Pool      Pool      `yaml:"pool"`
+	Stream    Stream    `yaml:"stream"`
+	Webhook   Webhook   `yaml:"webhook"`
+	Health    Health    `yaml:"health"`
+	Tracing   Tracing   `yaml:"tracing"`
+	Workers   Workers   `yaml:"workers"`
+	Admin     Admin     `yaml:"admin"`
+}
+
+type HTTP struct {
+	Addr string `yaml:"addr" env:"HTTP_ADDR"`
+	// budget to drain requests, workers and async jobs after SIGTERM
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+}
+
+type GRPC struct {
+	Addr string `yaml:"addr" env:"GRPC_ADDR"`
+}
+
+type MySQL struct {
+	Host     string `yaml:"host" env:"MYSQL_HOST"`
+	Port     int    `yaml:"port" env:"MYSQL_PORT"`
+	User     string `yaml:"user" env:"MYSQL_USER"`
+	Password Secret `yaml:"password" env:"MYSQL_PASSWORD"`
+	Database string `yaml:"database" env:"MYSQL_DATABASE"`
+}
+
+// DSN contain password, never log it
+func (m MySQL) DSN() string {
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		m.User, m.Password.Value(), m.Host, m.Port, m.Database)
+}
+
+// Addr is what can be logged instead of DSN
+func (m MySQL) Addr() string {
+	return (&url.URL{Scheme: "mysql", User: url.User(m.User), Host: fmt.Sprintf("%s:%d", m.Host, m.Port), Path: m.Database}).String()
+}
+
+type Redis struct {
+	Addr     string `yaml:"addr" env:"REDIS_ADDR"`
+	Password Secret `yaml:"password" env:"REDIS_PASSWORD"`
+	DB       int    `yaml:"db" env:"REDIS_DB"`
+}
+
+type Migration struct {
+	// demo user 1 with three accounts, never in prod
+	SeedDemoData bool `yaml:"seed_demo_data" env:"SEED_DEMO_DATA"`
+}
+
+type Pool struct {
+	// workers of async job pool of each use case (cache update)
+	Size int `yaml:"size" env:"POOL_SIZE"`
+	// jobs waiting for a worker in each pool, over it Overflow apply
+	Capacity int `yaml:"capacity" env:"POOL_CAPACITY"`
+	// block, drop_oldest, reject or spill, see repo_pool_async.OverflowPolicy
+	Overflow string `yaml:"overflow" env:"POOL_OVERFLOW"`
+	// temporary goroutines of each pool when no worker is available or queue is full with spill
+	MaxSpill int `yaml:"max_spill" env:"POOL_MAX_SPILL"`
+	// job pushed in a transaction which is neither committed nor aborted in it is discarded
+	CommitTimeout time.Duration `yaml:"commit_timeout" env:"POOL_COMMIT_TIMEOUT"`
+	// jobs of same key (account:3) run in order, with coalesce only latest committed write of a key run
+	Coalesce bool      `yaml:"coalesce" env:"POOL_COALESCE"`
+	Retry    PoolRetry `yaml:"retry" env:"POOL_RETRY"`
+}
+
+// PoolRetry of failed async job, job failing every attempt go to dead letters (/api/admin/dead-letters)
+type PoolRetry struct {
+	MaxAttempts    int           `yaml:"max_attempts"`
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	MaxBackoff     time.Duration `yaml:"max_backoff"`
+	// fraction of backoff randomized, 0.2 is between 80% and 120%
+	Jitter float64 `yaml:"jitter"`
+}
+
+type Stream struct {
+	HistorySize int           `yaml:"history_size" env:"STREAM_HISTORY_SIZE"`
+	HistoryTTL  time.Duration `yaml:"history_ttl" env:"STREAM_HISTORY_TTL"`
+	Heartbeat   time.Duration `yaml:"heartbeat" env:"STREAM_HEARTBEAT"`
+}
+
+type Health struct {
+	// timeout of each dependency probe of /readyz
+	Timeout time.Duration `yaml:"timeout" env:"HEALTH_TIMEOUT"`
+	// not ready when async cache jobs waiting for workers are over this
+	MaxQueueDepth int `yaml:"max_queue_depth" env:"HEALTH_MAX_QUEUE_DEPTH"`
+}
+
+type Tracing struct {
+	// none, stdout or file, see tracing.RegisterExporter
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER"`
+	// spans as json lines for file exporter
+	File string `yaml:"file" env:"TRACING_FILE"`
+	// fraction of new traces sampled, parent decision is always kept
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+type Webhook struct {
+	// timeout of one delivery request
+	Timeout time.Duration `yaml:"timeout" env:"WEBHOOK_TIMEOUT"`
+}
+
+type Admin struct {
+	// bearer token of /api/admin, admin api is disabled when it is empty
+	Token Secret `yaml:"token" env:"ADMIN_TOKEN"`
+}
+
+type Worker struct {
+	Interval  time.Duration `yaml:"interval"`
+	BatchSize int           `yaml:"batch_size"`
+}
+
+type Workers struct {
+	Schedule  Worker `yaml:"schedule" env:"SCHEDULE_WORKER"`
+	Hold      Worker `yaml:"hold" env:"HOLD_WORKER"`
+	Overdraft Worker `yaml:"overdraft" env:"OVERDRAFT_WORKER"`
+	Interest  Worker `yaml:"interest" env:"INTEREST_WORKER"`
+	Webhook   Worker `yaml:"webhook" env:"WEBHOOK_WORKER"`
+}
+
+const (
+	EnvironmentDev  = "dev"
+	EnvironmentProd = "prod"
+)
+
+// Default is used for every value which is not set, same values as before config existed
+func Default() *Config {
+	return &Config{
+		Environment: EnvironmentDev,
+		LogLevel:    "info",
+		HTTP:        HTTP{Addr: ":8080", ShutdownTimeout: 15 * time.Second},
+		GRPC:        GRPC{Addr: ":9090"},
+		MySQL: MySQL{
+			Host: "localhost",
+			Port: 3306,
+		},
+		Redis: Redis{Addr: "localhost:6379"},
+		Pool: Pool{
+			Size:          10,
+			Capacity:      1000,
+			Overflow:      "reject",
+			MaxSpill:      100,
+			CommitTimeout: time.Second,
+			Coalesce:      true,
+			Retry:         PoolRetry{MaxAttempts: 3, InitialBackoff: 100 * time.Millisecond, MaxBackoff: 2 * time.Second, Jitter: 0.2},
+		},
+		Stream: Stream{
+			HistorySize: 1000,
+			HistoryTTL:  24 * time.Hour,
+			Heartbeat:   15 * time.Second,
+		},
+		Webhook: Webhook{Timeout: 10 * time.Second},
+		Health:  Health{Timeout: 2 * time.Second, MaxQueueDepth: 1000},
+		Tracing: Tracing{Exporter: "none", File: "traces.json", SampleRatio: 1},
+		Workers: Workers{
+			Schedule:  Worker{Interval: 30 * time.Second, BatchSize: 100},
+			Hold:      Worker{Interval: time.Minute, BatchSize: 100},
+			Overdraft: Worker{Interval: time.Hour, BatchSize: 100},
+			Interest:  Worker{Interval: time.Hour, BatchSize: 100},
+			Webhook:   Worker{Interval: 5 * time.Second, BatchSize: 100},
+		},
+	}
+}
+
+// Validate return every invalid value at once
+func (c *Config) Validate() error {
+	var errs []string
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(c.Environment == EnvironmentDev || c.Environment == EnvironmentProd, "environment %q must be %s or %s", c.Environment, EnvironmentDev, EnvironmentProd)
+	check(c.LogLevel == "debug" || c.LogLevel == "info" || c.LogLevel == "warn" || c.LogLevel == "error", "log_level %q must be debug, info, warn or error", c.LogLevel)
+	check(c.HTTP.Addr != "", "http.addr is required")
+	check(c.HTTP.ShutdownTimeout > 0, "http.shutdown_timeout must be > 0")
+	check(c.GRPC.Addr != "", "grpc.addr is required")
+	check(c.MySQL.Host != "", "mysql.host is required")
+	check(c.MySQL.Port > 0 && c.MySQL.Port < 65536, "mysql.port %d is not valid", c.MySQL.Port)
+	check(c.MySQL.User != "", "mysql.user is required")
+	check(c.MySQL.Database != "", "mysql.database is required")
+	check(c.Redis.Addr != "", "redis.addr is required")
+	check(c.Redis.DB >= 0, "redis.db %d is not valid", c.Redis.DB)
+	check(c.Pool.Size > 0, "pool.size must be > 0")
+	check(c.Pool.Capacity > 0, "pool.capacity must be > 0")
+	check(c.Pool.Overflow == "block" || c.Pool.Overflow == "drop_oldest" || c.Pool.Overflow == "reject" || c.Pool.Overflow == "spill",
+		"pool.overflow %q must be block, drop_oldest, reject or spill", c.Pool.Overflow)
+	check(c.Pool.MaxSpill >= 0, "pool.max_spill must be >= 0")
+	check(c.Pool.CommitTimeout > 0, "pool.commit_timeout must be > 0")
+	check(c.Pool.Retry.MaxAttempts > 0, "pool.retry.max_attempts must be > 0")
+	check(c.Pool.Retry.InitialBackoff >= 0 && c.Pool.Retry.MaxBackoff >= c.Pool.Retry.InitialBackoff,
+		"pool.retry.max_backoff must be >= pool.retry.initial_backoff >= 0")
+	check(c.Pool.Retry.Jitter >= 0 && c.Pool.Retry.Jitter <= 1, "pool.retry.jitter %v must be between 0 and 1", c.Pool.Retry.Jitter)
+	check(c.Stream.HistorySize > 0, "stream.history_size must be > 0")
+	check(c.Stream.HistoryTTL >= time.Second, "stream.history_ttl must be >= 1s")
+	check(c.Stream.Heartbeat > 0, "stream.heartbeat must be > 0")
+	check(c.Webhook.Timeout > 0, "webhook.timeout must be > 0")
+	check(c.Health.Timeout > 0, "health.timeout must be > 0")
+	check(c.Health.MaxQueueDepth > 0, "health.max_queue_depth must be > 0")
+	check(c.Tracing.Exporter != "", "tracing.exporter is required, none to disable")
+	check(c.Tracing.Exporter != "file" || c.Tracing.File != "", "tracing.file is required by file exporter")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio %v must be between 0 and 1", c.Tracing.SampleRatio)
+	for name, worker := range map[string]Worker{
+		"schedule":  c.Workers.Schedule,
+		"hold":      c.Workers.Hold,
+		"overdraft": c.Workers.Overdraft,
+		"interest":  c.Workers.Interest,
+		"webhook":   c.Workers.Webhook,
+	} {
+		check(worker.Interval > 0, "workers.%s.interval must be > 0", name)
+		check(worker.BatchSize > 0, "workers.%s.batch_size must be > 0", name)
+	}
+
+	if c.Environment == EnvironmentProd {
+		check(c.MySQL.Password != "", "mysql.password is required in %s", EnvironmentProd)
+		check(!c.Migration.SeedDemoData, "migration.seed_demo_data must be false in %s", EnvironmentProd)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Strings(errs)
+	return fmt.Errorf("invalid config: %s", strings.Join(errs, "; "))
+}
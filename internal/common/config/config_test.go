@@ -0,0 +1,156 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func envOf(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+func requiredEnv() map[string]string {
+	return map[string]string{
+		"MYSQL_USER":     "user",
+		"MYSQL_DATABASE": "transaction_db",
+	}
+}
+
+func TestLoadLayers(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	err := os.WriteFile(path, []byte(`
+http:
+  addr: ":8000"
+mysql:
+  host: file-host
+  port: 3307
+pool:
+  size: 5
+workers:
+  schedule:
+    interval: 10s
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env := requiredEnv()
+	env[EnvConfigFile] = path
+	env["MYSQL_HOST"] = "env-host"
+	env["POOL_SIZE"] = "7"
+	env["SCHEDULE_WORKER_BATCH_SIZE"] = "20"
+	env["STREAM_HEARTBEAT"] = "5s"
+
+	cfg, err := Load("test", []string{"--pool-size", "9", "--seed-demo-data", "true"}, envOf(env))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "file over default", got: cfg.HTTP.Addr, want: ":8000"},
+		{name: "default kept", got: cfg.GRPC.Addr, want: ":9090"},
+		{name: "env over file", got: cfg.MySQL.Host, want: "env-host"},
+		{name: "file only", got: cfg.MySQL.Port, want: 3307},
+		{name: "flag over env", got: cfg.Pool.Size, want: 9},
+		{name: "flag bool", got: cfg.Migration.SeedDemoData, want: true},
+		{name: "nested file", got: cfg.Workers.Schedule.Interval, want: 10 * time.Second},
+		{name: "nested env", got: cfg.Workers.Schedule.BatchSize, want: 20},
+		{name: "env duration", got: cfg.Stream.Heartbeat, want: 5 * time.Second},
+	}
+	for _, tc := range testCases {
+		if fmt.Sprint(tc.got) != fmt.Sprint(tc.want) {
+			t.Errorf("%s: got %v want %v", tc.name, tc.got, tc.want)
+		}
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	dir := t.TempDir()
+	typo := filepath.Join(dir, "typo.yaml")
+	err := os.WriteFile(typo, []byte("mysql:\n  hots: x\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name string
+		env  map[string]string
+		args []string
+		want string
+	}{
+		{name: "missing required", env: map[string]string{}, want: "mysql.user is required"},
+		{name: "bad int", env: map[string]string{"POOL_SIZE": "ten"}, want: "env POOL_SIZE"},
+		{name: "bad flag duration", args: []string{"--stream-heartbeat", "15"}, want: "flag --stream-heartbeat"},
+		{name: "unknown yaml key", env: map[string]string{EnvConfigFile: typo}, want: "hots"},
+		{name: "prod without password", env: map[string]string{"ENVIRONMENT": "prod"}, want: "mysql.password is required"},
+		{name: "prod with demo data", env: map[string]string{"ENVIRONMENT": "prod", "SEED_DEMO_DATA": "true"}, want: "seed_demo_data must be false"},
+		{name: "unknown environment", env: map[string]string{"ENVIRONMENT": "staging"}, want: "environment \"staging\""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := map[string]string{}
+			for key, value := range tc.env {
+				env[key] = value
+			}
+			if tc.name != "missing required" {
+				for key, value := range requiredEnv() {
+					env[key] = value
+				}
+			}
+			_, err := Load("test", tc.args, envOf(env))
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("got %v want error containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestSecretRedacted(t *testing.T) {
+	cfg := Default()
+	cfg.MySQL.User = "user"
+	cfg.MySQL.Password = "p4ssw0rd"
+	cfg.MySQL.Database = "db"
+
+	out, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	printed := fmt.Sprintf("%v %+v %s", cfg.MySQL, cfg.MySQL, cfg.MySQL.Password)
+	for _, s := range []string{string(out), printed, cfg.MySQL.Addr()} {
+		if strings.Contains(s, "p4ssw0rd") {
+			t.Errorf("secret leaked in %s", s)
+		}
+	}
+	if !strings.Contains(cfg.MySQL.DSN(), ":p4ssw0rd@") {
+		t.Errorf("dsn must contain password")
+	}
+}
+
+// example file must stay loadable when fields change
+func TestExampleFile(t *testing.T) {
+	content, err := os.ReadFile("../../../deploy/monolithic/config.example.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := Default()
+	err = Decode(cfg, content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Workers.Hold.Interval != time.Minute {
+		t.Errorf("got %v want 1m", cfg.Workers.Hold.Interval)
+	}
+}
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvConfigFile is path of yaml file when --config is not given, file is optional
+const EnvConfigFile = "CONFIG_FILE"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// field is one leaf value of Config with its env and flag name
+type field struct {
+	env   string
+	flag  string
+	value reflect.Value
+}
+
+// Load build config from defaults, yaml file, environment and args, then validate it
+// lookupEnv is os.LookupEnv, tests pass a map
+func Load(name string, args []string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	cfg := Default()
+	fields := fieldsOf(reflect.ValueOf(cfg).Elem(), "")
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	configFile := flags.String("config", "", "yaml config file (env "+EnvConfigFile+")")
+	flagValues := map[string]*string{}
+	for _, f := range fields {
+		flagValues[f.flag] = flags.String(f.flag, "", fmt.Sprintf("env %s (default %s)", f.env, valueString(f.value)))
+	}
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	if flags.NArg() != 0 {
+		return nil, fmt.Errorf("unexpected arguments %v", flags.Args())
+	}
+
+	path := *configFile
+	if path == "" {
+		path, _ = lookupEnv(EnvConfigFile)
+	}
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		err = Decode(cfg, content)
+		if err != nil {
+			return nil, fmt.Errorf("config file %s: %w", path, err)
+		}
+	}
+
+	for _, f := range fields {
+		value, ok := lookupEnv(f.env)
+		if !ok || value == "" {
+			continue
+		}
+		err = setValue(f.value, value)
+		if err != nil {
+			return nil, fmt.Errorf("env %s: %w", f.env, err)
+		}
+	}
+
+	// only flags given in args, flag default must not override env
+	flags.Visit(func(fl *flag.Flag) {
+		flagValue, ok := flagValues[fl.Name]
+		if !ok || err != nil {
+			return
+		}
+		for _, f := range fields {
+			if f.flag == fl.Name {
+				err = setValue(f.value, *flagValue)
+				if err != nil {
+					err = fmt.Errorf("flag --%s: %w", fl.Name, err)
+				}
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Decode yaml into cfg, unknown keys are error so typo is not silently ignored
+func Decode(cfg *Config, content []byte) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	err := decoder.Decode(cfg)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// fieldsOf walk struct, env name of leaf in struct with env prefix is <prefix>_<YAML NAME>
+func fieldsOf(v reflect.Value, envPrefix string) []field {
+	fields := []field{}
+	for i := 0; i < v.NumField(); i++ {
+		structField := v.Type().Field(i)
+		env := structField.Tag.Get("env")
+		if env == "" && envPrefix != "" {
+			yamlName := strings.Split(structField.Tag.Get("yaml"), ",")[0]
+			env = envPrefix + "_" + strings.ToUpper(yamlName)
+		}
+
+		fieldValue := v.Field(i)
+		if fieldValue.Kind() == reflect.Struct && fieldValue.Type() != durationType {
+			fields = append(fields, fieldsOf(fieldValue, env)...)
+			continue
+		}
+		if env == "" {
+			continue
+		}
+		fields = append(fields, field{
+			env:   env,
+			flag:  strings.ReplaceAll(strings.ToLower(env), "_", "-"),
+			value: fieldValue,
+		})
+	}
+	return fields
+}
+
+func setValue(v reflect.Value, raw string) error {
+	if v.Type() == durationType {
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(duration))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int:
+		number, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(number))
+	case reflect.Bool:
+		boolean, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(boolean)
+	case reflect.Float64:
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(number)
+	default:
+		return fmt.Errorf("unsupported kind %s", v.Kind())
+	}
+	return nil
+}
+
+func valueString(v reflect.Value) string {
+	if stringer, ok := v.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprint(v.Interface())
+}
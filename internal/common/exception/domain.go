@@ -0,0 +1,126 @@
+package exception
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind is class of domain error, transport map it to a status (see httpresponse.TransformToError)
+// errors.Is(err, exception.NotFound) is true for every error of kind NotFound
+type Kind string
+
+const (
+	NotFound          Kind = "NOT_FOUND"
+	Forbidden         Kind = "FORBIDDEN"
+	Validation        Kind = "VALIDATION"
+	InsufficientFunds Kind = "INSUFFICIENT_FUNDS"
+	Conflict          Kind = "CONFLICT"
+	Unavailable       Kind = "UNAVAILABLE"
+	// Internal is kind of errors which are not domain errors
+	Internal Kind = "INTERNAL"
+)
+
+func (k Kind) Error() string {
+	return string(k)
+}
+
+// DomainError is error with kind and stable machine code, clients switch on code not on message
+type DomainError struct {
+	Kind Kind
+	// Code is stable, e.g. ACCOUNT_NOT_FOUND, default to kind
+	Code    string
+	Message string
+	// Fields are invalid fields of request, only on Validation
+	Fields []FieldError
+	// Err is cause, kept for errors.Is / errors.As (e.g. gorm.ErrRecordNotFound)
+	Err error
+}
+
+// New domain error, code is empty to use kind as code
+func New(kind Kind, code string, message string) *DomainError {
+	if code == "" {
+		code = string(kind)
+	}
+	return &DomainError{
+		Kind:    kind,
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Newf is New with formatted message
+func Newf(kind Kind, code string, format string, args ...any) *DomainError {
+	return New(kind, code, fmt.Sprintf(format, args...))
+}
+
+// Wrap err as domain error, message is err message, nil stays nil
+func Wrap(kind Kind, code string, err error) error {
+	if err == nil {
+		return nil
+	}
+	domainErr := New(kind, code, err.Error())
+	domainErr.Err = err
+	return domainErr
+}
+
+// Is match kind, so one check cover all codes of kind
+func (e *DomainError) Is(target error) bool {
+	kind, ok := target.(Kind)
+	return ok && kind == e.Kind
+}
+
+func (e *DomainError) Error() string {
+	return e.Message
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.Err
+}
+
+// KindOf err, errors which are not domain errors are Internal
+func KindOf(err error) Kind {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Kind
+	}
+	return Internal
+}
+
+// FieldError is one invalid field of request
+type FieldError struct {
+	// Field is json name, nested fields are joined by dot, e.g. tags.0
+	Field string `json:"field"`
+	// Code is rule which failed, e.g. required, range
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Invalid is Validation error of fields, message is kept for clients which read message
+func Invalid(message string, fields ...FieldError) *DomainError {
+	err := New(Validation, "", message)
+	err.Fields = fields
+	return err
+}
+
+// InvalidField is Invalid of one field, field message is the message
+func InvalidField(field string, code string, message string) *DomainError {
+	return Invalid(message, FieldError{Field: field, Code: code, Message: message})
+}
+
+// FieldsOf err, nil when err has no field errors
+func FieldsOf(err error) []FieldError {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Fields
+	}
+	return nil
+}
+
+// CodeOf err, errors which are not domain errors are INTERNAL
+func CodeOf(err error) string {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Code
+	}
+	return string(Internal)
+}
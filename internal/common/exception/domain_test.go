@@ -0,0 +1,44 @@
+package exception
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestDomainErrorKind(t *testing.T) {
+	errAccountNotFound := New(NotFound, "ACCOUNT_NOT_FOUND", "account not found")
+	wrapped := fmt.Errorf("%w: account %d", errAccountNotFound, 3)
+
+	if !errors.Is(wrapped, errAccountNotFound) {
+		t.Error("wrapped error must match its sentinel")
+	}
+	if !errors.Is(wrapped, NotFound) || errors.Is(wrapped, Conflict) {
+		t.Error("error must match its kind only")
+	}
+	if KindOf(wrapped) != NotFound || CodeOf(wrapped) != "ACCOUNT_NOT_FOUND" {
+		t.Errorf("got %s %s", KindOf(wrapped), CodeOf(wrapped))
+	}
+	if wrapped.Error() != "account not found: account 3" {
+		t.Errorf("got message %q", wrapped.Error())
+	}
+}
+
+func TestDomainErrorDefaults(t *testing.T) {
+	if code := CodeOf(New(Validation, "", "bad")); code != "VALIDATION" {
+		t.Errorf("code default to kind, got %s", code)
+	}
+
+	plain := errors.New("boom")
+	if KindOf(plain) != Internal || CodeOf(plain) != "INTERNAL" {
+		t.Errorf("plain error must be internal, got %s %s", KindOf(plain), CodeOf(plain))
+	}
+
+	if Wrap(Unavailable, "", nil) != nil {
+		t.Error("wrap nil must be nil")
+	}
+	wrapped := Wrap(Unavailable, "DATABASE_UNAVAILABLE", plain)
+	if !errors.Is(wrapped, plain) || !errors.Is(wrapped, Unavailable) {
+		t.Error("wrapped error must match cause and kind")
+	}
+}
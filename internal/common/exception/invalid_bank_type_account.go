@@ -20,10 +20,10 @@ func NewCheckExceptionBankTypeAccount(expecteds []string) *CheckExceptionBankTyp
 func (i *CheckExceptionBankTypeAccount) getExpectToString() string {
 	v := ""
 	for i, expect := range i.expecteds {
-		v += fmt.Sprintf("%v", expect)
 		if i > 0 {
-			v += ", or"
+			v += ", "
 		}
+		v += fmt.Sprintf("%v", expect)
 	}
 	return v
 }
@@ -35,6 +35,7 @@ func (i *CheckExceptionBankTypeAccount) Check(value any) {
 
 	if _, ok := value.(string); !ok {
 		i.errString = constructErrString(i.name, "string", "unknown")
+		return
 	}
 
 	bank_type := value.(string)
@@ -41,6 +41,7 @@ func (i *CheckExceptionTransactionType) Check(value any) {
 
 	if _, ok := value.(string); !ok {
 		i.errString = constructErrString(i.name, "string", "unknown")
+		return
 	}
 
 	transactionType := fmt.Sprintf("%s", value)
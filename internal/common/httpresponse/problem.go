@@ -0,0 +1,84 @@
+package httpresponse
+
+import (
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/logging"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// ProblemContentType is media type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// problemTypeBase prefix type of problems, type is relative to api and identify error code
+const problemTypeBase = "/problems/"
+
+// Problem is RFC 7807 problem details of error response
+// code, request_id and errors are extension members
+type Problem struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Status    int                    `json:"status"`
+	Detail    string                 `json:"detail"`
+	Instance  string                 `json:"instance,omitempty"`
+	Code      string                 `json:"code"`
+	RequestId string                 `json:"request_id,omitempty"`
+	Errors    []exception.FieldError `json:"errors,omitempty"`
+}
+
+// Problem of error response, instance is path of request
+func (r *Response) Problem(instance string) *Problem {
+	code := r.ErrorCode
+	if code == "" {
+		code = exception.CodeOf(r.err)
+	}
+	return &Problem{
+		Type:      ProblemType(code),
+		Title:     http.StatusText(r.Code),
+		Status:    r.Code,
+		Detail:    r.ErrCodeString,
+		Instance:  instance,
+		Code:      code,
+		RequestId: r.RequestId,
+		Errors:    exception.FieldsOf(r.err),
+	}
+}
+
+// ProblemType of error code, e.g. ACCOUNT_NOT_FOUND is /problems/account-not-found
+func ProblemType(code string) string {
+	return problemTypeBase + strings.ReplaceAll(strings.ToLower(code), "_", "-")
+}
+
+// AcceptProblem is true when client ask problem+json before json in Accept,
+// clients which send no Accept, */* or application/json keep legacy envelope
+func AcceptProblem(ginCtx *gin.Context) bool {
+	return ginCtx.NegotiateFormat(binding.MIMEJSON, ProblemContentType) == ProblemContentType
+}
+
+// Write response with its status, errors carry X-Request-ID of request
+// and are problem+json when client accept it
+func Write(ginCtx *gin.Context, r *Response) {
+	r.WithRequestId(logging.RequestId(ginCtx))
+	if r.Code < http.StatusBadRequest {
+		ginCtx.JSON(r.Code, r)
+		return
+	}
+
+	// body of errors depend on Accept, caches must not mix them
+	ginCtx.Header("Vary", "Accept")
+	if AcceptProblem(ginCtx) {
+		WriteProblem(ginCtx, r.Problem(ginCtx.Request.URL.Path))
+		return
+	}
+	ginCtx.JSON(r.Code, r)
+}
+
+// WriteProblem write problem with problem+json content type
+func WriteProblem(ginCtx *gin.Context, problem *Problem) {
+	// json render keep content type which is already set
+	ginCtx.Header("Content-Type", ProblemContentType)
+	ginCtx.JSON(problem.Status, problem)
+}
@@ -0,0 +1,78 @@
+package httpresponse
+
+import (
+	"encoding/json"
+	"money_forward_code_challenge/internal/common/exception"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func serveResponse(accept string, res *Response) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/users/:id/accounts/:account_id", func(ginCtx *gin.Context) {
+		Write(ginCtx, res)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/users/1/accounts/9", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestWriteNegotiation(t *testing.T) {
+	testCases := []struct {
+		name        string
+		accept      string
+		contentType string
+	}{
+		{name: "no accept", accept: "", contentType: "application/json; charset=utf-8"},
+		{name: "any", accept: "*/*", contentType: "application/json; charset=utf-8"},
+		{name: "json", accept: "application/json", contentType: "application/json; charset=utf-8"},
+		{name: "problem", accept: "application/problem+json", contentType: ProblemContentType},
+		{name: "problem first", accept: "application/problem+json, application/json", contentType: ProblemContentType},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := serveResponse(tc.accept, (&Response{}).TransformToNotFound("account 9 not found"))
+			if recorder.Code != http.StatusNotFound {
+				t.Errorf("got status %d", recorder.Code)
+			}
+			if got := recorder.Header().Get("Content-Type"); got != tc.contentType {
+				t.Errorf("got content type %q want %q", got, tc.contentType)
+			}
+		})
+	}
+
+	recorder := serveResponse("application/problem+json", (&Response{}).TransformToSuccessOk("ok"))
+	if recorder.Header().Get("Content-Type") == ProblemContentType {
+		t.Error("success is never problem")
+	}
+}
+
+func TestProblemBody(t *testing.T) {
+	err := exception.Invalid("[InValidErrAmountValue] ->>> expects: (min=10000, max=20000000), !got: 5",
+		exception.FieldError{Field: "amount", Code: "range", Message: "must be in [10000, 20000000]"})
+	recorder := serveResponse("application/problem+json", (&Response{}).TransformToError(err))
+
+	var problem Problem
+	if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	if problem.Type != "/problems/validation" || problem.Title != "Bad Request" || problem.Status != http.StatusBadRequest ||
+		problem.Code != "VALIDATION" || problem.Instance != "/api/users/1/accounts/9" {
+		t.Errorf("got %+v", problem)
+	}
+	if len(problem.Errors) != 1 || problem.Errors[0].Field != "amount" || problem.Errors[0].Code != "range" {
+		t.Errorf("got field errors %+v", problem.Errors)
+	}
+	if ProblemType("ACCOUNT_NOT_FOUND") != "/problems/account-not-found" {
+		t.Errorf("got %s", ProblemType("ACCOUNT_NOT_FOUND"))
+	}
+}
@@ -1,17 +1,47 @@
 package httpresponse
 
-import "net/http"
+import (
+	"money_forward_code_challenge/internal/common/exception"
+	"net/http"
+)
+
+// statusByKind is http status of each exception kind, kinds not listed are 500
+var statusByKind = map[exception.Kind]int{
+	exception.NotFound:          http.StatusNotFound,
+	exception.Forbidden:         http.StatusForbidden,
+	exception.Validation:        http.StatusBadRequest,
+	exception.InsufficientFunds: http.StatusUnprocessableEntity,
+	exception.Conflict:          http.StatusConflict,
+	exception.Unavailable:       http.StatusServiceUnavailable,
+}
 
 type Response struct {
 	Code          int    `json:"code"`
 	ErrCodeString string `json:"err_code_string"`
-	Data          any    `json:"data"`
+	// stable machine code of error, e.g. ACCOUNT_NOT_FOUND, see exception.DomainError
+	ErrorCode string `json:"error_code,omitempty"`
+	Data      any    `json:"data"`
+	// X-Request-ID of request, set on errors so client can quote it
+	RequestId string `json:"request_id,omitempty"`
+	// err of TransformToError, rendered as problem details
+	err error
 }
 
 func (r *Response) resetBeforeTransform() {
 	r.Code = 0
 	r.ErrCodeString = ""
+	r.ErrorCode = ""
 	r.Data = nil
+	r.RequestId = ""
+	r.err = nil
+}
+
+// WithRequestId set request id when response is an error
+func (r *Response) WithRequestId(requestId string) *Response {
+	if r.Code >= http.StatusBadRequest {
+		r.RequestId = requestId
+	}
+	return r
 }
 func (r *Response) ErrString() *string {
 	return &r.ErrCodeString
@@ -28,21 +58,34 @@ func (r *Response) constructDataMessage(dataMessage any) *Response {
 }
 
 func (r *Response) TransformToInternalServerError(errString string) *Response {
-	r.resetBeforeTransform()
-	r.Code = http.StatusInternalServerError
-	return r.constructErrMessage(errString)
+	return r.TransformToError(exception.New(exception.Internal, "", errString))
 }
 
 func (r *Response) TransformToBadRequest(errString string) *Response {
-	r.resetBeforeTransform()
-	r.Code = http.StatusBadRequest
-	return r.constructErrMessage(errString)
+	return r.TransformToError(exception.New(exception.Validation, "", errString))
 }
 
 func (r *Response) TransformToNotFound(errString string) *Response {
+	return r.TransformToError(exception.New(exception.NotFound, "", errString))
+}
+
+// TransformToError is the mapper of errors, status and error_code come from kind and code of err
+// errors which are not exception.DomainError are 500 INTERNAL
+func (r *Response) TransformToError(err error) *Response {
 	r.resetBeforeTransform()
-	r.Code = http.StatusNotFound
-	return r.constructErrMessage(errString)
+	r.Code = StatusOf(err)
+	r.ErrorCode = exception.CodeOf(err)
+	r.err = err
+	return r.constructErrMessage(err.Error())
+}
+
+// StatusOf err, for transports which do not write Response
+func StatusOf(err error) int {
+	status, ok := statusByKind[exception.KindOf(err)]
+	if !ok {
+		return http.StatusInternalServerError
+	}
+	return status
 }
 
 func (r *Response) TransformToCreatedSuccess(dataMessage any) *Response {
@@ -64,9 +107,7 @@ func (r *Response) TransformToDeletedSuccess(dataMessage any) *Response {
 }
 
 func (r *Response) TransformToConflictUniqueResourceError(errString string) *Response {
-	r.resetBeforeTransform()
-	r.Code = http.StatusConflict
-	return r.constructErrMessage(errString)
+	return r.TransformToError(exception.New(exception.Conflict, "", errString))
 }
 
 func (r *Response) TransformToSuccessOk(data any) *Response {
@@ -0,0 +1,50 @@
+package httpresponse
+
+import (
+	"errors"
+	"fmt"
+	"money_forward_code_challenge/internal/common/exception"
+	"net/http"
+	"testing"
+)
+
+func TestTransformToError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{name: "not found", err: exception.New(exception.NotFound, "ACCOUNT_NOT_FOUND", "account 1 not found"), status: http.StatusNotFound, code: "ACCOUNT_NOT_FOUND"},
+		{name: "forbidden", err: exception.New(exception.Forbidden, "", "other user"), status: http.StatusForbidden, code: "FORBIDDEN"},
+		{name: "validation", err: exception.New(exception.Validation, "INVALID_RULE", "invalid rule"), status: http.StatusBadRequest, code: "INVALID_RULE"},
+		{name: "insufficient funds", err: exception.New(exception.InsufficientFunds, "", "not enough"), status: http.StatusUnprocessableEntity, code: "INSUFFICIENT_FUNDS"},
+		{name: "conflict", err: exception.New(exception.Conflict, "HOLD_EXPIRED", "hold is expired"), status: http.StatusConflict, code: "HOLD_EXPIRED"},
+		{name: "unavailable", err: exception.New(exception.Unavailable, "", "down"), status: http.StatusServiceUnavailable, code: "UNAVAILABLE"},
+		{name: "wrapped", err: fmt.Errorf("%w: hold 2", exception.New(exception.NotFound, "HOLD_NOT_FOUND", "hold not found")), status: http.StatusNotFound, code: "HOLD_NOT_FOUND"},
+		{name: "plain error", err: errors.New("boom"), status: http.StatusInternalServerError, code: "INTERNAL"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := (&Response{Data: "stale"}).TransformToError(tc.err)
+			if res.Code != tc.status || res.ErrorCode != tc.code {
+				t.Errorf("got %d %s want %d %s", res.Code, res.ErrorCode, tc.status, tc.code)
+			}
+			if res.ErrCodeString != tc.err.Error() || res.Data != nil {
+				t.Errorf("body is not reset, got %+v", res)
+			}
+		})
+	}
+}
+
+func TestTransformHelpersUseMapper(t *testing.T) {
+	res := (&Response{}).TransformToNotFound("missing")
+	if res.Code != http.StatusNotFound || res.ErrorCode != "NOT_FOUND" {
+		t.Errorf("got %d %s", res.Code, res.ErrorCode)
+	}
+	res = res.TransformToSuccessOk("data")
+	if res.ErrorCode != "" || res.ErrCodeString != "" {
+		t.Errorf("success must clear error, got %+v", res)
+	}
+}
@@ -0,0 +1,39 @@
+package logging
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type loggerKey struct{}
+type requestIdKey struct{}
+
+// NewContext put request scoped logger (with request_id, trace_id) in ctx
+func NewContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext return logger of request, fallback (struct level logger) outside requests (workers, async jobs)
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if ctx != nil {
+		logger, ok := ctx.Value(loggerKey{}).(*zap.Logger)
+		if ok {
+			return logger
+		}
+	}
+	return fallback
+}
+
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+// RequestId is empty outside requests
+func RequestId(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestId, _ := ctx.Value(requestIdKey{}).(string)
+	return requestId
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// metrics are registered on default registry, served by promhttp.Handler on /metrics
+
+var HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "http_request_duration_seconds",
+	Help:    "Latency of http requests by route and status.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"method", "route", "status"})
+
+var RepoCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "repo_call_duration_seconds",
+	Help:    "Latency of mysql and redis calls by repo method.",
+	Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+}, []string{"store", "repo", "method", "result"})
+
+var CacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "cache_requests_total",
+	Help: "Read through cache lookups by result (hit, miss).",
+}, []string{"cache", "result"})
+
+var Transactions = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "transactions_total",
+	Help: "Committed transactions by event (created, deleted), type and bank.",
+}, []string{"event", "type", "bank"})
+
+const (
+	CacheAccount     = "account"
+	CacheTransaction = "transaction"
+)
+
+// ObserveCache count lookup of read through cache
+func ObserveCache(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	CacheRequests.WithLabelValues(cache, result).Inc()
+}
@@ -0,0 +1,188 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"money_forward_code_challenge/internal/common/tracing"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// repos are found from call stack, every repo method is measured and traced without wrapping it
+const dataProviderPkg = "money_forward_code_challenge/internal/infrastructure/data-provider/"
+
+const (
+	StoreMysql = "mysql"
+	StoreRedis = "redis"
+)
+
+// repoCall is one mysql statement or redis command, measured and traced
+type repoCall struct {
+	store  string
+	repo   string
+	method string
+	start  time.Time
+	span   trace.Span
+}
+
+func startRepoCall(ctx context.Context, store string, attrs ...attribute.KeyValue) (context.Context, *repoCall) {
+	call := &repoCall{
+		store: store,
+		start: time.Now(),
+	}
+	call.repo, call.method = repoCaller(dataProviderPkg + store)
+	attrs = append(attrs, attribute.String("db.system", store), attribute.String("repo", call.repo))
+	ctx, call.span = tracing.Tracer().Start(ctx, fmt.Sprintf("%s %s.%s", store, call.repo, call.method),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return ctx, call
+}
+
+func (c *repoCall) end(err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	RepoCallDuration.WithLabelValues(c.store, c.repo, c.method, result).Observe(time.Since(c.start).Seconds())
+	tracing.End(c.span, err)
+}
+
+// repoCaller is first method of package in call stack, calls from outside repos are "other"
+func repoCaller(pkgPath string) (string, string) {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, pkgPath+".") {
+			repo, method, ok := parseRepoMethod(frame.Function[len(pkgPath)+1:])
+			if ok {
+				return repo, method
+			}
+		}
+		if !more {
+			return "other", "other"
+		}
+	}
+}
+
+// parseRepoMethod split "(*mysqlTransactionRepoImpl).GetById.func1" into repo and method
+// repos have pointer receivers, plain functions are not repo methods
+func parseRepoMethod(name string) (string, string, bool) {
+	if !strings.HasPrefix(name, "(") {
+		return "", "", false
+	}
+	end := strings.Index(name, ").")
+	if end < 0 {
+		return "", "", false
+	}
+	repo := strings.TrimPrefix(name[1:end], "*")
+	repo, _, _ = strings.Cut(repo, "[")
+	method, _, _ := strings.Cut(name[end+2:], ".")
+	if repo == "" || method == "" {
+		return "", "", false
+	}
+	return repo, method, true
+}
+
+const gormCallKey = "metrics:repo_call"
+
+// GormPlugin measure and trace every statement of gorm, register with db.Use
+// span is child of span in context of statement (WithContext of repo)
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string {
+	return "metrics"
+}
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	before := func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		ctx, call := startRepoCall(ctx, StoreMysql, attribute.String("db.sql.table", db.Statement.Table))
+		db.Statement.Context = ctx
+		db.InstanceSet(gormCallKey, call)
+	}
+	after := func(db *gorm.DB) {
+		value, ok := db.InstanceGet(gormCallKey)
+		if !ok {
+			return
+		}
+		call := value.(*repoCall)
+		call.span.SetAttributes(attribute.Int64("db.rows_affected", db.RowsAffected))
+		err := db.Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = nil
+		}
+		call.end(err)
+	}
+
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("*").Register("metrics:before_create", before),
+		callback.Create().After("*").Register("metrics:after_create", after),
+		callback.Query().Before("*").Register("metrics:before_query", before),
+		callback.Query().After("*").Register("metrics:after_query", after),
+		callback.Update().Before("*").Register("metrics:before_update", before),
+		callback.Update().After("*").Register("metrics:after_update", after),
+		callback.Delete().Before("*").Register("metrics:before_delete", before),
+		callback.Delete().After("*").Register("metrics:after_delete", after),
+		callback.Row().Before("*").Register("metrics:before_row", before),
+		callback.Row().After("*").Register("metrics:after_row", after),
+		callback.Raw().Before("*").Register("metrics:before_raw", before),
+		callback.Raw().After("*").Register("metrics:after_raw", after),
+	)
+}
+
+type redisCallKey struct{}
+
+// RedisHook measure and trace every command and pipeline, register with client.AddHook
+type RedisHook struct{}
+
+func (RedisHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	ctx, call := startRepoCall(ctx, StoreRedis, attribute.String("db.operation", cmd.Name()))
+	return context.WithValue(ctx, redisCallKey{}, call), nil
+}
+
+func (RedisHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	endRedis(ctx, cmd.Err())
+	return nil
+}
+
+func (RedisHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	ctx, call := startRepoCall(ctx, StoreRedis, attribute.String("db.operation", "pipeline"), attribute.Int("db.redis.commands", len(cmds)))
+	return context.WithValue(ctx, redisCallKey{}, call), nil
+}
+
+func (RedisHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if cmd.Err() != nil && cmd.Err() != redis.Nil {
+			err = cmd.Err()
+			break
+		}
+	}
+	endRedis(ctx, err)
+	return nil
+}
+
+func endRedis(ctx context.Context, err error) {
+	call, ok := ctx.Value(redisCallKey{}).(*repoCall)
+	if !ok {
+		return
+	}
+	// missing key is a result, not a failure
+	if err == redis.Nil {
+		err = nil
+	}
+	call.end(err)
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import "testing"
+
+func TestParseRepoMethod(t *testing.T) {
+	testCases := []struct {
+		name   string
+		repo   string
+		method string
+		ok     bool
+	}{
+		{name: "(*mysqlTransactionRepoImpl).GetById", repo: "mysqlTransactionRepoImpl", method: "GetById", ok: true},
+		{name: "(*redisUserCacheRepoImpl).SetAccount.func1", repo: "redisUserCacheRepoImpl", method: "SetAccount", ok: true},
+		{name: "(*mysqlScheduleRepoImpl[...]).GetDue", repo: "mysqlScheduleRepoImpl", method: "GetDue", ok: true},
+		{name: "NewMysqlTransactionRepo", ok: false},
+		{name: "init.func1", ok: false},
+	}
+
+	for _, tc := range testCases {
+		repo, method, ok := parseRepoMethod(tc.name)
+		if repo != tc.repo || method != tc.method || ok != tc.ok {
+			t.Errorf("%s: got %q %q %v want %q %q %v", tc.name, repo, method, ok, tc.repo, tc.method, tc.ok)
+		}
+	}
+}
+
+type fakeRepoImpl struct{}
+
+func (*fakeRepoImpl) GetById() (string, string) {
+	return call()
+}
+
+// call stand for gorm callback or redis hook between repo method and repoCaller
+func call() (string, string) {
+	return func() (string, string) {
+		return repoCaller("money_forward_code_challenge/internal/common/metrics")
+	}()
+}
+
+func TestRepoCaller(t *testing.T) {
+	repo, method := (&fakeRepoImpl{}).GetById()
+	if repo != "fakeRepoImpl" || method != "GetById" {
+		t.Errorf("got %s %s", repo, method)
+	}
+
+	repo, method = call()
+	if repo != "other" || method != "other" {
+		t.Errorf("call outside repo got %s %s", repo, method)
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/httpresponse"
+)
+
+var (
+	ErrAdminDisabled     = exception.New(exception.Forbidden, "ADMIN_API_DISABLED", "admin api is disabled, admin.token is not set")
+	ErrAdminTokenInvalid = exception.New(exception.Forbidden, "ADMIN_TOKEN_INVALID", "Authorization: Bearer <admin token> is missing or invalid")
+)
+
+// AdminToken allow request only with header Authorization: Bearer <token>
+// empty token disable every route of group, admin api is never open by default
+func AdminToken(token string) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		res := &httpresponse.Response{}
+		if token == "" {
+			httpresponse.Write(ginCtx, res.TransformToError(ErrAdminDisabled))
+			ginCtx.Abort()
+			return
+		}
+
+		given, ok := strings.CutPrefix(ginCtx.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			httpresponse.Write(ginCtx, res.TransformToError(ErrAdminTokenInvalid))
+			ginCtx.Abort()
+			return
+		}
+		ginCtx.Next()
+	}
+}
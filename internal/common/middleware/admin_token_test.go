@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAdminToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newRouter := func(token string) *gin.Engine {
+		router := gin.New()
+		router.POST("/api/admin/approve", AdminToken(token), func(ginCtx *gin.Context) {
+			ginCtx.Status(http.StatusAccepted)
+		})
+		return router
+	}
+
+	testCases := []struct {
+		name   string
+		token  string
+		header string
+		status int
+		code   string
+	}{
+		{name: "disabled without token", token: "", header: "Bearer ", status: http.StatusForbidden, code: "ADMIN_API_DISABLED"},
+		{name: "missing header", token: "s3cret", status: http.StatusForbidden, code: "ADMIN_TOKEN_INVALID"},
+		{name: "wrong token", token: "s3cret", header: "Bearer other", status: http.StatusForbidden, code: "ADMIN_TOKEN_INVALID"},
+		{name: "not bearer", token: "s3cret", header: "s3cret", status: http.StatusForbidden, code: "ADMIN_TOKEN_INVALID"},
+		{name: "valid token", token: "s3cret", header: "Bearer s3cret", status: http.StatusAccepted},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/admin/approve", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			recorder := httptest.NewRecorder()
+			newRouter(tc.token).ServeHTTP(recorder, req)
+
+			if recorder.Code != tc.status {
+				t.Fatalf("status got %d want %d", recorder.Code, tc.status)
+			}
+			if tc.code != "" && !strings.Contains(recorder.Body.String(), tc.code) {
+				t.Errorf("body %s want code %s", recorder.Body.String(), tc.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"money_forward_code_challenge/internal/common/metrics"
+)
+
+// Metrics observe latency and status of every request by route template
+// route is "/api/users/:id/transactions/", unmatched paths share one label to keep cardinality low
+func Metrics() gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		start := time.Now()
+		ginCtx.Next()
+
+		route := ginCtx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.HTTPRequestDuration.
+			WithLabelValues(ginCtx.Request.Method, route, strconv.Itoa(ginCtx.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/httpresponse"
+)
+
+type OpenAPIValidatorOption struct {
+	// responses are already sent when they are validated
+	// so mismatch is only reported, tests use it to fail on drift
+	ValidateResponses bool
+	OnResponseError   func(ginCtx *gin.Context, err error)
+}
+
+// responseRecorder keep copy of body for response validation
+type responseRecorder struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
+
+// NewOpenAPIValidator reject requests which do not match spec with 400
+// requests to paths which are not in spec are passed through
+func NewOpenAPIValidator(doc *openapi3.T, logger *zap.Logger, option OpenAPIValidatorOption) (gin.HandlerFunc, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if option.OnResponseError == nil {
+		option.OnResponseError = func(ginCtx *gin.Context, err error) {
+			logger.Warn("[OpenAPIValidator-Response]", zap.String("Path", ginCtx.FullPath()), zap.String("Error", err.Error()))
+		}
+	}
+
+	filterOptions := &openapi3filter.Options{
+		// every parameter and body field error is reported in one response
+		MultiError:         true,
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(ginCtx *gin.Context) {
+		route, pathParams, err := router.FindRoute(ginCtx.Request)
+		if err != nil {
+			ginCtx.Next()
+			return
+		}
+
+		requestInput := &openapi3filter.RequestValidationInput{
+			Request:    ginCtx.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    filterOptions,
+		}
+		err = openapi3filter.ValidateRequest(ginCtx.Request.Context(), requestInput)
+		if err != nil {
+			res := &httpresponse.Response{}
+			httpresponse.Write(ginCtx, res.TransformToError(exception.Invalid(err.Error(), fieldErrors(err)...)))
+			ginCtx.Abort()
+			return
+		}
+
+		if !option.ValidateResponses || isEventStream(route) {
+			ginCtx.Next()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: ginCtx.Writer, body: &bytes.Buffer{}}
+		ginCtx.Writer = recorder
+		ginCtx.Next()
+
+		responseInput := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: requestInput,
+			Status:                 recorder.Status(),
+			Header:                 recorder.Header(),
+			Body:                   io.NopCloser(bytes.NewReader(recorder.body.Bytes())),
+			Options:                filterOptions,
+		}
+		err = openapi3filter.ValidateResponse(ginCtx.Request.Context(), responseInput)
+		if err != nil {
+			option.OnResponseError(ginCtx, err)
+		}
+	}, nil
+}
+
+// fieldErrors of parameters and body which do not match spec
+func fieldErrors(err error) []exception.FieldError {
+	if multiErr, ok := err.(openapi3.MultiError); ok {
+		var fieldErrs []exception.FieldError
+		for _, err := range multiErr {
+			fieldErrs = append(fieldErrs, fieldErrors(err)...)
+		}
+		return fieldErrs
+	}
+
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return nil
+	}
+	// schema errors of one parameter or body are nested in its request error
+	if schemaErrs, ok := requestErr.Err.(openapi3.MultiError); ok {
+		fieldErrs := make([]exception.FieldError, 0, len(schemaErrs))
+		for _, schemaErr := range schemaErrs {
+			fieldErrs = append(fieldErrs, fieldError(requestErr, schemaErr))
+		}
+		return fieldErrs
+	}
+	return []exception.FieldError{fieldError(requestErr, requestErr)}
+}
+
+// fieldError of err in parameter or body of requestErr
+func fieldError(requestErr *openapi3filter.RequestError, err error) exception.FieldError {
+	fieldError := exception.FieldError{
+		Field:   "body",
+		Code:    "invalid",
+		Message: requestErr.Reason,
+	}
+	if requestErr.Parameter != nil {
+		fieldError.Field = requestErr.Parameter.Name
+	}
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		if pointer := schemaErr.JSONPointer(); len(pointer) > 0 && requestErr.Parameter == nil {
+			fieldError.Field = strings.Join(pointer, ".")
+		}
+		fieldError.Code = schemaErr.SchemaField
+		fieldError.Message = schemaErr.Reason
+	}
+	if fieldError.Message == "" {
+		fieldError.Message = err.Error()
+	}
+	return fieldError
+}
+
+// isEventStream streams are never buffered
+func isEventStream(route *routers.Route) bool {
+	for _, response := range route.Operation.Responses.Map() {
+		if response.Value == nil {
+			continue
+		}
+		for contentType := range response.Value.Content {
+			if strings.HasPrefix(contentType, "text/event-stream") {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,185 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/httpresponse"
+)
+
+const testSpec = `
+openapi: 3.0.3
+info:
+  title: test
+  version: "1"
+servers:
+  - url: /
+paths:
+  /api/users/{id}/items/:
+    post:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+            minimum: 1
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [amount]
+              properties:
+                amount:
+                  type: number
+                currency:
+                  type: string
+                  enum: [VND, USD]
+      responses:
+        "201":
+          description: created
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+                properties:
+                  id:
+                    type: integer
+`
+
+func newTestRouter(t *testing.T, body string, onResponseError func(*gin.Context, error)) *gin.Engine {
+	t.Helper()
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = doc.Validate(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validator, err := NewOpenAPIValidator(doc, zap.NewNop(), OpenAPIValidatorOption{
+		ValidateResponses: true,
+		OnResponseError:   onResponseError,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(validator)
+	handler := func(ginCtx *gin.Context) {
+		ginCtx.Data(http.StatusCreated, "application/json", []byte(body))
+	}
+	router.POST("/api/users/:id/items/", handler)
+	router.POST("/not-in-spec", handler)
+	return router
+}
+
+func serve(router *gin.Engine, path string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestOpenAPIValidatorRequest(t *testing.T) {
+	router := newTestRouter(t, `{"id": 1}`, func(ginCtx *gin.Context, err error) {
+		t.Errorf("unexpected response error: %v", err)
+	})
+
+	testCases := []struct {
+		name string
+		path string
+		body string
+		code int
+	}{
+		{name: "valid", path: "/api/users/1/items/", body: `{"amount": 10}`, code: http.StatusCreated},
+		{name: "missing required field", path: "/api/users/1/items/", body: `{}`, code: http.StatusBadRequest},
+		{name: "wrong field type", path: "/api/users/1/items/", body: `{"amount": "10"}`, code: http.StatusBadRequest},
+		{name: "invalid path param", path: "/api/users/0/items/", body: `{"amount": 10}`, code: http.StatusBadRequest},
+		{name: "not in spec", path: "/not-in-spec", body: `not json`, code: http.StatusCreated},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := serve(router, tc.path, tc.body)
+			if recorder.Code != tc.code {
+				t.Errorf("got %d want %d, body %s", recorder.Code, tc.code, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestOpenAPIValidatorProblem(t *testing.T) {
+	router := newTestRouter(t, `{"id": 1}`, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/users/1/items/", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", httpresponse.ProblemContentType)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	var problem httpresponse.Problem
+	err := json.Unmarshal(recorder.Body.Bytes(), &problem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorder.Header().Get("Content-Type") != httpresponse.ProblemContentType || problem.Status != http.StatusBadRequest {
+		t.Fatalf("got %s %+v", recorder.Header().Get("Content-Type"), problem)
+	}
+	if len(problem.Errors) != 1 || problem.Errors[0].Field != "amount" || problem.Errors[0].Code != "required" {
+		t.Errorf("got field errors %+v", problem.Errors)
+	}
+}
+
+func TestOpenAPIValidatorAllFieldErrors(t *testing.T) {
+	router := newTestRouter(t, `{"id": 1}`, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/users/1/items/", strings.NewReader(`{"currency": "EUR"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", httpresponse.ProblemContentType)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	var problem httpresponse.Problem
+	err := json.Unmarshal(recorder.Body.Bytes(), &problem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codes := map[string]string{}
+	for _, fieldErr := range problem.Errors {
+		codes[fieldErr.Field] = fieldErr.Code
+	}
+	if len(problem.Errors) != 2 || codes["amount"] != "required" || codes["currency"] != "enum" {
+		t.Errorf("got field errors %+v", problem.Errors)
+	}
+}
+
+func TestOpenAPIValidatorResponse(t *testing.T) {
+	var responseErr error
+	router := newTestRouter(t, `{"name": "no id"}`, func(ginCtx *gin.Context, err error) {
+		responseErr = err
+	})
+
+	recorder := serve(router, "/api/users/1/items/", `{"amount": 10}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("response is already sent, got %d", recorder.Code)
+	}
+	if responseErr == nil {
+		t.Fatal("expected response mismatch to be reported")
+	}
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"money_forward_code_challenge/internal/common/logging"
+)
+
+const RequestIdHeader = "X-Request-ID"
+
+// RequestID accept X-Request-ID of caller or generate one, return it in response header
+// request scoped logger is put in context, then one access log line is written per request
+// run after Tracing so trace_id is in logger
+func RequestID(logger *zap.Logger) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		start := time.Now()
+		requestId := ginCtx.GetHeader(RequestIdHeader)
+		if !validRequestId(requestId) {
+			requestId = uuid.NewString()
+		}
+		ginCtx.Header(RequestIdHeader, requestId)
+
+		fields := []zap.Field{zap.String("request_id", requestId)}
+		span := trace.SpanFromContext(ginCtx.Request.Context())
+		if span.SpanContext().IsValid() {
+			fields = append(fields, zap.String("trace_id", span.SpanContext().TraceID().String()))
+			span.SetAttributes(attribute.String("request.id", requestId))
+		}
+		requestLogger := logger.With(fields...)
+
+		ctx := logging.WithRequestId(ginCtx.Request.Context(), requestId)
+		ctx = logging.NewContext(ctx, requestLogger)
+		ginCtx.Request = ginCtx.Request.WithContext(ctx)
+
+		ginCtx.Next()
+
+		status := ginCtx.Writer.Status()
+		level := zapcore.InfoLevel
+		if status >= 500 {
+			level = zapcore.ErrorLevel
+		} else if status >= 400 {
+			level = zapcore.WarnLevel
+		}
+		accessFields := []zap.Field{
+			zap.String("method", ginCtx.Request.Method),
+			zap.String("route", ginCtx.FullPath()),
+			zap.String("path", ginCtx.Request.URL.Path),
+			zap.Int("status", status),
+			zap.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			zap.Int("bytes", ginCtx.Writer.Size()),
+			zap.String("client_ip", ginCtx.ClientIP()),
+			zap.String("user_agent", ginCtx.Request.UserAgent()),
+		}
+		if len(ginCtx.Errors) > 0 {
+			accessFields = append(accessFields, zap.String("errors", ginCtx.Errors.String()))
+		}
+		requestLogger.Log(level, "[AccessLog]", accessFields...)
+	}
+}
+
+// validRequestId keep id of caller only when it is short printable ascii, it is written to logs and headers
+func validRequestId(requestId string) bool {
+	if requestId == "" || len(requestId) > 128 {
+		return false
+	}
+	for _, c := range requestId {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"money_forward_code_challenge/internal/common/logging"
+)
+
+func TestRequestID(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.ContextWithFallback = true
+	router.Use(RequestID(zap.New(core)))
+	router.GET("/items/:id", func(ginCtx *gin.Context) {
+		// use cases log through logger of context
+		logging.FromContext(ginCtx, zap.NewNop()).Info("in use case")
+		ginCtx.String(http.StatusNotFound, logging.RequestId(ginCtx))
+	})
+
+	testCases := []struct {
+		name     string
+		header   string
+		generate bool
+	}{
+		{name: "accept id of caller", header: "abc-123"},
+		{name: "generate when missing", generate: true},
+		{name: "generate when not printable", header: "bad id\x01", generate: true},
+		{name: "generate when too long", header: strings.Repeat("a", 129), generate: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logs.TakeAll()
+			req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+			if tc.header != "" {
+				req.Header.Set(RequestIdHeader, tc.header)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			requestId := recorder.Header().Get(RequestIdHeader)
+			if tc.generate && (requestId == "" || requestId == tc.header) {
+				t.Fatalf("expected generated id, got %q", requestId)
+			}
+			if !tc.generate && requestId != tc.header {
+				t.Fatalf("got %q want %q", requestId, tc.header)
+			}
+			if recorder.Body.String() != requestId {
+				t.Errorf("id in context %q is not id of response %q", recorder.Body.String(), requestId)
+			}
+
+			entries := logs.AllUntimed()
+			if len(entries) != 2 {
+				t.Fatalf("got %d log lines want use case line and one access line", len(entries))
+			}
+			for _, entry := range entries {
+				if entry.ContextMap()["request_id"] != requestId {
+					t.Errorf("%q has no request_id", entry.Message)
+				}
+			}
+			access := entries[1]
+			if access.Message != "[AccessLog]" || access.Level != zapcore.WarnLevel {
+				t.Errorf("got %s %s", access.Level, access.Message)
+			}
+			fields := access.ContextMap()
+			if fields["route"] != "/items/:id" || fields["status"] != int64(http.StatusNotFound) {
+				t.Errorf("access log fields %v", fields)
+			}
+		})
+	}
+}
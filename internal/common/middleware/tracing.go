@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"money_forward_code_challenge/internal/common/tracing"
+)
+
+// Tracing start server span of request, continuing traceparent header of caller
+// span is put in request context, engine need ContextWithFallback so ginCtx passed to services carry it
+func Tracing() gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(ginCtx.Request.Context(), propagation.HeaderCarrier(ginCtx.Request.Header))
+
+		route := ginCtx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		ctx, span := tracing.Tracer().Start(ctx, fmt.Sprintf("%s %s", ginCtx.Request.Method, route),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", ginCtx.Request.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", ginCtx.Request.URL.Path),
+			),
+		)
+		defer span.End()
+
+		ginCtx.Request = ginCtx.Request.WithContext(ctx)
+		ginCtx.Next()
+
+		status := ginCtx.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= 500 {
+			span.SetStatus(codes.Error, fmt.Sprintf("status %d", status))
+		}
+		for _, err := range ginCtx.Errors {
+			span.RecordError(err)
+		}
+	}
+}
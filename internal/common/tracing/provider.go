@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"money_forward_code_challenge/internal/common/config"
+)
+
+// ExporterFactory build exporter from config, returned closer is called after exporter shutdown
+type ExporterFactory func(cfg config.Tracing) (sdktrace.SpanExporter, io.Closer, error)
+
+var (
+	exportersLock = &sync.Mutex{}
+	exporters     = map[string]ExporterFactory{
+		"stdout": func(cfg config.Tracing) (sdktrace.SpanExporter, io.Closer, error) {
+			exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+			return exporter, nil, err
+		},
+		// one json span per line, read with jq or import into a local collector
+		"file": func(cfg config.Tracing) (sdktrace.SpanExporter, io.Closer, error) {
+			file, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if err != nil {
+				return nil, nil, err
+			}
+			exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+			if err != nil {
+				_ = file.Close()
+				return nil, nil, err
+			}
+			return exporter, file, nil
+		},
+	}
+)
+
+// RegisterExporter add exporter selectable by tracing.exporter (e.g. otlp grpc to a collector)
+func RegisterExporter(name string, factory ExporterFactory) {
+	exportersLock.Lock()
+	defer exportersLock.Unlock()
+	exporters[name] = factory
+}
+
+// Setup install global tracer provider and w3c propagator
+// exporter none keep noop provider, spans cost nothing
+// returned shutdown flush pending spans, call it at exit
+func Setup(cfg config.Tracing, serviceName string) (func(ctx context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if cfg.Exporter == "none" {
+		return func(ctx context.Context) error { return nil }, nil
+	}
+
+	exportersLock.Lock()
+	factory, ok := exporters[cfg.Exporter]
+	names := make([]string, 0, len(exporters))
+	for name := range exporters {
+		names = append(names, name)
+	}
+	exportersLock.Unlock()
+	if !ok {
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown tracing exporter %q, expect none, %s", cfg.Exporter, strings.Join(names, ", "))
+	}
+
+	exporter, closer, err := factory(cfg)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			closeErr := closer.Close()
+			if err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}, nil
+}
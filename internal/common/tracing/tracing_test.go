@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"money_forward_code_challenge/internal/common/config"
+)
+
+func TestSetupFileExporter(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := Setup(config.Tracing{Exporter: "file", File: file, SampleRatio: 1}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, parent := Start(context.Background(), "parent")
+	_, child := Start(ctx, "child")
+	End(child, errors.New("boom"))
+	End(parent, nil)
+
+	err = shutdown(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d spans want 2: %s", len(lines), content)
+	}
+	if !strings.Contains(lines[0], `"Name":"child"`) || !strings.Contains(lines[0], "boom") {
+		t.Errorf("child span with error is not exported: %s", lines[0])
+	}
+}
+
+func TestSetupUnknownExporter(t *testing.T) {
+	_, err := Setup(config.Tracing{Exporter: "jaeger"}, "test")
+	if err == nil || !strings.Contains(err.Error(), "none, file, stdout") {
+		t.Fatalf("got %v", err)
+	}
+
+	shutdown, err := Setup(config.Tracing{Exporter: "none"}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shutdown(context.Background()) != nil {
+		t.Fatal("noop shutdown failed")
+	}
+}
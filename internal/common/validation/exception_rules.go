@@ -0,0 +1,26 @@
+package validation
+
+import "money_forward_code_challenge/internal/common/exception"
+
+// rules of legacy checks, message format "[Name] ->>> expects: [...], !got: [...]" is kept for clients which parse it
+
+// Amount is exception.CheckErrAmountValue, min and max are included
+func Amount(min float32, max float32) Rule {
+	return NewRule("range", func() exception.Error {
+		return exception.NewCheckErrAmountValue(min, max)
+	})
+}
+
+// TransactionType is exception.CheckExceptionTransactionType
+func TransactionType(expecteds []string) Rule {
+	return NewRule("one_of", func() exception.Error {
+		return exception.NewCheckExceptionTransactionType(expecteds)
+	})
+}
+
+// BankType is exception.CheckExceptionBankTypeAccount
+func BankType(expecteds []string) Rule {
+	return NewRule("one_of", func() exception.Error {
+		return exception.NewCheckExceptionBankTypeAccount(expecteds)
+	})
+}
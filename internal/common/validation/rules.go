@@ -0,0 +1,102 @@
+package validation
+
+import (
+	"cmp"
+	"fmt"
+	"money_forward_code_challenge/internal/common/exception"
+	"regexp"
+	"strings"
+)
+
+// check is exception.Error of func, message is empty when value is valid
+type check struct {
+	fn        func(value any) string
+	errString string
+}
+
+func (c *check) Check(value any) {
+	if c.errString != "" {
+		return
+	}
+	c.errString = c.fn(value)
+}
+
+func (c *check) Error() string {
+	return c.errString
+}
+
+// Custom rule, fn return message when value is invalid and empty string when valid
+func Custom(code string, fn func(value any) string) Rule {
+	return NewRule(code, func() exception.Error {
+		return &check{fn: fn}
+	})
+}
+
+// Required fail on zero value (0, "", nil, empty struct)
+func Required() Rule {
+	return Custom("required", func(value any) string {
+		if isZero(value) {
+			return "is required"
+		}
+		return ""
+	})
+}
+
+// Range of value, min and max are included
+func Range[T cmp.Ordered](min T, max T) Rule {
+	return Custom("range", func(value any) string {
+		typed, ok := value.(T)
+		if !ok {
+			return fmt.Sprintf("must be %T", min)
+		}
+		if typed < min || typed > max {
+			return fmt.Sprintf("must be between %v and %v", min, max)
+		}
+		return ""
+	})
+}
+
+// Min of value, included
+func Min[T cmp.Ordered](min T) Rule {
+	return Custom("min", func(value any) string {
+		typed, ok := value.(T)
+		if !ok {
+			return fmt.Sprintf("must be %T", min)
+		}
+		if typed < min {
+			return fmt.Sprintf("must be greater than or equal to %v", min)
+		}
+		return ""
+	})
+}
+
+// OneOf values
+func OneOf[T comparable](values ...T) Rule {
+	return Custom("one_of", func(value any) string {
+		typed, ok := value.(T)
+		if ok {
+			for _, expect := range values {
+				if typed == expect {
+					return ""
+				}
+			}
+		}
+		expects := make([]string, len(values))
+		for i, expect := range values {
+			expects[i] = fmt.Sprintf("%v", expect)
+		}
+		return fmt.Sprintf("must be one of [%s]", strings.Join(expects, ", "))
+	})
+}
+
+// Regex match of string value, pattern is compiled once (panic on invalid pattern, like regexp.MustCompile)
+func Regex(pattern string) Rule {
+	compiled := regexp.MustCompile(pattern)
+	return Custom("regex", func(value any) string {
+		typed, ok := value.(string)
+		if !ok || !compiled.MatchString(typed) {
+			return fmt.Sprintf("must match %s", pattern)
+		}
+		return ""
+	})
+}
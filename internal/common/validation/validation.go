@@ -0,0 +1,105 @@
+package validation
+
+import (
+	"fmt"
+	"money_forward_code_challenge/internal/common/exception"
+	"reflect"
+	"strings"
+)
+
+// Validatable is request which declare its own rules
+type Validatable interface {
+	Validate() error
+}
+
+// Rule make check of one field, checks are stateful (exception.Error)
+// so every field get fresh one, legacy checks of exception package are rules too
+type Rule struct {
+	Code     string
+	NewCheck func() exception.Error
+}
+
+// NewRule of code, e.g. NewRule("one_of", func() exception.Error { return exception.NewCheckExceptionBankTypeAccount(banks) })
+func NewRule(code string, newCheck func() exception.Error) Rule {
+	return Rule{
+		Code:     code,
+		NewCheck: newCheck,
+	}
+}
+
+// Validator collect errors of every field, not only first one
+//
+//	err := validation.New().
+//		Field("account_id", req.AccountId, validation.Required()).
+//		Field("amount", req.Amount, validation.Range[float32](1, 100)).
+//		Err()
+type Validator struct {
+	fields []exception.FieldError
+}
+
+func New() *Validator {
+	return &Validator{}
+}
+
+// Field check value with rules in order, first failing rule is error of field
+func (v *Validator) Field(field string, value any, rules ...Rule) *Validator {
+	for _, rule := range rules {
+		check := rule.NewCheck()
+		check.Check(value)
+		if check.Error() != "" {
+			v.fields = append(v.fields, exception.FieldError{
+				Field:   field,
+				Code:    rule.Code,
+				Message: check.Error(),
+			})
+			break
+		}
+	}
+	return v
+}
+
+// OptionalField is Field when value is set (not zero value)
+func (v *Validator) OptionalField(field string, value any, rules ...Rule) *Validator {
+	if isZero(value) {
+		return v
+	}
+	return v.Field(field, value, rules...)
+}
+
+// Errors of fields, in order of Field calls
+func (v *Validator) Errors() []exception.FieldError {
+	return v.fields
+}
+
+// Err is nil when every field is valid, otherwise exception Validation error with field errors
+// message of one field error is its message, so clients reading message see same text as before
+func (v *Validator) Err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	if len(v.fields) == 1 {
+		return exception.Invalid(v.fields[0].Message, v.fields...)
+	}
+
+	messages := make([]string, len(v.fields))
+	for i, field := range v.fields {
+		messages[i] = fmt.Sprintf("%s: %s", field.Field, field.Message)
+	}
+	return exception.Invalid(strings.Join(messages, "; "), v.fields...)
+}
+
+// Validate req when it declare its rules
+func Validate(req any) error {
+	validatable, ok := req.(Validatable)
+	if !ok {
+		return nil
+	}
+	return validatable.Validate()
+}
+
+func isZero(value any) bool {
+	if value == nil {
+		return true
+	}
+	return reflect.ValueOf(value).IsZero()
+}
@@ -0,0 +1,95 @@
+package validation
+
+import (
+	"errors"
+	"money_forward_code_challenge/internal/common/exception"
+	"reflect"
+	"testing"
+)
+
+func TestRules(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value any
+		rule  Rule
+		valid bool
+	}{
+		{name: "required set", value: uint32(1), rule: Required(), valid: true},
+		{name: "required zero", value: uint32(0), rule: Required(), valid: false},
+		{name: "required empty string", value: "", rule: Required(), valid: false},
+		{name: "range in", value: float32(10), rule: Range[float32](10, 20), valid: true},
+		{name: "range out", value: float32(21), rule: Range[float32](10, 20), valid: false},
+		{name: "range other type", value: 15, rule: Range[float32](10, 20), valid: false},
+		{name: "min", value: float32(0), rule: Min[float32](0), valid: true},
+		{name: "min out", value: float32(-1), rule: Min[float32](0), valid: false},
+		{name: "one of", value: "deposit", rule: OneOf("deposit", "withdraw"), valid: true},
+		{name: "one of out", value: "refund", rule: OneOf("deposit", "withdraw"), valid: false},
+		{name: "regex", value: "acc-12", rule: Regex(`^acc-\d+$`), valid: true},
+		{name: "regex out", value: "acc-x", rule: Regex(`^acc-\d+$`), valid: false},
+		{name: "regex not string", value: 12, rule: Regex(`^\d+$`), valid: false},
+		{name: "amount", value: float32(10000), rule: Amount(10000, 20000000), valid: true},
+		{name: "amount out", value: float32(1), rule: Amount(10000, 20000000), valid: false},
+		{name: "bank", value: "ACB", rule: BankType([]string{"ACB", "VCB"}), valid: true},
+		{name: "bank not string", value: 1, rule: BankType([]string{"ACB", "VCB"}), valid: false},
+		{name: "custom", value: 2, rule: Custom("even", func(value any) string {
+			if value.(int)%2 != 0 {
+				return "must be even"
+			}
+			return ""
+		}), valid: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := New().Field("field", tc.value, tc.rule).Err()
+			if (err == nil) != tc.valid {
+				t.Fatalf("valid %v, got %v", tc.valid, err)
+			}
+			if err != nil && exception.FieldsOf(err)[0].Code != tc.rule.Code {
+				t.Errorf("code %s, got %+v", tc.rule.Code, exception.FieldsOf(err))
+			}
+		})
+	}
+}
+
+func TestValidatorCollectFields(t *testing.T) {
+	err := New().
+		Field("account_id", uint32(0), Required()).
+		Field("amount", float32(1), Required(), Range[float32](10, 20)).
+		Field("bank", "ACB", OneOf("ACB")).
+		OptionalField("note", "", Required()).
+		Err()
+
+	if !errors.Is(err, exception.Validation) {
+		t.Fatalf("err is not validation, got %v", err)
+	}
+	want := []exception.FieldError{
+		{Field: "account_id", Code: "required", Message: "is required"},
+		{Field: "amount", Code: "range", Message: "must be between 10 and 20"},
+	}
+	if got := exception.FieldsOf(err); !reflect.DeepEqual(got, want) {
+		t.Errorf("fields %+v, got %+v", want, got)
+	}
+	if err.Error() != "account_id: is required; amount: must be between 10 and 20" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
+
+func TestValidatorKeepLegacyMessage(t *testing.T) {
+	err := New().Field("amount", float32(1), Amount(10000, 20000000)).Err()
+
+	want := exception.NewCheckErrAmountValue(10000, 20000000)
+	want.Check(float32(1))
+	if err.Error() != want.Error() {
+		t.Errorf("message %q, got %q", want.Error(), err.Error())
+	}
+}
+
+func TestValidatorValid(t *testing.T) {
+	if err := New().Field("account_id", uint32(1), Required()).Err(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := Validate(struct{}{}); err != nil {
+		t.Errorf("request without rules is valid, got %v", err)
+	}
+}
@@ -7,4 +7,20 @@ type AccountByDetails struct {
 	Bank        string  `json:"bank"`
 	UserId      uint32  `json:"user_id"`
 	CreatedAt   string  `json:"created_at"`
+	// sum of authorized holds
+	HeldAmount float32 `json:"held_amount"`
+	// overdraft facility, limit is only usable when approved
+	OverdraftLimit    float32 `json:"overdraft_limit"`
+	OverdraftApproved bool    `json:"overdraft_approved"`
+	OverdraftRate     float32 `json:"overdraft_rate"`
+	// balance which can be withdrawn or held, balance - held amount + approved overdraft limit
+	AvailableBalance float32 `json:"available_balance" gorm:"-"`
+}
+
+// ComputeAvailableBalance must be called every time balance, held amount or overdraft change
+func (a *AccountByDetails) ComputeAvailableBalance() {
+	a.AvailableBalance = a.Balance - a.HeldAmount
+	if a.OverdraftApproved {
+		a.AvailableBalance += a.OverdraftLimit
+	}
 }
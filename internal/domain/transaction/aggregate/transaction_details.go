@@ -1,7 +1,7 @@
 package aggregate
 
 import (
-	"fmt"
+	"money_forward_code_challenge/internal/domain/transaction/categorization"
 	"time"
 )
 
@@ -17,6 +17,20 @@ type TransactionByDetails struct {
 
 	// field of user id
 	UserId uint32 `json:"user_id"`
+
+	// field of categorization
+	Memo         string `json:"memo"`
+	Counterparty string `json:"counterparty"`
+	Category     string `json:"category"`
+	Tags         string `json:"tags"`
+
+	// fee transaction point to transaction it is charged for
+	ParentTransactionId uint32 `json:"parent_transaction_id,omitempty"`
+	// fee charged on create, it not be persisted
+	FeeTransaction *TransactionByDetails `json:"fee_transaction,omitempty" gorm:"-"`
+
+	// which rule matched on create, it not be persisted
+	RuleMatch *categorization.Explanation `json:"rule_match,omitempty" gorm:"-"`
 }
 
 var (
@@ -34,8 +48,6 @@ func (t *TransactionByDetails) FormatDateHCM(layout ...string) error {
 		l = layout[0]
 	}
 
-	fmt.Println("time: ", t.CreatedAt)
-
 	timeObj, err := time.Parse(DefaultLayoutDateUTCMST, t.CreatedAt)
 	if err != nil {
 		timeObj, err = time.Parse(time.RFC3339, t.CreatedAt)
@@ -51,3 +63,16 @@ func (t *TransactionByDetails) FormatDateHCM(layout ...string) error {
 	t.CreatedAt = convertedTime.Format(l)
 	return nil
 }
+
+// CreatedAtTime parse back CreatedAt after FormatDateHCM
+func (t *TransactionByDetails) CreatedAtTime() (time.Time, error) {
+	loc := time.FixedZone("UTC+7", 7*60*60)
+	if len(t.CreatedAt) >= len(time.DateTime) {
+		timeObj, err := time.ParseInLocation(time.DateTime, t.CreatedAt[:len(time.DateTime)], loc)
+		if err == nil {
+			return timeObj, nil
+		}
+	}
+
+	return time.Parse(time.RFC3339, t.CreatedAt)
+}
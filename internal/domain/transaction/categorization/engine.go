@@ -0,0 +1,264 @@
+package categorization
+
+import (
+	"fmt"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Subject is what rules are evaluated on
+// it be built from CreateReq on create
+// or from TransactionByDetails when re-run over history
+type Subject struct {
+	Amount          float32
+	TransactionType string
+	Bank            string
+	Memo            string
+	Counterparty    string
+	At              time.Time
+}
+
+// SkippedRule explain why one rule is not matched
+type SkippedRule struct {
+	RuleId   uint32 `json:"rule_id"`
+	RuleName string `json:"rule_name"`
+	Reason   string `json:"reason"`
+}
+
+// Explanation is dry-run result of engine
+// which rule matched (and by what conditions)
+// and why the rules before it are skipped
+type Explanation struct {
+	Matched    bool          `json:"matched"`
+	RuleId     uint32        `json:"rule_id,omitempty"`
+	RuleName   string        `json:"rule_name,omitempty"`
+	Category   string        `json:"category,omitempty"`
+	Tags       []string      `json:"tags,omitempty"`
+	Conditions []string      `json:"conditions,omitempty"`
+	Skipped    []SkippedRule `json:"skipped,omitempty"`
+}
+
+var ErrInvalidRule = exception.New(exception.Validation, "INVALID_RULE", "invalid rule")
+
+var timeOfDayLayout = "15:04"
+
+// rules time of day is on +07:00 same as FormatDateHCM
+var timeOfDayLocation = time.FixedZone("UTC+7", 7*60*60)
+
+// Evaluate run rules on subject, first match (order by priority, id) win
+func Evaluate(rules []*models.CategorizationRule, subject *Subject) *Explanation {
+	ordered := make([]*models.CategorizationRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Enabled {
+			ordered = append(ordered, rule)
+		}
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority == ordered[j].Priority {
+			return ordered[i].ID < ordered[j].ID
+		}
+		return ordered[i].Priority < ordered[j].Priority
+	})
+
+	explanation := &Explanation{}
+	for _, rule := range ordered {
+		conditions, reason := match(rule, subject)
+		if reason != "" {
+			explanation.Skipped = append(explanation.Skipped, SkippedRule{
+				RuleId:   rule.ID,
+				RuleName: rule.Name,
+				Reason:   reason,
+			})
+			continue
+		}
+
+		explanation.Matched = true
+		explanation.RuleId = rule.ID
+		explanation.RuleName = rule.Name
+		explanation.Category = rule.Category
+		explanation.Tags = SplitTags(rule.Tags)
+		explanation.Conditions = conditions
+		return explanation
+	}
+
+	return explanation
+}
+
+// match return conditions matched or reason of first condition failed
+func match(rule *models.CategorizationRule, subject *Subject) ([]string, string) {
+	var conditions []string
+
+	if rule.MinAmount > 0 {
+		if subject.Amount < rule.MinAmount {
+			return nil, fmt.Sprintf("amount %.2f < min_amount %.2f", subject.Amount, rule.MinAmount)
+		}
+		conditions = append(conditions, fmt.Sprintf("amount >= %.2f", rule.MinAmount))
+	}
+
+	if rule.MaxAmount > 0 {
+		if subject.Amount > rule.MaxAmount {
+			return nil, fmt.Sprintf("amount %.2f > max_amount %.2f", subject.Amount, rule.MaxAmount)
+		}
+		conditions = append(conditions, fmt.Sprintf("amount <= %.2f", rule.MaxAmount))
+	}
+
+	if rule.TransactionType != "" {
+		if rule.TransactionType != subject.TransactionType {
+			return nil, fmt.Sprintf("transaction_type %s != %s", subject.TransactionType, rule.TransactionType)
+		}
+		conditions = append(conditions, "transaction_type = "+rule.TransactionType)
+	}
+
+	if rule.Bank != "" {
+		if rule.Bank != subject.Bank {
+			return nil, fmt.Sprintf("bank %s != %s", subject.Bank, rule.Bank)
+		}
+		conditions = append(conditions, "bank = "+rule.Bank)
+	}
+
+	if rule.MemoPattern != "" {
+		ok, err := matchText(rule.MatchMode, rule.MemoPattern, subject.Memo)
+		if err != nil {
+			return nil, err.Error()
+		}
+		if !ok {
+			return nil, fmt.Sprintf("memo not %s %q", rule.MatchMode, rule.MemoPattern)
+		}
+		conditions = append(conditions, fmt.Sprintf("memo %s %q", rule.MatchMode, rule.MemoPattern))
+	}
+
+	if rule.CounterpartyPattern != "" {
+		ok, err := matchText(rule.MatchMode, rule.CounterpartyPattern, subject.Counterparty)
+		if err != nil {
+			return nil, err.Error()
+		}
+		if !ok {
+			return nil, fmt.Sprintf("counterparty not %s %q", rule.MatchMode, rule.CounterpartyPattern)
+		}
+		conditions = append(conditions, fmt.Sprintf("counterparty %s %q", rule.MatchMode, rule.CounterpartyPattern))
+	}
+
+	if rule.TimeOfDayStart != "" && rule.TimeOfDayEnd != "" {
+		ok, err := inTimeOfDay(rule.TimeOfDayStart, rule.TimeOfDayEnd, subject.At)
+		if err != nil {
+			return nil, err.Error()
+		}
+		if !ok {
+			return nil, fmt.Sprintf("time %s not in [%s, %s]", subject.At.In(timeOfDayLocation).Format(timeOfDayLayout), rule.TimeOfDayStart, rule.TimeOfDayEnd)
+		}
+		conditions = append(conditions, fmt.Sprintf("time in [%s, %s]", rule.TimeOfDayStart, rule.TimeOfDayEnd))
+	}
+
+	return conditions, ""
+}
+
+func matchText(mode string, pattern string, value string) (bool, error) {
+	if mode == models.RULEMATCHMODEREGEX {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid regex %q: %v", pattern, err)
+		}
+		return re.MatchString(value), nil
+	}
+
+	return strings.Contains(strings.ToLower(value), strings.ToLower(pattern)), nil
+}
+
+func inTimeOfDay(start string, end string, at time.Time) (bool, error) {
+	startMinute, err := minuteOfDay(start)
+	if err != nil {
+		return false, err
+	}
+	endMinute, err := minuteOfDay(end)
+	if err != nil {
+		return false, err
+	}
+
+	local := at.In(timeOfDayLocation)
+	minute := local.Hour()*60 + local.Minute()
+	if startMinute <= endMinute {
+		return minute >= startMinute && minute <= endMinute, nil
+	}
+
+	// wrap midnight, as 22:00 -> 06:00
+	return minute >= startMinute || minute <= endMinute, nil
+}
+
+func minuteOfDay(value string) (int, error) {
+	t, err := time.Parse(timeOfDayLayout, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expects HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// ValidateRule check rule before save, it compile regex and parse time of day
+func ValidateRule(rule *models.CategorizationRule) error {
+	if rule.MatchMode != "" && rule.MatchMode != models.RULEMATCHMODECONTAINS && rule.MatchMode != models.RULEMATCHMODEREGEX {
+		return fmt.Errorf("%w: match_mode expects one of %v", ErrInvalidRule, models.RULEMATCHMODEEXPECTS)
+	}
+
+	if rule.Category == "" {
+		return fmt.Errorf("%w: category is required", ErrInvalidRule)
+	}
+
+	if rule.MinAmount > 0 && rule.MaxAmount > 0 && rule.MinAmount > rule.MaxAmount {
+		return fmt.Errorf("%w: min_amount must be smaller than max_amount", ErrInvalidRule)
+	}
+
+	if rule.MatchMode == models.RULEMATCHMODEREGEX {
+		for _, pattern := range []string{rule.MemoPattern, rule.CounterpartyPattern} {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Errorf("%w: invalid regex %q: %v", ErrInvalidRule, pattern, err)
+			}
+		}
+	}
+
+	if (rule.TimeOfDayStart == "") != (rule.TimeOfDayEnd == "") {
+		return fmt.Errorf("%w: time_of_day_start and time_of_day_end must be set together", ErrInvalidRule)
+	}
+
+	if rule.TimeOfDayStart != "" {
+		for _, value := range []string{rule.TimeOfDayStart, rule.TimeOfDayEnd} {
+			if _, err := minuteOfDay(value); err != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidRule, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SplitTags split comma separated tags
+func SplitTags(tags string) []string {
+	var ret []string
+	for _, tag := range strings.Split(tags, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			ret = append(ret, tag)
+		}
+	}
+	return ret
+}
+
+// MergeTags join tags without duplicate, keep order of first seen
+func MergeTags(tags ...[]string) string {
+	seen := map[string]bool{}
+	var ret []string
+	for _, group := range tags {
+		for _, tag := range group {
+			tag = strings.TrimSpace(tag)
+			if tag == "" || seen[tag] {
+				continue
+			}
+			seen[tag] = true
+			ret = append(ret, tag)
+		}
+	}
+	return strings.Join(ret, ",")
+}
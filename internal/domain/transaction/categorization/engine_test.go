@@ -0,0 +1,83 @@
+package categorization
+
+import (
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"testing"
+	"time"
+)
+
+func TestEvaluate(t *testing.T) {
+	loc := time.FixedZone("UTC+7", 7*60*60)
+	rules := []*models.CategorizationRule{
+		{ID: 1, Name: "big withdraw", Priority: 10, Enabled: true, MinAmount: 1000000, TransactionType: "withdraw", Category: "large"},
+		{ID: 2, Name: "coffee", Priority: 1, Enabled: true, MatchMode: models.RULEMATCHMODECONTAINS, MemoPattern: "coffee", Category: "food", Tags: "drink, morning"},
+		{ID: 3, Name: "night", Priority: 5, Enabled: true, TimeOfDayStart: "22:00", TimeOfDayEnd: "06:00", Category: "night"},
+		{ID: 4, Name: "disabled", Priority: 0, Enabled: false, Category: "never"},
+		{ID: 5, Name: "grab", Priority: 2, Enabled: true, MatchMode: models.RULEMATCHMODEREGEX, CounterpartyPattern: "^GRAB[0-9]+$", Bank: "VCB", Category: "transport"},
+	}
+
+	cases := []struct {
+		name     string
+		subject  *Subject
+		ruleId   uint32
+		category string
+	}{
+		{"memo contains ignore case", &Subject{Amount: 20000, TransactionType: "withdraw", Memo: "Morning COFFEE", At: time.Date(2024, 5, 30, 9, 0, 0, 0, loc)}, 2, "food"},
+		{"regex counterparty with bank", &Subject{Amount: 20000, Bank: "VCB", Counterparty: "GRAB123", At: time.Date(2024, 5, 30, 9, 0, 0, 0, loc)}, 5, "transport"},
+		{"regex counterparty wrong bank", &Subject{Amount: 20000, Bank: "ACB", Counterparty: "GRAB123", At: time.Date(2024, 5, 30, 9, 0, 0, 0, loc)}, 0, ""},
+		{"time of day wrap midnight", &Subject{Amount: 20000, At: time.Date(2024, 5, 30, 23, 30, 0, 0, loc)}, 3, "night"},
+		{"time of day in utc", &Subject{Amount: 20000, At: time.Date(2024, 5, 30, 20, 0, 0, 0, time.UTC)}, 3, "night"},
+		{"amount range", &Subject{Amount: 2000000, TransactionType: "withdraw", At: time.Date(2024, 5, 30, 12, 0, 0, 0, loc)}, 1, "large"},
+		{"no match", &Subject{Amount: 20000, TransactionType: "deposit", At: time.Date(2024, 5, 30, 12, 0, 0, 0, loc)}, 0, ""},
+	}
+
+	for _, c := range cases {
+		explanation := Evaluate(rules, c.subject)
+		if explanation.RuleId != c.ruleId || explanation.Category != c.category {
+			t.Errorf("case [%s]: expects rule %d (%s), got rule %d (%s)", c.name, c.ruleId, c.category, explanation.RuleId, explanation.Category)
+		}
+		if explanation.Matched != (c.ruleId != 0) {
+			t.Errorf("case [%s]: expects matched %v", c.name, c.ruleId != 0)
+		}
+	}
+
+	explanation := Evaluate(rules, cases[0].subject)
+	if len(explanation.Tags) != 2 || explanation.Tags[0] != "drink" || explanation.Tags[1] != "morning" {
+		t.Errorf("expects tags [drink morning], got %v", explanation.Tags)
+	}
+
+	explanation = Evaluate(rules, cases[5].subject)
+	// coffee, grab, night are checked before big withdraw
+	if len(explanation.Skipped) != 3 {
+		t.Errorf("expects 3 skipped rules, got %v", explanation.Skipped)
+	}
+}
+
+func TestValidateRule(t *testing.T) {
+	cases := []struct {
+		name  string
+		rule  *models.CategorizationRule
+		valid bool
+	}{
+		{"valid", &models.CategorizationRule{Category: "food", MemoPattern: "coffee"}, true},
+		{"missing category", &models.CategorizationRule{MemoPattern: "coffee"}, false},
+		{"invalid regex", &models.CategorizationRule{Category: "food", MatchMode: models.RULEMATCHMODEREGEX, MemoPattern: "(["}, false},
+		{"min greater than max", &models.CategorizationRule{Category: "food", MinAmount: 100, MaxAmount: 10}, false},
+		{"only start time", &models.CategorizationRule{Category: "food", TimeOfDayStart: "10:00"}, false},
+		{"invalid time", &models.CategorizationRule{Category: "food", TimeOfDayStart: "25:00", TimeOfDayEnd: "10:00"}, false},
+	}
+
+	for _, c := range cases {
+		err := ValidateRule(c.rule)
+		if (err == nil) != c.valid {
+			t.Errorf("case [%s]: expects valid %v, got %v", c.name, c.valid, err)
+		}
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	got := MergeTags([]string{"a", "b"}, SplitTags("b, c,,a"))
+	if got != "a,b,c" {
+		t.Errorf("expects a,b,c got %s", got)
+	}
+}
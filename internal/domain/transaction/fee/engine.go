@@ -0,0 +1,143 @@
+package fee
+
+import (
+	"fmt"
+	"math"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"time"
+)
+
+// Subject is what fee schedules are matched on
+type Subject struct {
+	TransactionType string
+	Bank            string
+	Amount          float32
+	// transactions of same type on account in this month, before this one
+	UsedThisMonth int
+}
+
+// Quote is fee of one transaction and which schedule priced it
+type Quote struct {
+	TransactionType string  `json:"transaction_type"`
+	Amount          float32 `json:"amount"`
+	Fee             float32 `json:"fee"`
+	Charged         bool    `json:"charged"`
+	ScheduleId      uint32  `json:"schedule_id,omitempty"`
+	ScheduleName    string  `json:"schedule_name,omitempty"`
+	// free quota of schedule and how many are left after this transaction
+	FreeQuota     int    `json:"free_quota,omitempty"`
+	FreeRemaining int    `json:"free_remaining,omitempty"`
+	Reason        string `json:"reason"`
+}
+
+// fee month is on +07:00 same as FormatDateHCM
+var feeLocation = time.FixedZone("UTC+7", 7*60*60)
+
+// MonthStart is first instant of month of t, quota is counted from it
+func MonthStart(t time.Time) time.Time {
+	local := t.In(feeLocation)
+	return time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, feeLocation)
+}
+
+// Match return schedule pricing subject, nil when none
+// schedule on bank of subject beat any bank one, then narrower band (higher min amount), then lower id
+func Match(schedules []*models.FeeSchedule, subject *Subject) *models.FeeSchedule {
+	var matched *models.FeeSchedule
+	for _, schedule := range schedules {
+		if !schedule.Active || schedule.TransactionType != subject.TransactionType {
+			continue
+		}
+		if schedule.Bank != "" && schedule.Bank != subject.Bank {
+			continue
+		}
+		if subject.Amount < schedule.MinAmount {
+			continue
+		}
+		if schedule.MaxAmount > 0 && subject.Amount >= schedule.MaxAmount {
+			continue
+		}
+		if matched == nil || moreSpecific(schedule, matched) {
+			matched = schedule
+		}
+	}
+	return matched
+}
+
+func moreSpecific(a, b *models.FeeSchedule) bool {
+	if (a.Bank != "") != (b.Bank != "") {
+		return a.Bank != ""
+	}
+	if a.MinAmount != b.MinAmount {
+		return a.MinAmount > b.MinAmount
+	}
+	return a.ID < b.ID
+}
+
+// Calculate price subject with schedule, schedule nil is no fee
+func Calculate(schedule *models.FeeSchedule, subject *Subject) *Quote {
+	quote := &Quote{
+		TransactionType: subject.TransactionType,
+		Amount:          subject.Amount,
+	}
+	if schedule == nil {
+		quote.Reason = "no fee schedule matched"
+		return quote
+	}
+
+	quote.ScheduleId = schedule.ID
+	quote.ScheduleName = schedule.Name
+	quote.FreeQuota = schedule.FreeQuotaPerMonth
+	if subject.UsedThisMonth < schedule.FreeQuotaPerMonth {
+		quote.FreeRemaining = schedule.FreeQuotaPerMonth - subject.UsedThisMonth - 1
+		quote.Reason = fmt.Sprintf("free %d of %d this month", subject.UsedThisMonth+1, schedule.FreeQuotaPerMonth)
+		return quote
+	}
+
+	fee := float64(schedule.FlatFee) + float64(subject.Amount)*float64(schedule.Percentage)
+	quote.Reason = fmt.Sprintf("flat %v + %.4g%% of amount", schedule.FlatFee, float64(schedule.Percentage)*100)
+	if schedule.MinFee > 0 && fee < float64(schedule.MinFee) {
+		fee = float64(schedule.MinFee)
+		quote.Reason = fmt.Sprintf("min fee %v", schedule.MinFee)
+	}
+	if schedule.MaxFee > 0 && fee > float64(schedule.MaxFee) {
+		fee = float64(schedule.MaxFee)
+		quote.Reason = fmt.Sprintf("max fee %v", schedule.MaxFee)
+	}
+
+	quote.Fee = RoundAmount(fee)
+	quote.Charged = quote.Fee > 0
+	return quote
+}
+
+// RoundAmount round half to even on 2 decimals, so same input always give same fee
+func RoundAmount(amount float64) float32 {
+	// snap float32 noise (0.1 * x) before rounding
+	cents := math.Round(amount*100*1e6) / 1e6
+	return float32(math.RoundToEven(cents) / 100)
+}
+
+// Validate check fee schedule before it is saved
+func Validate(schedule *models.FeeSchedule) error {
+	if schedule.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if schedule.MinAmount < 0 || schedule.MaxAmount < 0 {
+		return fmt.Errorf("amount band must not be negative")
+	}
+	if schedule.MaxAmount > 0 && schedule.MaxAmount <= schedule.MinAmount {
+		return fmt.Errorf("max_amount must be greater than min_amount")
+	}
+	if schedule.FlatFee < 0 || schedule.Percentage < 0 || schedule.Percentage > 1 {
+		return fmt.Errorf("flat_fee must not be negative and percentage must be in [0, 1]")
+	}
+	if schedule.MinFee < 0 || schedule.MaxFee < 0 {
+		return fmt.Errorf("min_fee and max_fee must not be negative")
+	}
+	if schedule.MaxFee > 0 && schedule.MaxFee < schedule.MinFee {
+		return fmt.Errorf("max_fee must not be less than min_fee")
+	}
+	if schedule.FreeQuotaPerMonth < 0 {
+		return fmt.Errorf("free_quota_per_month must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package fee
+
+import (
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"testing"
+	"time"
+)
+
+func TestMatchPrefersBankThenNarrowerBand(t *testing.T) {
+	schedules := []*models.FeeSchedule{
+		{ID: 1, Name: "any", TransactionType: "withdraw", Active: true},
+		{ID: 2, Name: "large", TransactionType: "withdraw", MinAmount: 1000000, Active: true},
+		{ID: 3, Name: "vcb", TransactionType: "withdraw", Bank: "VCB", Active: true},
+		{ID: 4, Name: "inactive", TransactionType: "withdraw", Bank: "ACB", Active: false},
+		{ID: 5, Name: "deposit", TransactionType: "deposit", Active: true},
+		{ID: 6, Name: "small", TransactionType: "withdraw", MaxAmount: 50000, Active: true},
+	}
+
+	cases := []struct {
+		bank   string
+		amount float32
+		want   uint32
+	}{
+		{"ACB", 100000, 1},
+		{"ACB", 2000000, 2},
+		{"VCB", 2000000, 3},
+		{"ACB", 10000, 1},
+	}
+	for _, c := range cases {
+		matched := Match(schedules, &Subject{TransactionType: "withdraw", Bank: c.bank, Amount: c.amount})
+		if matched == nil || matched.ID != c.want {
+			t.Fatalf("bank %s amount %v: want schedule %d, got %+v", c.bank, c.amount, c.want, matched)
+		}
+	}
+
+	if matched := Match(schedules, &Subject{TransactionType: "transfer", Amount: 100}); matched != nil {
+		t.Fatalf("want no schedule, got %d", matched.ID)
+	}
+}
+
+func TestMatchBandUpperBoundIsExclusive(t *testing.T) {
+	schedules := []*models.FeeSchedule{
+		{ID: 1, Name: "small", TransactionType: "withdraw", MaxAmount: 50000, Active: true},
+	}
+	if Match(schedules, &Subject{TransactionType: "withdraw", Amount: 49999}) == nil {
+		t.Fatal("want match below max_amount")
+	}
+	if Match(schedules, &Subject{TransactionType: "withdraw", Amount: 50000}) != nil {
+		t.Fatal("want no match on max_amount")
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	schedule := &models.FeeSchedule{ID: 1, Name: "withdraw", FlatFee: 1000, Percentage: 0.01, MinFee: 2000, MaxFee: 50000, Active: true}
+
+	cases := []struct {
+		amount float32
+		want   float32
+	}{
+		// 1000 + 500 < min
+		{50000, 2000},
+		// 1000 + 10000
+		{1000000, 11000},
+		// 1000 + 100000 > max
+		{10000000, 50000},
+	}
+	for _, c := range cases {
+		quote := Calculate(schedule, &Subject{Amount: c.amount})
+		if quote.Fee != c.want || !quote.Charged {
+			t.Fatalf("amount %v: want fee %v, got %+v", c.amount, c.want, quote)
+		}
+	}
+}
+
+func TestCalculateFreeQuota(t *testing.T) {
+	schedule := &models.FeeSchedule{ID: 1, Name: "withdraw", FlatFee: 1000, FreeQuotaPerMonth: 2, Active: true}
+
+	quote := Calculate(schedule, &Subject{Amount: 100000, UsedThisMonth: 0})
+	if quote.Fee != 0 || quote.Charged || quote.FreeRemaining != 1 {
+		t.Fatalf("first: want free with 1 remaining, got %+v", quote)
+	}
+
+	quote = Calculate(schedule, &Subject{Amount: 100000, UsedThisMonth: 1})
+	if quote.Fee != 0 || quote.FreeRemaining != 0 {
+		t.Fatalf("second: want free with 0 remaining, got %+v", quote)
+	}
+
+	quote = Calculate(schedule, &Subject{Amount: 100000, UsedThisMonth: 2})
+	if quote.Fee != 1000 || !quote.Charged {
+		t.Fatalf("third: want charged 1000, got %+v", quote)
+	}
+}
+
+func TestCalculateNoSchedule(t *testing.T) {
+	quote := Calculate(nil, &Subject{Amount: 100000})
+	if quote.Fee != 0 || quote.Charged {
+		t.Fatalf("want no fee, got %+v", quote)
+	}
+}
+
+func TestRoundAmountHalfEven(t *testing.T) {
+	cases := []struct {
+		in   float64
+		want float32
+	}{
+		{0.125, 0.12},
+		{0.135, 0.14},
+		{12.344, 12.34},
+		{float64(float32(0.1)) * 3, 0.3},
+	}
+	for _, c := range cases {
+		if got := RoundAmount(c.in); got != c.want {
+			t.Fatalf("RoundAmount(%v): want %v, got %v", c.in, c.want, got)
+		}
+	}
+}
+
+func TestMonthStart(t *testing.T) {
+	// 2026-10-31 18:00 UTC is 2026-11-01 01:00 +07:00
+	got := MonthStart(time.Date(2026, 10, 31, 18, 0, 0, 0, time.UTC))
+	want := time.Date(2026, 11, 1, 0, 0, 0, 0, feeLocation)
+	if !got.Equal(want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := &models.FeeSchedule{Name: "ok", FlatFee: 1000, MinFee: 100, MaxFee: 2000, MaxAmount: 100}
+	if err := Validate(valid); err != nil {
+		t.Fatalf("want valid, got %v", err)
+	}
+
+	invalids := []*models.FeeSchedule{
+		{Name: ""},
+		{Name: "band", MinAmount: 100, MaxAmount: 50},
+		{Name: "percentage", Percentage: 2},
+		{Name: "caps", MinFee: 100, MaxFee: 50},
+		{Name: "quota", FreeQuotaPerMonth: -1},
+	}
+	for _, schedule := range invalids {
+		if err := Validate(schedule); err == nil {
+			t.Fatalf("want error on %s", schedule.Name)
+		}
+	}
+}
@@ -16,23 +16,34 @@ var (
 
 var ACCOUNTTABLE = "accounts"
 var (
-	ACCOUNTCOLUMN_ID         = ACCOUNTTABLE + "." + "id"
-	ACCOUNTCOLUMN_BANK       = ACCOUNTTABLE + "." + "bank"
-	ACCOUNTCOLUMN_BALANCE    = ACCOUNTTABLE + "." + "balance"
-	ACCOUNTCOLUMN_NAME       = ACCOUNTTABLE + "." + "name"
-	ACCOUNTCOLUMN_USER_ID    = ACCOUNTTABLE + "." + "user_id"
-	ACCOUNTCOLUMN_CREATED_AT = ACCOUNTTABLE + "." + "created_at"
-	ACCOUNTCOLUMN_UPDATED_AT = ACCOUNTTABLE + "." + "updated_at"
-	ACCOUNTCOLUMN_DELETED_AT = ACCOUNTTABLE + "." + "deleted"
+	ACCOUNTCOLUMN_ID                 = ACCOUNTTABLE + "." + "id"
+	ACCOUNTCOLUMN_BANK               = ACCOUNTTABLE + "." + "bank"
+	ACCOUNTCOLUMN_BALANCE            = ACCOUNTTABLE + "." + "balance"
+	ACCOUNTCOLUMN_HELD               = ACCOUNTTABLE + "." + "held_amount"
+	ACCOUNTCOLUMN_OVERDRAFT_LIMIT    = ACCOUNTTABLE + "." + "overdraft_limit"
+	ACCOUNTCOLUMN_OVERDRAFT_APPROVED = ACCOUNTTABLE + "." + "overdraft_approved"
+	ACCOUNTCOLUMN_OVERDRAFT_RATE     = ACCOUNTTABLE + "." + "overdraft_rate"
+	ACCOUNTCOLUMN_NAME               = ACCOUNTTABLE + "." + "name"
+	ACCOUNTCOLUMN_USER_ID            = ACCOUNTTABLE + "." + "user_id"
+	ACCOUNTCOLUMN_CREATED_AT         = ACCOUNTTABLE + "." + "created_at"
+	ACCOUNTCOLUMN_UPDATED_AT         = ACCOUNTTABLE + "." + "updated_at"
+	ACCOUNTCOLUMN_DELETED_AT         = ACCOUNTTABLE + "." + "deleted"
 )
 
 type Account struct {
-	ID        uint32         `gorm:"column:id;primaryKey;autoIncrement;not null"`
-	Bank      string         `gorm:"column:bank;type:char(3);not null"`
-	Balance   float32        `gorm:"column:balance;not null"`
-	Name      string         `gorm:"column:name;type:varchar(255);not null"`
-	UserId    uint32         `gorm:"column:user_id;not null"`
-	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt time.Time      `gorm:"column:updated_at;autoUpdateTime"`
-	Deleted   gorm.DeletedAt `gorm:"colum:deleted;index"`
+	ID      uint32  `gorm:"column:id;primaryKey;autoIncrement;not null"`
+	Bank    string  `gorm:"column:bank;type:char(3);not null"`
+	Balance float32 `gorm:"column:balance;not null"`
+	// sum of authorized holds, available balance = balance - held amount
+	HeldAmount float32 `gorm:"column:held_amount;not null;default:0"`
+	// balance can go down to -overdraft_limit only when approved
+	OverdraftLimit    float32 `gorm:"column:overdraft_limit;not null;default:0"`
+	OverdraftApproved bool    `gorm:"column:overdraft_approved;not null;default:false"`
+	// annual rate charged daily on negative balance, 0.18 mean 18%
+	OverdraftRate float32        `gorm:"column:overdraft_rate;not null;default:0"`
+	Name          string         `gorm:"column:name;type:varchar(255);not null"`
+	UserId        uint32         `gorm:"column:user_id;not null;index"`
+	CreatedAt     time.Time      `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt     time.Time      `gorm:"column:updated_at;autoUpdateTime"`
+	Deleted       gorm.DeletedAt `gorm:"colum:deleted;index"`
 }
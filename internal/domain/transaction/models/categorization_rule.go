@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+)
+
+var (
+	RULEMATCHMODECONTAINS = "contains"
+	RULEMATCHMODEREGEX    = "regex"
+)
+
+var (
+	RULEMATCHMODEEXPECTS = []string{RULEMATCHMODECONTAINS, RULEMATCHMODEREGEX}
+)
+
+var CATEGORIZATIONRULETABLE = "categorization_rules"
+var (
+	CATEGORIZATIONRULECOLUMN_ID       = CATEGORIZATIONRULETABLE + ".id"
+	CATEGORIZATIONRULECOLUMN_USER_ID  = CATEGORIZATIONRULETABLE + ".user_id"
+	CATEGORIZATIONRULECOLUMN_PRIORITY = CATEGORIZATIONRULETABLE + ".priority"
+	CATEGORIZATIONRULECOLUMN_ENABLED  = CATEGORIZATIONRULETABLE + ".enabled"
+)
+
+// CategorizationRule is one user defined rule
+// every condition with zero value is skipped (match any)
+// first enabled rule (order by priority, id) matched all conditions win
+type CategorizationRule struct {
+	ID       uint32 `gorm:"column:id;primaryKey;autoIncrement;not null"`
+	UserId   uint32 `gorm:"column:user_id;not null;index"`
+	Name     string `gorm:"column:name;type:varchar(100);not null"`
+	Priority int    `gorm:"column:priority;not null;default:0"`
+	Enabled  bool   `gorm:"column:enabled;not null"`
+
+	// conditions
+	MinAmount           float32 `gorm:"column:min_amount;not null;default:0"`
+	MaxAmount           float32 `gorm:"column:max_amount;not null;default:0"`
+	TransactionType     string  `gorm:"column:transaction_type;type:varchar(15)"`
+	Bank                string  `gorm:"column:bank;type:char(3)"`
+	MatchMode           string  `gorm:"column:match_mode;type:varchar(10);not null"`
+	MemoPattern         string  `gorm:"column:memo_pattern;type:varchar(255)"`
+	CounterpartyPattern string  `gorm:"column:counterparty_pattern;type:varchar(255)"`
+	// time of day in +07:00 with layout HH:MM, range can wrap midnight (22:00 -> 06:00)
+	TimeOfDayStart string `gorm:"column:time_of_day_start;type:char(5)"`
+	TimeOfDayEnd   string `gorm:"column:time_of_day_end;type:char(5)"`
+
+	// actions
+	Category string `gorm:"column:category;type:varchar(50);not null"`
+	Tags     string `gorm:"column:tags;type:varchar(255)"`
+
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
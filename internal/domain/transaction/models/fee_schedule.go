@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+var FEESCHEDULETABLE = "fee_schedules"
+var (
+	FEESCHEDULECOLUMN_ID               = FEESCHEDULETABLE + ".id"
+	FEESCHEDULECOLUMN_TRANSACTION_TYPE = FEESCHEDULETABLE + ".transaction_type"
+	FEESCHEDULECOLUMN_ACTIVE           = FEESCHEDULETABLE + ".active"
+)
+
+// FeeSchedule price one transaction type, optionally only on one bank and one amount band
+// fee = flat + amount * percentage, then capped by min fee and max fee (0 is no cap)
+// first free_quota_per_month transactions of the type on an account each month have no fee
+type FeeSchedule struct {
+	ID              uint32 `gorm:"column:id;primaryKey;autoIncrement;not null"`
+	Name            string `gorm:"column:name;type:varchar(100);not null"`
+	TransactionType string `gorm:"column:transaction_type;type:varchar(30);not null;index"`
+	// empty match any bank
+	Bank string `gorm:"column:bank;type:char(3)"`
+	// amount band [min_amount, max_amount), max_amount 0 is no upper bound
+	MinAmount float32 `gorm:"column:min_amount;not null;default:0"`
+	MaxAmount float32 `gorm:"column:max_amount;not null;default:0"`
+
+	FlatFee float32 `gorm:"column:flat_fee;not null;default:0"`
+	// 0.01 mean 1% of amount
+	Percentage        float32   `gorm:"column:percentage;not null;default:0"`
+	MinFee            float32   `gorm:"column:min_fee;not null;default:0"`
+	MaxFee            float32   `gorm:"column:max_fee;not null;default:0"`
+	FreeQuotaPerMonth int       `gorm:"column:free_quota_per_month;not null;default:0"`
+	Active            bool      `gorm:"column:active;not null"`
+	CreatedAt         time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt         time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+)
+
+var (
+	HOLDSTATUSAUTHORIZED = "authorized"
+	HOLDSTATUSCAPTURED   = "captured"
+	HOLDSTATUSVOIDED     = "voided"
+	HOLDSTATUSEXPIRED    = "expired"
+)
+
+var HOLDTABLE = "holds"
+var (
+	HOLDCOLUMN_ID              = HOLDTABLE + ".id"
+	HOLDCOLUMN_USER_ID         = HOLDTABLE + ".user_id"
+	HOLDCOLUMN_ACCOUNT_ID      = HOLDTABLE + ".account_id"
+	HOLDCOLUMN_STATUS          = HOLDTABLE + ".status"
+	HOLDCOLUMN_CAPTURED_AMOUNT = HOLDTABLE + ".captured_amount"
+	HOLDCOLUMN_TRANSACTION_ID  = HOLDTABLE + ".transaction_id"
+	HOLDCOLUMN_EXPIRES_AT      = HOLDTABLE + ".expires_at"
+	HOLDCOLUMN_CLOSED_AT       = HOLDTABLE + ".closed_at"
+)
+
+// Hold reserve amount on account, it reduce available balance
+// but ledger balance is only changed on capture (one withdraw transaction)
+type Hold struct {
+	ID           uint32  `gorm:"column:id;primaryKey;autoIncrement;not null"`
+	UserId       uint32  `gorm:"column:user_id;not null;index"`
+	AccountId    uint32  `gorm:"column:account_id;not null;index"`
+	Amount       float32 `gorm:"column:amount;not null"`
+	Memo         string  `gorm:"column:memo;type:varchar(255)"`
+	Counterparty string  `gorm:"column:counterparty;type:varchar(255)"`
+	Status       string  `gorm:"column:status;type:varchar(10);not null;index"`
+	// can be less than amount (partial capture), rest is released
+	CapturedAmount float32 `gorm:"column:captured_amount;not null;default:0"`
+	// withdraw transaction created on capture
+	TransactionId uint32     `gorm:"column:transaction_id;not null;default:0"`
+	ExpiresAt     time.Time  `gorm:"column:expires_at;not null;index"`
+	ClosedAt      *time.Time `gorm:"column:closed_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
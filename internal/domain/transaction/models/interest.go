@@ -0,0 +1,105 @@
+package models
+
+import (
+	"time"
+)
+
+var (
+	INTERESTDAYCOUNTACT365    = "ACT/365"
+	INTERESTDAYCOUNTTHIRTY360 = "30/360"
+)
+
+var (
+	INTERESTDAYCOUNTEXPECTS = []string{INTERESTDAYCOUNTACT365, INTERESTDAYCOUNTTHIRTY360}
+)
+
+var (
+	// accrual base include interest accrued but not posted yet
+	INTERESTCOMPOUNDINGDAILY = "daily"
+	// accrual base is only balance, interest is compounded when posted each month
+	INTERESTCOMPOUNDINGMONTHLY = "monthly"
+)
+
+var (
+	INTERESTCOMPOUNDINGEXPECTS = []string{INTERESTCOMPOUNDINGDAILY, INTERESTCOMPOUNDINGMONTHLY}
+)
+
+var INTERESTPRODUCTTABLE = "interest_products"
+var (
+	INTERESTPRODUCTCOLUMN_ID = INTERESTPRODUCTTABLE + ".id"
+)
+
+type InterestProduct struct {
+	ID   uint32 `gorm:"column:id;primaryKey;autoIncrement;not null"`
+	Name string `gorm:"column:name;type:varchar(100);not null"`
+	// annual rate, 0.05 mean 5%
+	Rate        float32   `gorm:"column:rate;not null"`
+	DayCount    string    `gorm:"column:day_count;type:varchar(10);not null"`
+	Compounding string    `gorm:"column:compounding;type:varchar(10);not null"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+var ACCOUNTINTERESTTABLE = "account_interests"
+var (
+	ACCOUNTINTERESTCOLUMN_ACCOUNT_ID        = ACCOUNTINTERESTTABLE + ".account_id"
+	ACCOUNTINTERESTCOLUMN_PRODUCT_ID        = ACCOUNTINTERESTTABLE + ".product_id"
+	ACCOUNTINTERESTCOLUMN_LAST_ACCRUED_DATE = ACCOUNTINTERESTTABLE + ".last_accrued_date"
+	ACCOUNTINTERESTCOLUMN_ACCRUED_MICRO     = ACCOUNTINTERESTTABLE + ".accrued_micro"
+	ACCOUNTINTERESTCOLUMN_UPDATED_AT        = ACCOUNTINTERESTTABLE + ".updated_at"
+)
+
+// AccountInterest attach product to account and keep accrual state
+type AccountInterest struct {
+	AccountId uint32 `gorm:"column:account_id;primaryKey;autoIncrement:false;not null"`
+	ProductId uint32 `gorm:"column:product_id;not null"`
+	// last local date (2006-01-02) accrued, job catch up every day after it
+	LastAccruedDate string `gorm:"column:last_accrued_date;type:char(10);not null"`
+	// accrued but not posted, in micro unit (1e-6) so rounding is only done on posting
+	AccruedMicro int64     `gorm:"column:accrued_micro;not null;default:0"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+var INTERESTACCRUALTABLE = "interest_accruals"
+var (
+	INTERESTACCRUALCOLUMN_ID           = INTERESTACCRUALTABLE + ".id"
+	INTERESTACCRUALCOLUMN_ACCOUNT_ID   = INTERESTACCRUALTABLE + ".account_id"
+	INTERESTACCRUALCOLUMN_ACCRUAL_DATE = INTERESTACCRUALTABLE + ".accrual_date"
+	INTERESTACCRUALCOLUMN_POSTING_ID   = INTERESTACCRUALTABLE + ".posting_id"
+)
+
+// InterestAccrual is interest of one day, (account_id, accrual_date) is unique
+type InterestAccrual struct {
+	ID          uint32 `gorm:"column:id;primaryKey;autoIncrement;not null"`
+	AccountId   uint32 `gorm:"column:account_id;not null;uniqueIndex:idx_interest_accruals_account_date"`
+	AccrualDate string `gorm:"column:accrual_date;type:char(10);not null;uniqueIndex:idx_interest_accruals_account_date"`
+	// balance (+ accrued when compounding daily) interest is calculated on
+	Base        float64 `gorm:"column:base;not null"`
+	Rate        float32 `gorm:"column:rate;not null"`
+	AmountMicro int64   `gorm:"column:amount_micro;not null"`
+	// 0 until posted
+	PostingId uint32    `gorm:"column:posting_id;not null;default:0;index"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+var INTERESTPOSTINGTABLE = "interest_postings"
+var (
+	INTERESTPOSTINGCOLUMN_ID         = INTERESTPOSTINGTABLE + ".id"
+	INTERESTPOSTINGCOLUMN_ACCOUNT_ID = INTERESTPOSTINGTABLE + ".account_id"
+	INTERESTPOSTINGCOLUMN_MONTH      = INTERESTPOSTINGTABLE + ".month"
+)
+
+// InterestPosting turn accruals of one month into one interest transaction
+// (account_id, month) is unique, so one month is never posted twice
+type InterestPosting struct {
+	ID        uint32 `gorm:"column:id;primaryKey;autoIncrement;not null"`
+	AccountId uint32 `gorm:"column:account_id;not null;uniqueIndex:idx_interest_postings_account_month"`
+	// 2006-01
+	Month       string  `gorm:"column:month;type:char(7);not null;uniqueIndex:idx_interest_postings_account_month"`
+	AmountMicro int64   `gorm:"column:amount_micro;not null"`
+	Amount      float32 `gorm:"column:amount;not null"`
+	// 0 when amount is rounded to 0
+	TransactionId uint32    `gorm:"column:transaction_id;not null;default:0"`
+	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+var OVERDRAFTINTERESTTABLE = "overdraft_interest_postings"
+var (
+	OVERDRAFTINTERESTCOLUMN_ID           = OVERDRAFTINTERESTTABLE + ".id"
+	OVERDRAFTINTERESTCOLUMN_ACCOUNT_ID   = OVERDRAFTINTERESTTABLE + ".account_id"
+	OVERDRAFTINTERESTCOLUMN_ACCRUAL_DATE = OVERDRAFTINTERESTTABLE + ".accrual_date"
+)
+
+// OverdraftInterestPosting is one daily interest charge on negative balance
+// (account_id, accrual_date) is unique, so one day is never charged twice
+type OverdraftInterestPosting struct {
+	ID        uint32 `gorm:"column:id;primaryKey;autoIncrement;not null"`
+	AccountId uint32 `gorm:"column:account_id;not null;uniqueIndex:idx_overdraft_interest_account_date"`
+	// local date (+07:00) as 2006-01-02
+	AccrualDate string `gorm:"column:accrual_date;type:char(10);not null;uniqueIndex:idx_overdraft_interest_account_date"`
+	// negative balance interest is charged on
+	Balance       float32   `gorm:"column:balance;not null"`
+	Rate          float32   `gorm:"column:rate;not null"`
+	Amount        float32   `gorm:"column:amount;not null"`
+	TransactionId uint32    `gorm:"column:transaction_id;not null"`
+	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime"`
+}
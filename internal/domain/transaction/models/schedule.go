@@ -0,0 +1,105 @@
+package models
+
+import (
+	"time"
+)
+
+var (
+	// transfer only be used by schedules
+	// it run as one withdraw on account and one deposit on target account
+	TRANSACTIONTYPETRANSFER = "transfer"
+)
+
+var (
+	SCHEDULETRANSACTIONTYPEEXPECTS = []string{TRANSACTIONTYPEDEPOSIT, TRANSACTIONTYPEWITHDRAW, TRANSACTIONTYPETRANSFER}
+)
+
+var (
+	SCHEDULEFREQUENCYONCE    = "once"
+	SCHEDULEFREQUENCYDAILY   = "daily"
+	SCHEDULEFREQUENCYWEEKLY  = "weekly"
+	SCHEDULEFREQUENCYMONTHLY = "monthly"
+	SCHEDULEFREQUENCYRRULE   = "rrule"
+)
+
+var (
+	SCHEDULEFREQUENCYEXPECTS = []string{SCHEDULEFREQUENCYONCE, SCHEDULEFREQUENCYDAILY, SCHEDULEFREQUENCYWEEKLY, SCHEDULEFREQUENCYMONTHLY, SCHEDULEFREQUENCYRRULE}
+)
+
+var (
+	SCHEDULESTATUSACTIVE    = "active"
+	SCHEDULESTATUSPAUSED    = "paused"
+	SCHEDULESTATUSCOMPLETED = "completed"
+)
+
+var (
+	SCHEDULERUNSTATUSSTARTED   = "started"
+	SCHEDULERUNSTATUSSUCCEEDED = "succeeded"
+	SCHEDULERUNSTATUSFAILED    = "failed"
+)
+
+var SCHEDULETABLE = "schedules"
+var (
+	SCHEDULECOLUMN_ID          = SCHEDULETABLE + ".id"
+	SCHEDULECOLUMN_USER_ID     = SCHEDULETABLE + ".user_id"
+	SCHEDULECOLUMN_STATUS      = SCHEDULETABLE + ".status"
+	SCHEDULECOLUMN_NEXT_RUN_AT = SCHEDULETABLE + ".next_run_at"
+	SCHEDULECOLUMN_LAST_RUN_AT = SCHEDULETABLE + ".last_run_at"
+	SCHEDULECOLUMN_RUN_COUNT   = SCHEDULETABLE + ".run_count"
+	SCHEDULECOLUMN_UPDATED_AT  = SCHEDULETABLE + ".updated_at"
+)
+
+type Schedule struct {
+	ID        uint32 `gorm:"column:id;primaryKey;autoIncrement;not null"`
+	UserId    uint32 `gorm:"column:user_id;not null;index"`
+	AccountId uint32 `gorm:"column:account_id;not null"`
+	// only for transfer
+	TargetAccountId uint32  `gorm:"column:target_account_id;not null;default:0"`
+	Amount          float32 `gorm:"column:amount;not null"`
+	TransactionType string  `gorm:"column:transaction_type;type:varchar(15);not null"`
+	Memo            string  `gorm:"column:memo;type:varchar(255)"`
+	Counterparty    string  `gorm:"column:counterparty;type:varchar(255)"`
+
+	Frequency string `gorm:"column:frequency;type:varchar(10);not null"`
+	// every n days, weeks, months
+	Interval int `gorm:"column:repeat_interval;not null;default:1"`
+	// monthly on day n, 0 mean same day of start_at
+	DayOfMonth int    `gorm:"column:day_of_month;not null;default:0"`
+	RRule      string `gorm:"column:rrule;type:varchar(255)"`
+	// 0 mean unlimited
+	MaxRuns int `gorm:"column:max_runs;not null;default:0"`
+
+	StartAt   time.Time  `gorm:"column:start_at;not null"`
+	NextRunAt time.Time  `gorm:"column:next_run_at;not null;index"`
+	LastRunAt *time.Time `gorm:"column:last_run_at"`
+	RunCount  int        `gorm:"column:run_count;not null;default:0"`
+	Status    string     `gorm:"column:status;type:varchar(10);not null;index"`
+
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+var SCHEDULERUNTABLE = "schedule_runs"
+var (
+	SCHEDULERUNCOLUMN_ID              = SCHEDULERUNTABLE + ".id"
+	SCHEDULERUNCOLUMN_SCHEDULE_ID     = SCHEDULERUNTABLE + ".schedule_id"
+	SCHEDULERUNCOLUMN_IDEMPOTENCY_KEY = SCHEDULERUNTABLE + ".idempotency_key"
+	SCHEDULERUNCOLUMN_STATUS          = SCHEDULERUNTABLE + ".status"
+	SCHEDULERUNCOLUMN_ERROR           = SCHEDULERUNTABLE + ".error"
+	SCHEDULERUNCOLUMN_UPDATED_AT      = SCHEDULERUNTABLE + ".updated_at"
+)
+
+// ScheduleRun is one execution of schedule
+// idempotency key is unique, so one occurrence never be executed twice
+// even when worker restart between claim and finish, run left started is failed after schedule.StaleRunAfter
+type ScheduleRun struct {
+	ID             uint32    `gorm:"column:id;primaryKey;autoIncrement;not null"`
+	ScheduleId     uint32    `gorm:"column:schedule_id;not null;index"`
+	IdempotencyKey string    `gorm:"column:idempotency_key;type:varchar(100);not null;uniqueIndex"`
+	ScheduledAt    time.Time `gorm:"column:scheduled_at;not null"`
+	Status         string    `gorm:"column:status;type:varchar(10);not null"`
+	TransactionId  uint32    `gorm:"column:transaction_id;not null;default:0"`
+	Error          string    `gorm:"column:error;type:varchar(255)"`
+	CreatedAt      time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
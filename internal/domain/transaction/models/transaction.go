@@ -8,11 +8,51 @@ var (
 	TRANSACTIONTYPEEXPECTS = []string{TRANSACTIONTYPEDEPOSIT, TRANSACTIONTYPEWITHDRAW}
 )
 
+// amount of one transaction created by client (deposit, withdraw, transfer, hold)
+const (
+	TRANSACTIONAMOUNTMIN float32 = 10000
+	TRANSACTIONAMOUNTMAX float32 = 20000000
+)
+
 var (
 	TRANSACTIONTYPEDEPOSIT  = "deposit"
 	TRANSACTIONTYPEWITHDRAW = "withdraw"
+	// daily interest on negative balance, only posted by system
+	TRANSACTIONTYPEOVERDRAFTINTEREST = "overdraft_interest"
+	// monthly interest of savings, only posted by system
+	TRANSACTIONTYPEINTEREST = "interest"
+	// fee of other transaction, linked by parent_transaction_id
+	TRANSACTIONTYPEFEE = "fee"
+)
+
+var (
+	// debit types decrease balance, every other type increase it
+	TRANSACTIONDEBITTYPES = []string{TRANSACTIONTYPEWITHDRAW, TRANSACTIONTYPEOVERDRAFTINTEREST, TRANSACTIONTYPEFEE}
+)
+
+var (
+	// system types are only posted by system, client can not reverse them
+	TRANSACTIONSYSTEMTYPES = []string{TRANSACTIONTYPEOVERDRAFTINTEREST, TRANSACTIONTYPEINTEREST, TRANSACTIONTYPEFEE}
 )
 
+func IsSystemTransactionType(transactionType string) bool {
+	for _, systemType := range TRANSACTIONSYSTEMTYPES {
+		if systemType == transactionType {
+			return true
+		}
+	}
+	return false
+}
+
+func IsDebitTransactionType(transactionType string) bool {
+	for _, debitType := range TRANSACTIONDEBITTYPES {
+		if debitType == transactionType {
+			return true
+		}
+	}
+	return false
+}
+
 var TRANSACTIONTABLE = "transactions"
 var (
 	TRANSACTIONCOLUMN_ID               = TRANSACTIONTABLE + ".id"
@@ -22,14 +62,29 @@ var (
 	TRANSACTIONCOLUMN_CREATED_AT       = TRANSACTIONTABLE + ".created_at"
 	TRANSACTIONCOLUMN_UPDATED_AT       = TRANSACTIONTABLE + ".updated_at"
 	TRANSACTIONCOLUMN_DELETED          = TRANSACTIONTABLE + ".deleted"
+	TRANSACTIONCOLUMN_REVERSED_AT      = TRANSACTIONTABLE + ".reversed_at"
+	TRANSACTIONCOLUMN_MEMO             = TRANSACTIONTABLE + ".memo"
+	TRANSACTIONCOLUMN_COUNTERPARTY     = TRANSACTIONTABLE + ".counterparty"
+	TRANSACTIONCOLUMN_CATEGORY         = TRANSACTIONTABLE + ".category"
+	TRANSACTIONCOLUMN_TAGS             = TRANSACTIONTABLE + ".tags"
+	TRANSACTIONCOLUMN_PARENT_ID        = TRANSACTIONTABLE + ".parent_transaction_id"
 )
 
 type Transaction struct {
 	ID              uint32    `gorm:"column:id;primaryKey;autoIncrement;not null"`
-	AccountID       uint32    `gorm:"column:account_id;not null"`
+	AccountID       uint32    `gorm:"column:account_id;not null;index"`
 	Amount          float32   `gorm:"column:amount;not null"`
-	TransactionType string    `gorm:"column:transaction_type;type:varchar(15);not null"`
+	TransactionType string    `gorm:"column:transaction_type;type:varchar(30);not null"`
 	CreatedAt       time.Time `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt       time.Time `gorm:"column:updated_at;autoUpdateTime"`
 	Deleted         bool      `gorm:"column:deleted;index"`
+	Memo            string    `gorm:"column:memo;type:varchar(255)"`
+	Counterparty    string    `gorm:"column:counterparty;type:varchar(255)"`
+	Category        string    `gorm:"column:category;type:varchar(50);index"`
+	// tags is comma separated, as (food,lunch)
+	Tags string `gorm:"column:tags;type:varchar(255)"`
+	// fee transaction point to transaction it is charged for, 0 otherwise
+	ParentTransactionId uint32 `gorm:"column:parent_transaction_id;not null;default:0;index"`
+	// set with deleted, balance at end of days before it still include transaction
+	ReversedAt *time.Time `gorm:"column:reversed_at"`
 }
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+)
+
+var (
+	WEBHOOKEVENTTRANSACTIONCREATED = "transaction.created"
+	WEBHOOKEVENTTRANSACTIONDELETED = "transaction.deleted"
+	WEBHOOKEVENTBALANCEUPDATED     = "balance.updated"
+)
+
+var (
+	WEBHOOKEVENTEXPECTS = []string{WEBHOOKEVENTTRANSACTIONCREATED, WEBHOOKEVENTTRANSACTIONDELETED, WEBHOOKEVENTBALANCEUPDATED}
+)
+
+var (
+	// waiting for first attempt or retry at next_attempt_at
+	WEBHOOKDELIVERYSTATUSPENDING   = "pending"
+	WEBHOOKDELIVERYSTATUSDELIVERED = "delivered"
+	// attempts exhausted
+	WEBHOOKDELIVERYSTATUSFAILED = "failed"
+)
+
+var WEBHOOKSUBSCRIPTIONTABLE = "webhook_subscriptions"
+var (
+	WEBHOOKSUBSCRIPTIONCOLUMN_ID      = WEBHOOKSUBSCRIPTIONTABLE + ".id"
+	WEBHOOKSUBSCRIPTIONCOLUMN_USER_ID = WEBHOOKSUBSCRIPTIONTABLE + ".user_id"
+)
+
+// WebhookSubscription push events of user to url
+type WebhookSubscription struct {
+	ID     uint32 `gorm:"column:id;primaryKey;autoIncrement;not null"`
+	UserId uint32 `gorm:"column:user_id;not null;index"`
+	URL    string `gorm:"column:url;type:varchar(500);not null"`
+	// comma separated, as (transaction.created,balance.updated)
+	EventTypes string    `gorm:"column:event_types;type:varchar(255);not null"`
+	Secret     string    `gorm:"column:secret;type:varchar(100);not null"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+var WEBHOOKDELIVERYTABLE = "webhook_deliveries"
+var (
+	WEBHOOKDELIVERYCOLUMN_ID              = WEBHOOKDELIVERYTABLE + ".id"
+	WEBHOOKDELIVERYCOLUMN_SUBSCRIPTION_ID = WEBHOOKDELIVERYTABLE + ".subscription_id"
+	WEBHOOKDELIVERYCOLUMN_STATUS          = WEBHOOKDELIVERYTABLE + ".status"
+	WEBHOOKDELIVERYCOLUMN_ATTEMPTS        = WEBHOOKDELIVERYTABLE + ".attempts"
+	WEBHOOKDELIVERYCOLUMN_NEXT_ATTEMPT_AT = WEBHOOKDELIVERYTABLE + ".next_attempt_at"
+)
+
+// WebhookDelivery is one event for one subscription, it is the persistent queue
+// row is created in same db transaction as event, so committed events are never lost
+type WebhookDelivery struct {
+	ID             uint32 `gorm:"column:id;primaryKey;autoIncrement;not null"`
+	SubscriptionId uint32 `gorm:"column:subscription_id;not null;index"`
+	UserId         uint32 `gorm:"column:user_id;not null"`
+	EventId        string `gorm:"column:event_id;type:char(32);not null"`
+	EventType      string `gorm:"column:event_type;type:varchar(50);not null"`
+	Payload        string `gorm:"column:payload;type:text;not null"`
+	Status         string `gorm:"column:status;type:varchar(10);not null;index:idx_webhook_deliveries_due"`
+	Attempts       int    `gorm:"column:attempts;not null;default:0"`
+	// also lease of worker which claimed it
+	NextAttemptAt  time.Time  `gorm:"column:next_attempt_at;not null;index:idx_webhook_deliveries_due"`
+	LastStatusCode int        `gorm:"column:last_status_code;not null;default:0"`
+	LastError      string     `gorm:"column:last_error;type:varchar(500)"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at"`
+	CreatedAt      time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+var WEBHOOKATTEMPTTABLE = "webhook_attempts"
+var (
+	WEBHOOKATTEMPTCOLUMN_DELIVERY_ID = WEBHOOKATTEMPTTABLE + ".delivery_id"
+	WEBHOOKATTEMPTCOLUMN_ID          = WEBHOOKATTEMPTTABLE + ".id"
+)
+
+// WebhookAttempt is delivery log, one row per http call
+type WebhookAttempt struct {
+	ID         uint32    `gorm:"column:id;primaryKey;autoIncrement;not null"`
+	DeliveryId uint32    `gorm:"column:delivery_id;not null;index"`
+	Attempt    int       `gorm:"column:attempt;not null"`
+	StatusCode int       `gorm:"column:status_code;not null;default:0"`
+	Error      string    `gorm:"column:error;type:varchar(500)"`
+	DurationMs int64     `gorm:"column:duration_ms;not null;default:0"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
+}
@@ -0,0 +1,13 @@
+package repo
+
+import (
+	"context"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+)
+
+type CategorizationRuleRepo[TxType any] interface {
+	Create(ctx context.Context, rule *models.CategorizationRule, tx TxType) error
+	Delete(ctx context.Context, rule_id uint32, tx TxType) error
+	GetById(ctx context.Context, rule_id uint32) (*models.CategorizationRule, error)
+	GetByUserId(ctx context.Context, user_id uint32) ([]*models.CategorizationRule, error)
+}
@@ -0,0 +1,17 @@
+package repo
+
+import (
+	"context"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"time"
+)
+
+type FeeScheduleRepo[TxType any] interface {
+	Create(ctx context.Context, schedule *models.FeeSchedule, tx TxType) error
+	Deactivate(ctx context.Context, schedule_id uint32, tx TxType) (bool, error)
+	GetAll(ctx context.Context, query *Query) ([]*models.FeeSchedule, error)
+	GetActiveByTransactionType(ctx context.Context, transaction_type string) ([]*models.FeeSchedule, error)
+	// CountTransactionsSince count transactions of type on account created at or after since, reversed ones included
+	// with tx the account row is locked first, so concurrent creates on account count one after another
+	CountTransactionsSince(ctx context.Context, account_id uint32, transaction_type string, since time.Time, tx TxType) (int, error)
+}
@@ -0,0 +1,19 @@
+package repo
+
+import (
+	"context"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"time"
+)
+
+type HoldRepo[TxType any] interface {
+	Create(ctx context.Context, hold *models.Hold, tx TxType) error
+	// update status, captured amount, transaction id, closed at
+	// only when current status is from_status, false when hold already moved (concurrent capture/void)
+	Transition(ctx context.Context, hold *models.Hold, from_status string, tx TxType) (bool, error)
+	GetById(ctx context.Context, hold_id uint32) (*models.Hold, error)
+	GetByUserId(ctx context.Context, user_id uint32, query *Query) ([]*models.Hold, error)
+	// authorized holds with expires_at <= now, oldest first
+	GetExpired(ctx context.Context, now time.Time, limit int) ([]*models.Hold, error)
+	BeginTx() TxType
+}
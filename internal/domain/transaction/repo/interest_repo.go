@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"context"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"time"
+)
+
+type InterestRepo[TxType any] interface {
+	CreateProduct(ctx context.Context, product *models.InterestProduct, tx TxType) error
+	GetProductById(ctx context.Context, product_id uint32) (*models.InterestProduct, error)
+	GetProducts(ctx context.Context, query *Query) ([]*models.InterestProduct, error)
+
+	// insert product of account
+	SaveAccountInterest(ctx context.Context, accountInterest *models.AccountInterest, tx TxType) error
+	// only product_id, accrual state of account is kept
+	UpdateProduct(ctx context.Context, account_id uint32, product_id uint32, tx TxType) error
+	// add micro to accrued_micro and move last_accrued_date, product_id is kept
+	AddAccrued(ctx context.Context, account_id uint32, last_accrued_date string, micro int64, tx TxType) error
+	// subtract posted micro from accrued_micro
+	SubtractPosted(ctx context.Context, account_id uint32, micro int64, tx TxType) error
+	GetAccountInterest(ctx context.Context, account_id uint32) (*models.AccountInterest, error)
+	// order by account id, paging by after_account_id
+	GetAccountInterests(ctx context.Context, after_account_id uint32, limit int) ([]*models.AccountInterest, error)
+
+	// fail on duplicated (account_id, accrual_date)
+	CreateAccrual(ctx context.Context, accrual *models.InterestAccrual, tx TxType) error
+	// not posted accruals with accrual_date < before_date, order by date
+	GetUnpostedAccruals(ctx context.Context, account_id uint32, before_date string) ([]*models.InterestAccrual, error)
+	GetAccrualsByAccountId(ctx context.Context, account_id uint32, query *Query) ([]*models.InterestAccrual, error)
+	// balance of account, row is locked until tx end so transactions can not change it meanwhile
+	GetBalanceForUpdate(ctx context.Context, account_id uint32, tx TxType) (float32, error)
+	// signed change of balance at or after since, debits are negative
+	// transactions created since and not reversed, minus transactions created before and reversed since
+	SumBalanceChangesSince(ctx context.Context, account_id uint32, since time.Time, tx TxType) (float64, error)
+
+	// fail on duplicated (account_id, month)
+	CreatePosting(ctx context.Context, posting *models.InterestPosting, tx TxType) error
+	MarkAccrualsPosted(ctx context.Context, accrual_ids []uint32, posting_id uint32, tx TxType) error
+	GetPostingsByAccountId(ctx context.Context, account_id uint32, query *Query) ([]*models.InterestPosting, error)
+	BeginTx() TxType
+}
@@ -0,0 +1,13 @@
+package repo
+
+import (
+	"context"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+)
+
+type OverdraftRepo[TxType any] interface {
+	// fail on duplicated (account_id, accrual_date)
+	CreateInterestPosting(ctx context.Context, posting *models.OverdraftInterestPosting, tx TxType) error
+	GetInterestPostingsByAccountId(ctx context.Context, account_id uint32, query *Query) ([]*models.OverdraftInterestPosting, error)
+	BeginTx() TxType
+}
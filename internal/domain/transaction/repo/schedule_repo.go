@@ -0,0 +1,27 @@
+package repo
+
+import (
+	"context"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"time"
+)
+
+type ScheduleRepo[TxType any] interface {
+	Create(ctx context.Context, schedule *models.Schedule, tx TxType) error
+	Update(ctx context.Context, schedule *models.Schedule, tx TxType) error
+	// save next_run_at, last_run_at, run_count and status of schedule only when next_run_at and status are still
+	// read_next_run_at and read_status, false when schedule was claimed, paused or resumed meanwhile
+	UpdateIfUnchanged(ctx context.Context, schedule *models.Schedule, read_next_run_at time.Time, read_status string, tx TxType) (bool, error)
+	GetById(ctx context.Context, schedule_id uint32) (*models.Schedule, error)
+	GetByUserId(ctx context.Context, user_id uint32, query *Query) ([]*models.Schedule, error)
+	// active schedules with next_run_at <= now, oldest first
+	GetDue(ctx context.Context, now time.Time, limit int) ([]*models.Schedule, error)
+
+	CreateRun(ctx context.Context, run *models.ScheduleRun, tx TxType) error
+	UpdateRun(ctx context.Context, run *models.ScheduleRun, tx TxType) error
+	GetRunByIdempotencyKey(ctx context.Context, key string) (*models.ScheduleRun, error)
+	// fail runs still started and not updated since started_before, return number of failed runs
+	FailStaleRuns(ctx context.Context, started_before time.Time, reason string) (int64, error)
+	GetRunsByScheduleId(ctx context.Context, schedule_id uint32, query *Query) ([]*models.ScheduleRun, error)
+	BeginTx() TxType
+}
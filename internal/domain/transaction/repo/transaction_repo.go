@@ -16,10 +16,15 @@ type Query struct {
 type TransactionRepo[TxTypeT any] interface {
 	Create(context.Context, *models.Transaction, TxTypeT) error
 	Update(context.Context, *models.Transaction, TxTypeT) error
+	// Delete mark not deleted transaction deleted, fail with conflict when it is already deleted
 	Delete(context.Context, *models.Transaction, TxTypeT) error
 	GetByUserId(context.Context, uint32, *Query) ([]*aggregate.TransactionByDetails, error)
 	GetByAccountId(context.Context, uint32, *Query) ([]*aggregate.TransactionByDetails, error)
+	// GetById not deleted transaction
 	GetById(context.Context, uint32) (*aggregate.TransactionByDetails, error)
+	// GetFeesByParentId not deleted fee transactions charged for parent
+	GetFeesByParentId(ctx context.Context, parent_id uint32, tx TxTypeT) ([]*aggregate.TransactionByDetails, error)
+	UpdateCategorization(ctx context.Context, transaction_id uint32, category string, tags string, tx TxTypeT) error
 	BeginTx() TxTypeT
 }
 
@@ -10,6 +10,19 @@ type UserRepo[TxType any] interface {
 	CreateUser(ctx context.Context, user_model *models.User, tx TxType) error
 	CreateAccount(ctx context.Context, account_model *models.Account, tx TxType) error
 	UpdateBalance(ctx context.Context, account_id uint32, new_balance float32, tx TxType) error
+	// subtract amount from balance only when available balance (overdraft included) is enough, false when not enough
+	DebitBalance(ctx context.Context, account_id uint32, amount float32, tx TxType) (bool, error)
+	// add delta to balance without any check, delta can be negative (system charges)
+	AddBalance(ctx context.Context, account_id uint32, delta float32, tx TxType) error
+	UpdateOverdraft(ctx context.Context, account_id uint32, limit float32, rate float32, approved bool, tx TxType) error
+	// approve only when requested limit is still reviewed_limit, revoke always, false when limit changed or account not found
+	ApproveOverdraft(ctx context.Context, account_id uint32, reviewed_limit float32, approved bool, tx TxType) (bool, error)
+	// accounts with negative balance and id greater than after_id, order by id
+	GetOverdrawnAccounts(ctx context.Context, after_id uint32, limit int) ([]*models.Account, error)
+	// add amount to held amount only when available balance is enough, false when not enough
+	ReserveHeldAmount(ctx context.Context, account_id uint32, amount float32, tx TxType) (bool, error)
+	// subtract released from held amount and captured from balance in one update
+	ReleaseHeldAmount(ctx context.Context, account_id uint32, released float32, captured float32, tx TxType) error
 	UpdateAccount(ctx context.Context, account_model *models.Account, tx TxType) error
 	DeleteAccountById(ctx context.Context, account_id uint32, tx TxType) error
 	GetUserById(ctx context.Context, user_id uint32) (*models.User, error)
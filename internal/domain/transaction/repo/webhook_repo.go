@@ -0,0 +1,29 @@
+package repo
+
+import (
+	"context"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"time"
+)
+
+type WebhookRepo[TxType any] interface {
+	CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription, tx TxType) error
+	// false when subscription is not found for user
+	DeleteSubscription(ctx context.Context, user_id uint32, subscription_id uint32, tx TxType) (bool, error)
+	GetSubscriptionById(ctx context.Context, subscription_id uint32) (*models.WebhookSubscription, error)
+	GetSubscriptionsByUserId(ctx context.Context, user_id uint32) ([]*models.WebhookSubscription, error)
+
+	CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery, tx TxType) error
+	// pending deliveries with next_attempt_at <= now, oldest first
+	GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error)
+	// move next_attempt_at to lease_until only when it is not changed since read
+	// false when other worker claimed it first
+	ClaimDelivery(ctx context.Context, delivery *models.WebhookDelivery, lease_until time.Time, tx TxType) (bool, error)
+	// save status, attempts, next attempt and last result of delivery
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery, tx TxType) error
+	CreateAttempt(ctx context.Context, attempt *models.WebhookAttempt, tx TxType) error
+	GetDeliveriesBySubscriptionId(ctx context.Context, subscription_id uint32, query *Query) ([]*models.WebhookDelivery, error)
+	GetDeliveryById(ctx context.Context, delivery_id uint32) (*models.WebhookDelivery, error)
+	GetAttemptsByDeliveryId(ctx context.Context, delivery_id uint32) ([]*models.WebhookAttempt, error)
+	BeginTx() TxType
+}
@@ -0,0 +1,70 @@
+package fee
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	feeengine "money_forward_code_challenge/internal/domain/transaction/fee"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type CreateFeeScheduleReq struct {
+	Name              string  `json:"name"`
+	TransactionType   string  `json:"transaction_type"`
+	Bank              string  `json:"bank"`
+	MinAmount         float32 `json:"min_amount"`
+	MaxAmount         float32 `json:"max_amount"`
+	FlatFee           float32 `json:"flat_fee"`
+	Percentage        float32 `json:"percentage"`
+	MinFee            float32 `json:"min_fee"`
+	MaxFee            float32 `json:"max_fee"`
+	FreeQuotaPerMonth int     `json:"free_quota_per_month"`
+}
+
+type CreateFeeScheduleUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *CreateFeeScheduleReq, tx TxType) (*models.FeeSchedule, error)
+}
+
+type defaultCreateFeeScheduleUseCase[TxType any] struct {
+	persistentRepo repo.FeeScheduleRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewCreateFeeScheduleUseCase[TxType any](persistentRepo repo.FeeScheduleRepo[TxType], logger *zap.Logger) CreateFeeScheduleUseCase[TxType] {
+	return &defaultCreateFeeScheduleUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultCreateFeeScheduleUseCase[TxType]) Execute(ctx context.Context, req *CreateFeeScheduleReq, tx TxType) (*models.FeeSchedule, error) {
+	ctx, span := tracing.Start(ctx, "fee.CreateFeeSchedule")
+	defer span.End()
+
+	schedule := &models.FeeSchedule{
+		Name:              req.Name,
+		TransactionType:   req.TransactionType,
+		Bank:              req.Bank,
+		MinAmount:         req.MinAmount,
+		MaxAmount:         req.MaxAmount,
+		FlatFee:           req.FlatFee,
+		Percentage:        req.Percentage,
+		MinFee:            req.MinFee,
+		MaxFee:            req.MaxFee,
+		FreeQuotaPerMonth: req.FreeQuotaPerMonth,
+		Active:            true,
+	}
+
+	err := feeengine.Validate(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFeeSchedule, err.Error())
+	}
+
+	err = d.persistentRepo.Create(ctx, schedule, tx)
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
@@ -0,0 +1,44 @@
+package fee
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type DeactivateFeeScheduleReq struct {
+	ScheduleId uint32
+}
+
+// DeactivateFeeScheduleUseCase stop schedule from pricing new transactions, it is kept for history
+type DeactivateFeeScheduleUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *DeactivateFeeScheduleReq, tx TxType) error
+}
+
+type defaultDeactivateFeeScheduleUseCase[TxType any] struct {
+	persistentRepo repo.FeeScheduleRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewDeactivateFeeScheduleUseCase[TxType any](persistentRepo repo.FeeScheduleRepo[TxType], logger *zap.Logger) DeactivateFeeScheduleUseCase[TxType] {
+	return &defaultDeactivateFeeScheduleUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultDeactivateFeeScheduleUseCase[TxType]) Execute(ctx context.Context, req *DeactivateFeeScheduleReq, tx TxType) error {
+	ctx, span := tracing.Start(ctx, "fee.DeactivateFeeSchedule")
+	defer span.End()
+
+	deactivated, err := d.persistentRepo.Deactivate(ctx, req.ScheduleId, tx)
+	if err != nil {
+		return err
+	}
+	if !deactivated {
+		return fmt.Errorf("%w: %d is not found or already inactive", ErrFeeScheduleNotFound, req.ScheduleId)
+	}
+	return nil
+}
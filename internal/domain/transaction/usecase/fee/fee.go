@@ -0,0 +1,10 @@
+package fee
+
+import (
+	"money_forward_code_challenge/internal/common/exception"
+)
+
+var (
+	ErrInvalidFeeSchedule  = exception.New(exception.Validation, "INVALID_FEE_SCHEDULE", "invalid fee schedule")
+	ErrFeeScheduleNotFound = exception.New(exception.NotFound, "FEE_SCHEDULE_NOT_FOUND", "fee schedule not found")
+)
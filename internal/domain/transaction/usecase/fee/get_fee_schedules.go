@@ -0,0 +1,36 @@
+package fee
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type GetFeeSchedulesReq struct {
+	Query *repo.Query
+}
+
+type GetFeeSchedules[TxType any] interface {
+	Execute(ctx context.Context, req *GetFeeSchedulesReq) ([]*models.FeeSchedule, error)
+}
+
+type defaultGetFeeSchedules[TxType any] struct {
+	persistentRepo repo.FeeScheduleRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewGetFeeSchedules[TxType any](persistentRepo repo.FeeScheduleRepo[TxType], logger *zap.Logger) GetFeeSchedules[TxType] {
+	return &defaultGetFeeSchedules[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultGetFeeSchedules[TxType]) Execute(ctx context.Context, req *GetFeeSchedulesReq) ([]*models.FeeSchedule, error) {
+	ctx, span := tracing.Start(ctx, "fee.GetFeeSchedules")
+	defer span.End()
+
+	return d.persistentRepo.GetAll(ctx, req.Query)
+}
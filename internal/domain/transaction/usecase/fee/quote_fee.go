@@ -0,0 +1,62 @@
+package fee
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	feeengine "money_forward_code_challenge/internal/domain/transaction/fee"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"time"
+)
+
+type QuoteFeeReq struct {
+	AccountId       uint32
+	Bank            string
+	TransactionType string
+	Amount          float32
+	Now             time.Time
+}
+
+// QuoteFeeUseCase price a transaction before it is created
+// same use case is used by quote api and on create, so quoted fee is the charged fee
+// on create pass tx of create, free quota is counted with account locked until it commits
+type QuoteFeeUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *QuoteFeeReq, tx TxType) (*feeengine.Quote, error)
+}
+
+type defaultQuoteFeeUseCase[TxType any] struct {
+	persistentRepo repo.FeeScheduleRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewQuoteFeeUseCase[TxType any](persistentRepo repo.FeeScheduleRepo[TxType], logger *zap.Logger) QuoteFeeUseCase[TxType] {
+	return &defaultQuoteFeeUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultQuoteFeeUseCase[TxType]) Execute(ctx context.Context, req *QuoteFeeReq, tx TxType) (*feeengine.Quote, error) {
+	ctx, span := tracing.Start(ctx, "fee.QuoteFee")
+	defer span.End()
+
+	schedules, err := d.persistentRepo.GetActiveByTransactionType(ctx, req.TransactionType)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := &feeengine.Subject{
+		TransactionType: req.TransactionType,
+		Bank:            req.Bank,
+		Amount:          req.Amount,
+	}
+	schedule := feeengine.Match(schedules, subject)
+	if schedule != nil && schedule.FreeQuotaPerMonth > 0 {
+		subject.UsedThisMonth, err = d.persistentRepo.CountTransactionsSince(ctx, req.AccountId, req.TransactionType, feeengine.MonthStart(req.Now), tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return feeengine.Calculate(schedule, subject), nil
+}
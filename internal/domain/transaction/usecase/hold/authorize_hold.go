@@ -0,0 +1,97 @@
+package hold
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"money_forward_code_challenge/pkgs/repo_pool_async"
+	"time"
+)
+
+type AuthorizeHoldReq struct {
+	// userId not bound from json
+	// because in url api
+	UserId       uint32  `json:"-"`
+	AccountId    uint32  `json:"account_id"`
+	Amount       float32 `json:"amount"`
+	Memo         string  `json:"memo"`
+	Counterparty string  `json:"counterparty"`
+	// 0 mean DefaultHoldExpiry
+	ExpiresInMinutes int       `json:"expires_in_minutes"`
+	Now              time.Time `json:"-"`
+}
+
+// AuthorizeHoldUseCase reserve amount on account
+// only available balance is reduced, ledger balance and transactions are not changed
+type AuthorizeHoldUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *AuthorizeHoldReq, tx TxType) (*models.Hold, *repo_pool_async.Job, error)
+}
+
+type defaultAuthorizeHoldUseCase[TxType any] struct {
+	persistentRepo repo.HoldRepo[TxType]
+	userRepo       repo.UserRepo[TxType]
+	userCacheRepo  repo.UserCacheRepo
+	pool           *repo_pool_async.RepoUpdatePoolBusyWaiting
+	logger         *zap.Logger
+}
+
+func NewAuthorizeHoldUseCase[TxType any](persistentRepo repo.HoldRepo[TxType], userRepo repo.UserRepo[TxType],
+	userCacheRepo repo.UserCacheRepo, logger *zap.Logger,
+	poolSizeWorker int,
+) AuthorizeHoldUseCase[TxType] {
+	return &defaultAuthorizeHoldUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		userRepo:       userRepo,
+		userCacheRepo:  userCacheRepo,
+		pool:           repo_pool_async.NewPool(context.TODO(), poolSizeWorker, logger),
+		logger:         logger,
+	}
+}
+
+func (d *defaultAuthorizeHoldUseCase[TxType]) Execute(ctx context.Context, req *AuthorizeHoldReq, tx TxType) (*models.Hold, *repo_pool_async.Job, error) {
+	ctx, span := tracing.Start(ctx, "hold.AuthorizeHold")
+	defer span.End()
+
+	if req.ExpiresInMinutes < 0 {
+		return nil, nil, fmt.Errorf("%w: expires_in_minutes must not be negative", ErrInvalidHold)
+	}
+
+	expiry := DefaultHoldExpiry
+	if req.ExpiresInMinutes > 0 {
+		expiry = time.Duration(req.ExpiresInMinutes) * time.Minute
+	}
+
+	reserved, err := d.userRepo.ReserveHeldAmount(ctx, req.AccountId, req.Amount, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !reserved {
+		return nil, nil, fmt.Errorf("%w: account %d", ErrInsufficientAvailableBalance, req.AccountId)
+	}
+
+	holdModel := &models.Hold{
+		UserId:       req.UserId,
+		AccountId:    req.AccountId,
+		Amount:       req.Amount,
+		Memo:         req.Memo,
+		Counterparty: req.Counterparty,
+		Status:       models.HOLDSTATUSAUTHORIZED,
+		ExpiresAt:    req.Now.Add(expiry),
+	}
+
+	err = d.persistentRepo.Create(ctx, holdModel, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	job := d.pool.PushPriority(ctx, fmt.Sprintf("account:%d", req.AccountId), func(ctx context.Context) error {
+		// held amount changed in db, next read refill cache
+		return d.userCacheRepo.DeleteAccountById(ctx, req.AccountId)
+	})
+
+	return holdModel, job, nil
+}
@@ -0,0 +1,80 @@
+package hold
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"money_forward_code_challenge/pkgs/repo_pool_async"
+	"time"
+)
+
+type CaptureHoldReq struct {
+	Hold *models.Hold
+	// checked by ValidateCapture
+	Amount float32
+	// withdraw transaction created in same tx
+	TransactionId uint32
+	Now           time.Time
+}
+
+// CaptureHoldUseCase close hold as captured
+// whole hold is released and captured amount is withdrawn from balance in one update
+type CaptureHoldUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *CaptureHoldReq, tx TxType) (*models.Hold, *repo_pool_async.Job, error)
+}
+
+type defaultCaptureHoldUseCase[TxType any] struct {
+	persistentRepo repo.HoldRepo[TxType]
+	userRepo       repo.UserRepo[TxType]
+	userCacheRepo  repo.UserCacheRepo
+	pool           *repo_pool_async.RepoUpdatePoolBusyWaiting
+	logger         *zap.Logger
+}
+
+func NewCaptureHoldUseCase[TxType any](persistentRepo repo.HoldRepo[TxType], userRepo repo.UserRepo[TxType],
+	userCacheRepo repo.UserCacheRepo, logger *zap.Logger,
+	poolSizeWorker int,
+) CaptureHoldUseCase[TxType] {
+	return &defaultCaptureHoldUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		userRepo:       userRepo,
+		userCacheRepo:  userCacheRepo,
+		pool:           repo_pool_async.NewPool(context.TODO(), poolSizeWorker, logger),
+		logger:         logger,
+	}
+}
+
+func (d *defaultCaptureHoldUseCase[TxType]) Execute(ctx context.Context, req *CaptureHoldReq, tx TxType) (*models.Hold, *repo_pool_async.Job, error) {
+	ctx, span := tracing.Start(ctx, "hold.CaptureHold")
+	defer span.End()
+
+	holdModel := *req.Hold
+	holdModel.Status = models.HOLDSTATUSCAPTURED
+	holdModel.CapturedAmount = req.Amount
+	holdModel.TransactionId = req.TransactionId
+	holdModel.ClosedAt = &req.Now
+
+	moved, err := d.persistentRepo.Transition(ctx, &holdModel, models.HOLDSTATUSAUTHORIZED, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !moved {
+		// captured or voided by other request
+		return nil, nil, fmt.Errorf("%w: hold %d", ErrHoldNotAuthorized, holdModel.ID)
+	}
+
+	err = d.userRepo.ReleaseHeldAmount(ctx, holdModel.AccountId, holdModel.Amount, holdModel.CapturedAmount, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	job := d.pool.PushPriority(ctx, fmt.Sprintf("account:%d", holdModel.AccountId), func(ctx context.Context) error {
+		return d.userCacheRepo.DeleteAccountById(ctx, holdModel.AccountId)
+	})
+
+	return &holdModel, job, nil
+}
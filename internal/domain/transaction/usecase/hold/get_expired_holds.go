@@ -0,0 +1,38 @@
+package hold
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"time"
+)
+
+type GetExpiredHoldsReq struct {
+	Now   time.Time
+	Limit int
+}
+
+type GetExpiredHolds[TxType any] interface {
+	Execute(ctx context.Context, req *GetExpiredHoldsReq) ([]*models.Hold, error)
+}
+
+type defaultGetExpiredHolds[TxType any] struct {
+	persistentRepo repo.HoldRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewGetExpiredHolds[TxType any](persistentRepo repo.HoldRepo[TxType], logger *zap.Logger) GetExpiredHolds[TxType] {
+	return &defaultGetExpiredHolds[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultGetExpiredHolds[TxType]) Execute(ctx context.Context, req *GetExpiredHoldsReq) ([]*models.Hold, error) {
+	ctx, span := tracing.Start(ctx, "hold.GetExpiredHolds")
+	defer span.End()
+
+	return d.persistentRepo.GetExpired(ctx, req.Now, req.Limit)
+}
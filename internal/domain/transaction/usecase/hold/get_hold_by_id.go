@@ -0,0 +1,48 @@
+package hold
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type GetHoldByIdReq struct {
+	UserId uint32
+	HoldId uint32
+}
+
+// GetHoldById return ErrHoldNotFound also when hold is of other user
+type GetHoldById[TxType any] interface {
+	Execute(ctx context.Context, req *GetHoldByIdReq) (*models.Hold, error)
+}
+
+type defaultGetHoldById[TxType any] struct {
+	persistentRepo repo.HoldRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewGetHoldById[TxType any](persistentRepo repo.HoldRepo[TxType], logger *zap.Logger) GetHoldById[TxType] {
+	return &defaultGetHoldById[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultGetHoldById[TxType]) Execute(ctx context.Context, req *GetHoldByIdReq) (*models.Hold, error) {
+	ctx, span := tracing.Start(ctx, "hold.GetHoldById")
+	defer span.End()
+
+	holdModel, err := d.persistentRepo.GetById(ctx, req.HoldId)
+	if err != nil && !errors.Is(err, exception.NotFound) {
+		return nil, err
+	}
+	if err != nil || holdModel.UserId != req.UserId {
+		return nil, fmt.Errorf("%w: hold %d of user %d", ErrHoldNotFound, req.HoldId, req.UserId)
+	}
+	return holdModel, nil
+}
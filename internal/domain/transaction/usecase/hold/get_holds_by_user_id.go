@@ -0,0 +1,37 @@
+package hold
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type GetHoldsByUserIdReq struct {
+	UserId uint32
+	Query  *repo.Query
+}
+
+type GetHoldsByUserId[TxType any] interface {
+	Execute(ctx context.Context, req *GetHoldsByUserIdReq) ([]*models.Hold, error)
+}
+
+type defaultGetHoldsByUserId[TxType any] struct {
+	persistentRepo repo.HoldRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewGetHoldsByUserId[TxType any](persistentRepo repo.HoldRepo[TxType], logger *zap.Logger) GetHoldsByUserId[TxType] {
+	return &defaultGetHoldsByUserId[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultGetHoldsByUserId[TxType]) Execute(ctx context.Context, req *GetHoldsByUserIdReq) ([]*models.Hold, error) {
+	ctx, span := tracing.Start(ctx, "hold.GetHoldsByUserId")
+	defer span.End()
+
+	return d.persistentRepo.GetByUserId(ctx, req.UserId, req.Query)
+}
@@ -0,0 +1,40 @@
+package hold
+
+import (
+	"fmt"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"time"
+)
+
+var (
+	ErrInvalidHold                  = exception.New(exception.Validation, "INVALID_HOLD", "invalid hold")
+	ErrHoldNotFound                 = exception.New(exception.NotFound, "HOLD_NOT_FOUND", "hold not found")
+	ErrHoldNotAuthorized            = exception.New(exception.Conflict, "HOLD_NOT_AUTHORIZED", "hold is not authorized")
+	ErrHoldExpired                  = exception.New(exception.Conflict, "HOLD_EXPIRED", "hold is expired")
+	ErrInsufficientAvailableBalance = exception.New(exception.InsufficientFunds, "INSUFFICIENT_FUNDS", "available balance is not enough")
+)
+
+// like card authorization, not captured hold is released after 7 days
+var DefaultHoldExpiry = 7 * 24 * time.Hour
+
+// ValidateCapture check hold can be captured with amount at now
+// amount 0 mean full capture, it return amount to capture
+func ValidateCapture(hold *models.Hold, amount float32, now time.Time) (float32, error) {
+	if hold.Status != models.HOLDSTATUSAUTHORIZED {
+		return 0, fmt.Errorf("%w: hold %d is %s", ErrHoldNotAuthorized, hold.ID, hold.Status)
+	}
+
+	if !now.Before(hold.ExpiresAt) {
+		return 0, fmt.Errorf("%w: hold %d expired at %s", ErrHoldExpired, hold.ID, hold.ExpiresAt.Format(time.RFC3339))
+	}
+
+	if amount == 0 {
+		return hold.Amount, nil
+	}
+
+	if amount < 0 || amount > hold.Amount {
+		return 0, fmt.Errorf("%w: capture amount must be in (0, %v], !got: [%v]", ErrInvalidHold, hold.Amount, amount)
+	}
+	return amount, nil
+}
@@ -0,0 +1,42 @@
+package hold
+
+import (
+	"errors"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"testing"
+	"time"
+)
+
+func TestValidateCapture(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	authorized := &models.Hold{ID: 1, Amount: 50000, Status: models.HOLDSTATUSAUTHORIZED, ExpiresAt: now.Add(time.Hour)}
+
+	amount, err := ValidateCapture(authorized, 0, now)
+	if err != nil || amount != 50000 {
+		t.Errorf("expects full capture 50000, got %v (%v)", amount, err)
+	}
+
+	amount, err = ValidateCapture(authorized, 20000, now)
+	if err != nil || amount != 20000 {
+		t.Errorf("expects partial capture 20000, got %v (%v)", amount, err)
+	}
+
+	cases := []struct {
+		name   string
+		hold   *models.Hold
+		amount float32
+		expect error
+	}{
+		{"over capture", authorized, 60000, ErrInvalidHold},
+		{"negative", authorized, -1, ErrInvalidHold},
+		{"voided", &models.Hold{ID: 2, Amount: 50000, Status: models.HOLDSTATUSVOIDED, ExpiresAt: now.Add(time.Hour)}, 0, ErrHoldNotAuthorized},
+		{"captured", &models.Hold{ID: 3, Amount: 50000, Status: models.HOLDSTATUSCAPTURED, ExpiresAt: now.Add(time.Hour)}, 0, ErrHoldNotAuthorized},
+		{"expired", &models.Hold{ID: 4, Amount: 50000, Status: models.HOLDSTATUSAUTHORIZED, ExpiresAt: now}, 0, ErrHoldExpired},
+	}
+
+	for _, c := range cases {
+		if _, err := ValidateCapture(c.hold, c.amount, now); !errors.Is(err, c.expect) {
+			t.Errorf("case [%s]: expects %v, got %v", c.name, c.expect, err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package hold
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"money_forward_code_challenge/pkgs/repo_pool_async"
+	"time"
+)
+
+type ReleaseHoldReq struct {
+	Hold *models.Hold
+	// voided by user or expired by worker
+	Status string
+	Now    time.Time
+}
+
+// ReleaseHoldUseCase close hold without any transaction, held amount is given back
+type ReleaseHoldUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *ReleaseHoldReq, tx TxType) (*models.Hold, *repo_pool_async.Job, error)
+}
+
+type defaultReleaseHoldUseCase[TxType any] struct {
+	persistentRepo repo.HoldRepo[TxType]
+	userRepo       repo.UserRepo[TxType]
+	userCacheRepo  repo.UserCacheRepo
+	pool           *repo_pool_async.RepoUpdatePoolBusyWaiting
+	logger         *zap.Logger
+}
+
+func NewReleaseHoldUseCase[TxType any](persistentRepo repo.HoldRepo[TxType], userRepo repo.UserRepo[TxType],
+	userCacheRepo repo.UserCacheRepo, logger *zap.Logger,
+	poolSizeWorker int,
+) ReleaseHoldUseCase[TxType] {
+	return &defaultReleaseHoldUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		userRepo:       userRepo,
+		userCacheRepo:  userCacheRepo,
+		pool:           repo_pool_async.NewPool(context.TODO(), poolSizeWorker, logger),
+		logger:         logger,
+	}
+}
+
+func (d *defaultReleaseHoldUseCase[TxType]) Execute(ctx context.Context, req *ReleaseHoldReq, tx TxType) (*models.Hold, *repo_pool_async.Job, error) {
+	ctx, span := tracing.Start(ctx, "hold.ReleaseHold")
+	defer span.End()
+
+	if req.Status != models.HOLDSTATUSVOIDED && req.Status != models.HOLDSTATUSEXPIRED {
+		return nil, nil, fmt.Errorf("%w: release status expects one of [%s, %s], !got: [%s]", ErrInvalidHold,
+			models.HOLDSTATUSVOIDED, models.HOLDSTATUSEXPIRED, req.Status)
+	}
+
+	if req.Hold.Status != models.HOLDSTATUSAUTHORIZED {
+		return nil, nil, fmt.Errorf("%w: hold %d is %s", ErrHoldNotAuthorized, req.Hold.ID, req.Hold.Status)
+	}
+
+	holdModel := *req.Hold
+	holdModel.Status = req.Status
+	holdModel.ClosedAt = &req.Now
+
+	moved, err := d.persistentRepo.Transition(ctx, &holdModel, models.HOLDSTATUSAUTHORIZED, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !moved {
+		return nil, nil, fmt.Errorf("%w: hold %d", ErrHoldNotAuthorized, holdModel.ID)
+	}
+
+	err = d.userRepo.ReleaseHeldAmount(ctx, holdModel.AccountId, holdModel.Amount, 0, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	job := d.pool.PushPriority(ctx, fmt.Sprintf("account:%d", holdModel.AccountId), func(ctx context.Context) error {
+		return d.userCacheRepo.DeleteAccountById(ctx, holdModel.AccountId)
+	})
+
+	return &holdModel, job, nil
+}
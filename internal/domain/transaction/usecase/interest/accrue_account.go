@@ -0,0 +1,99 @@
+package interest
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"time"
+)
+
+type AccrueAccountReq struct {
+	AccountInterest *models.AccountInterest
+	Product         *models.InterestProduct
+	Now             time.Time
+}
+
+// AccrueAccountUseCase accrue every day after last accrued date until yesterday
+// one row per day, (account_id, accrual_date) unique so concurrent job fail instead of accrue twice
+// balance is read with account locked in tx, end of each caught up day is rebuilt from changes after that day
+type AccrueAccountUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *AccrueAccountReq, tx TxType) (int, error)
+}
+
+type defaultAccrueAccountUseCase[TxType any] struct {
+	persistentRepo repo.InterestRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewAccrueAccountUseCase[TxType any](persistentRepo repo.InterestRepo[TxType], logger *zap.Logger) AccrueAccountUseCase[TxType] {
+	return &defaultAccrueAccountUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultAccrueAccountUseCase[TxType]) Execute(ctx context.Context, req *AccrueAccountReq, tx TxType) (int, error) {
+	ctx, span := tracing.Start(ctx, "interest.AccrueAccount")
+	defer span.End()
+
+	accountInterest := req.AccountInterest
+	dates, err := DatesToAccrue(accountInterest.LastAccruedDate, req.Now)
+	if err != nil || len(dates) == 0 {
+		return 0, err
+	}
+
+	balance, err := d.persistentRepo.GetBalanceForUpdate(ctx, accountInterest.AccountId, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	accruedMicro := int64(0)
+	for _, date := range dates {
+		fraction, err := DayFraction(req.Product.DayCount, date)
+		if err != nil {
+			return 0, err
+		}
+
+		// end of day balance is balance now without changes made after that day
+		changedAfter, err := d.persistentRepo.SumBalanceChangesSince(ctx, accountInterest.AccountId, date.AddDate(0, 0, 1), tx)
+		if err != nil {
+			return 0, err
+		}
+
+		base := float64(balance) - changedAfter
+		if req.Product.Compounding == models.INTERESTCOMPOUNDINGDAILY {
+			base += float64(accountInterest.AccruedMicro+accruedMicro) / 1e6
+		}
+		if base < 0 {
+			// negative balance is charged by overdraft, it earn nothing
+			base = 0
+		}
+
+		accrual := &models.InterestAccrual{
+			AccountId:   accountInterest.AccountId,
+			AccrualDate: LocalDate(date),
+			Base:        base,
+			Rate:        req.Product.Rate,
+			AmountMicro: AccrueMicro(base, req.Product.Rate, fraction),
+		}
+
+		err = d.persistentRepo.CreateAccrual(ctx, accrual, tx)
+		if err != nil {
+			return 0, err
+		}
+
+		accruedMicro += accrual.AmountMicro
+	}
+
+	// only accrual columns, product assigned meanwhile is kept
+	lastAccruedDate := LocalDate(dates[len(dates)-1])
+	err = d.persistentRepo.AddAccrued(ctx, accountInterest.AccountId, lastAccruedDate, accruedMicro, tx)
+	if err != nil {
+		return 0, err
+	}
+	accountInterest.AccruedMicro += accruedMicro
+	accountInterest.LastAccruedDate = lastAccruedDate
+	return len(dates), nil
+}
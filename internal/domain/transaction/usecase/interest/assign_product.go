@@ -0,0 +1,72 @@
+package interest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"time"
+)
+
+type AssignProductReq struct {
+	AccountId uint32 `json:"account_id"`
+	ProductId uint32 `json:"product_id"`
+	Now       time.Time
+}
+
+// AssignProductUseCase attach product to account, accrual start from today
+// changing product keep accrued interest, new rate apply from next accrued day
+type AssignProductUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *AssignProductReq, tx TxType) (*models.AccountInterest, error)
+}
+
+type defaultAssignProductUseCase[TxType any] struct {
+	persistentRepo repo.InterestRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewAssignProductUseCase[TxType any](persistentRepo repo.InterestRepo[TxType], logger *zap.Logger) AssignProductUseCase[TxType] {
+	return &defaultAssignProductUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultAssignProductUseCase[TxType]) Execute(ctx context.Context, req *AssignProductReq, tx TxType) (*models.AccountInterest, error) {
+	ctx, span := tracing.Start(ctx, "interest.AssignProduct")
+	defer span.End()
+
+	_, err := d.persistentRepo.GetProductById(ctx, req.ProductId)
+	if err != nil && !errors.Is(err, exception.NotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %d", ErrProductNotFound, req.ProductId)
+	}
+
+	accountInterest, err := d.persistentRepo.GetAccountInterest(ctx, req.AccountId)
+	if err == nil {
+		// accrual state which job save meanwhile is kept
+		accountInterest.ProductId = req.ProductId
+		err = d.persistentRepo.UpdateProduct(ctx, req.AccountId, req.ProductId, tx)
+		if err != nil {
+			return nil, err
+		}
+		return accountInterest, nil
+	}
+
+	accountInterest = &models.AccountInterest{
+		AccountId:       req.AccountId,
+		ProductId:       req.ProductId,
+		LastAccruedDate: LocalDate(req.Now.AddDate(0, 0, -1)),
+	}
+	err = d.persistentRepo.SaveAccountInterest(ctx, accountInterest, tx)
+	if err != nil {
+		return nil, err
+	}
+	return accountInterest, nil
+}
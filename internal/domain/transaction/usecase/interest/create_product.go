@@ -0,0 +1,68 @@
+package interest
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"slices"
+)
+
+type CreateProductReq struct {
+	Name        string  `json:"name"`
+	Rate        float32 `json:"rate"`
+	DayCount    string  `json:"day_count"`
+	Compounding string  `json:"compounding"`
+}
+
+type CreateProductUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *CreateProductReq, tx TxType) (*models.InterestProduct, error)
+}
+
+type defaultCreateProductUseCase[TxType any] struct {
+	persistentRepo repo.InterestRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewCreateProductUseCase[TxType any](persistentRepo repo.InterestRepo[TxType], logger *zap.Logger) CreateProductUseCase[TxType] {
+	return &defaultCreateProductUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultCreateProductUseCase[TxType]) Execute(ctx context.Context, req *CreateProductReq, tx TxType) (*models.InterestProduct, error) {
+	ctx, span := tracing.Start(ctx, "interest.CreateProduct")
+	defer span.End()
+
+	if req.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidProduct)
+	}
+
+	if req.Rate < 0 || req.Rate > 1 {
+		return nil, fmt.Errorf("%w: rate must be in [0, 1], !got: [%v]", ErrInvalidProduct, req.Rate)
+	}
+
+	if !slices.Contains(models.INTERESTDAYCOUNTEXPECTS, req.DayCount) {
+		return nil, fmt.Errorf("%w: day_count expects one of %v, !got: [%s]", ErrInvalidProduct, models.INTERESTDAYCOUNTEXPECTS, req.DayCount)
+	}
+
+	if !slices.Contains(models.INTERESTCOMPOUNDINGEXPECTS, req.Compounding) {
+		return nil, fmt.Errorf("%w: compounding expects one of %v, !got: [%s]", ErrInvalidProduct, models.INTERESTCOMPOUNDINGEXPECTS, req.Compounding)
+	}
+
+	product := &models.InterestProduct{
+		Name:        req.Name,
+		Rate:        req.Rate,
+		DayCount:    req.DayCount,
+		Compounding: req.Compounding,
+	}
+
+	err := d.persistentRepo.CreateProduct(ctx, product, tx)
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
@@ -0,0 +1,38 @@
+package interest
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type GetAccountInterestsReq struct {
+	// paging by account id, 0 for first page
+	AfterAccountId uint32
+	Limit          int
+}
+
+type GetAccountInterests[TxType any] interface {
+	Execute(ctx context.Context, req *GetAccountInterestsReq) ([]*models.AccountInterest, error)
+}
+
+type defaultGetAccountInterests[TxType any] struct {
+	persistentRepo repo.InterestRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewGetAccountInterests[TxType any](persistentRepo repo.InterestRepo[TxType], logger *zap.Logger) GetAccountInterests[TxType] {
+	return &defaultGetAccountInterests[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultGetAccountInterests[TxType]) Execute(ctx context.Context, req *GetAccountInterestsReq) ([]*models.AccountInterest, error) {
+	ctx, span := tracing.Start(ctx, "interest.GetAccountInterests")
+	defer span.End()
+
+	return d.persistentRepo.GetAccountInterests(ctx, req.AfterAccountId, req.Limit)
+}
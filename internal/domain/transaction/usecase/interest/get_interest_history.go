@@ -0,0 +1,75 @@
+package interest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type GetInterestHistoryReq struct {
+	AccountId uint32
+	Query     *repo.Query
+}
+
+type InterestHistory struct {
+	AccountInterest *models.AccountInterest   `json:"account_interest"`
+	Product         *models.InterestProduct   `json:"product"`
+	Accruals        []*models.InterestAccrual `json:"accruals"`
+	Postings        []*models.InterestPosting `json:"postings"`
+}
+
+type GetInterestHistory[TxType any] interface {
+	Execute(ctx context.Context, req *GetInterestHistoryReq) (*InterestHistory, error)
+}
+
+type defaultGetInterestHistory[TxType any] struct {
+	persistentRepo repo.InterestRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewGetInterestHistory[TxType any](persistentRepo repo.InterestRepo[TxType], logger *zap.Logger) GetInterestHistory[TxType] {
+	return &defaultGetInterestHistory[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultGetInterestHistory[TxType]) Execute(ctx context.Context, req *GetInterestHistoryReq) (*InterestHistory, error) {
+	ctx, span := tracing.Start(ctx, "interest.GetInterestHistory")
+	defer span.End()
+
+	accountInterest, err := d.persistentRepo.GetAccountInterest(ctx, req.AccountId)
+	if err != nil && !errors.Is(err, exception.NotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: account %d", ErrAccountNotEarned, req.AccountId)
+	}
+
+	product, err := d.persistentRepo.GetProductById(ctx, accountInterest.ProductId)
+	if err != nil {
+		return nil, err
+	}
+
+	accruals, err := d.persistentRepo.GetAccrualsByAccountId(ctx, req.AccountId, req.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	postings, err := d.persistentRepo.GetPostingsByAccountId(ctx, req.AccountId, req.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InterestHistory{
+		AccountInterest: accountInterest,
+		Product:         product,
+		Accruals:        accruals,
+		Postings:        postings,
+	}, nil
+}
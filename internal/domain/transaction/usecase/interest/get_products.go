@@ -0,0 +1,36 @@
+package interest
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type GetProductsReq struct {
+	Query *repo.Query
+}
+
+type GetProducts[TxType any] interface {
+	Execute(ctx context.Context, req *GetProductsReq) ([]*models.InterestProduct, error)
+}
+
+type defaultGetProducts[TxType any] struct {
+	persistentRepo repo.InterestRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewGetProducts[TxType any](persistentRepo repo.InterestRepo[TxType], logger *zap.Logger) GetProducts[TxType] {
+	return &defaultGetProducts[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultGetProducts[TxType]) Execute(ctx context.Context, req *GetProductsReq) ([]*models.InterestProduct, error) {
+	ctx, span := tracing.Start(ctx, "interest.GetProducts")
+	defer span.End()
+
+	return d.persistentRepo.GetProducts(ctx, req.Query)
+}
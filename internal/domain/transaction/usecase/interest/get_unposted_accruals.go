@@ -0,0 +1,53 @@
+package interest
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type GetUnpostedAccrualsReq struct {
+	AccountId uint32
+	// first day of current month, only closed months are posted
+	BeforeDate string
+}
+
+// GetUnpostedAccruals return not posted accruals grouped by month (2006-01), months in order
+type GetUnpostedAccruals[TxType any] interface {
+	Execute(ctx context.Context, req *GetUnpostedAccrualsReq) ([]string, map[string][]*models.InterestAccrual, error)
+}
+
+type defaultGetUnpostedAccruals[TxType any] struct {
+	persistentRepo repo.InterestRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewGetUnpostedAccruals[TxType any](persistentRepo repo.InterestRepo[TxType], logger *zap.Logger) GetUnpostedAccruals[TxType] {
+	return &defaultGetUnpostedAccruals[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultGetUnpostedAccruals[TxType]) Execute(ctx context.Context, req *GetUnpostedAccrualsReq) ([]string, map[string][]*models.InterestAccrual, error) {
+	ctx, span := tracing.Start(ctx, "interest.GetUnpostedAccruals")
+	defer span.End()
+
+	accruals, err := d.persistentRepo.GetUnpostedAccruals(ctx, req.AccountId, req.BeforeDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var months []string
+	byMonth := make(map[string][]*models.InterestAccrual)
+	for _, accrual := range accruals {
+		month := MonthOf(accrual.AccrualDate)
+		if _, ok := byMonth[month]; !ok {
+			months = append(months, month)
+		}
+		byMonth[month] = append(byMonth[month], accrual)
+	}
+	return months, byMonth, nil
+}
@@ -0,0 +1,93 @@
+package interest
+
+import (
+	"fmt"
+	"math"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"time"
+)
+
+var (
+	ErrInvalidProduct   = exception.New(exception.Validation, "INVALID_INTEREST_PRODUCT", "invalid interest product")
+	ErrProductNotFound  = exception.New(exception.NotFound, "INTEREST_PRODUCT_NOT_FOUND", "interest product not found")
+	ErrAccountNotEarned = exception.New(exception.NotFound, "ACCOUNT_INTEREST_NOT_FOUND", "account has no interest product")
+)
+
+// max days accrued for one account in one call, rest is caught up on next calls
+var MaxCatchUpDays = 31
+
+var localZone = time.FixedZone("UTC+7", 7*60*60)
+
+// LocalDate is local date (+07:00) of t as 2006-01-02
+func LocalDate(t time.Time) string {
+	return t.In(localZone).Format(time.DateOnly)
+}
+
+// MonthOf return 2006-01 of local date
+func MonthOf(date string) string {
+	return date[:7]
+}
+
+// FirstOfMonth return local date of first day of month of t
+func FirstOfMonth(t time.Time) string {
+	return t.In(localZone).Format("2006-01") + "-01"
+}
+
+// DayFraction is part of year one day accrue
+// ACT/365 every day is 1/365
+// 30/360 every month is 30 days: day 31 accrue nothing and last day of february accrue the missing days
+func DayFraction(dayCount string, date time.Time) (float64, error) {
+	switch dayCount {
+	case models.INTERESTDAYCOUNTACT365:
+		return 1.0 / 365, nil
+	case models.INTERESTDAYCOUNTTHIRTY360:
+		if date.Day() == 31 {
+			return 0, nil
+		}
+		lastDay := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		if date.Month() == time.February && date.Day() == lastDay {
+			return float64(30-lastDay+1) / 360, nil
+		}
+		return 1.0 / 360, nil
+	}
+	return 0, fmt.Errorf("%w: day count expects one of %v, !got: [%s]", ErrInvalidProduct, models.INTERESTDAYCOUNTEXPECTS, dayCount)
+}
+
+// AccrueMicro is interest of one day in micro unit, rounded half to even
+// it is 0 when base is not positive
+func AccrueMicro(base float64, rate float32, fraction float64) int64 {
+	if base <= 0 || rate <= 0 {
+		return 0
+	}
+	// rate is stored as float32, 0.05 become 0.0500000007
+	// rates never have more than 6 decimals, so snap it back before multiply
+	exactRate := math.Round(float64(rate)*1e6) / 1e6
+	return int64(math.RoundToEven(base * exactRate * fraction * 1e6))
+}
+
+// MicroToAmount round micro unit to 2 decimals, half to even with integer math
+// so same accruals always post same amount
+func MicroToAmount(micro int64) float32 {
+	cents, rest := micro/10000, micro%10000
+	if rest > 5000 || (rest == 5000 && cents%2 == 1) {
+		cents++
+	}
+	return float32(cents) / 100
+}
+
+// DatesToAccrue return local dates after lastAccruedDate until yesterday of now
+// at most MaxCatchUpDays
+func DatesToAccrue(lastAccruedDate string, now time.Time) ([]time.Time, error) {
+	last, err := time.ParseInLocation(time.DateOnly, lastAccruedDate, localZone)
+	if err != nil {
+		return nil, err
+	}
+
+	today, _ := time.ParseInLocation(time.DateOnly, LocalDate(now), localZone)
+	var dates []time.Time
+	for date := last.AddDate(0, 0, 1); date.Before(today) && len(dates) < MaxCatchUpDays; date = date.AddDate(0, 0, 1) {
+		dates = append(dates, date)
+	}
+	return dates, nil
+}
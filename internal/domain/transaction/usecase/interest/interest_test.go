@@ -0,0 +1,94 @@
+package interest
+
+import (
+	"errors"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"testing"
+	"time"
+)
+
+func TestDayFraction(t *testing.T) {
+	sumOfMonth := func(dayCount string, year int, month time.Month) float64 {
+		sum := 0.0
+		for date := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC); date.Month() == month; date = date.AddDate(0, 0, 1) {
+			fraction, err := DayFraction(dayCount, date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sum += fraction
+		}
+		return sum
+	}
+
+	// every month is 30 days in 30/360
+	for _, month := range []time.Month{time.January, time.February, time.April} {
+		for _, year := range []int{2023, 2024} {
+			if got := sumOfMonth(models.INTERESTDAYCOUNTTHIRTY360, year, month); got < 30.0/360-1e-12 || got > 30.0/360+1e-12 {
+				t.Errorf("30/360 %d-%d expects 30/360, got %v", year, month, got)
+			}
+		}
+	}
+
+	if got := sumOfMonth(models.INTERESTDAYCOUNTACT365, 2024, time.January); got < 31.0/365-1e-12 || got > 31.0/365+1e-12 {
+		t.Errorf("ACT/365 january expects 31/365, got %v", got)
+	}
+
+	if _, err := DayFraction("ACT/360", time.Now()); !errors.Is(err, ErrInvalidProduct) {
+		t.Errorf("expects ErrInvalidProduct, got %v", err)
+	}
+}
+
+func TestAccrueAndRound(t *testing.T) {
+	// 36500000 at 5% ACT/365 is 5000 a day
+	if got := AccrueMicro(36500000, 0.05, 1.0/365); got != 5000*1e6 {
+		t.Errorf("expects 5000000000 micro, got %d", got)
+	}
+
+	if got := AccrueMicro(-100, 0.05, 1.0/365); got != 0 {
+		t.Errorf("expects no interest on negative base, got %d", got)
+	}
+
+	cases := []struct {
+		micro  int64
+		expect float32
+	}{
+		{1234567, 1.23},
+		{1235000, 1.24}, // half to even, 123.5 cents -> 124
+		{1225000, 1.22}, // half to even, 122.5 cents -> 122
+		{1225001, 1.23},
+		{0, 0},
+	}
+	for _, c := range cases {
+		if got := MicroToAmount(c.micro); got != c.expect {
+			t.Errorf("micro %d expects %v, got %v", c.micro, c.expect, got)
+		}
+	}
+}
+
+func TestDatesToAccrue(t *testing.T) {
+	now := time.Date(2024, 3, 2, 1, 0, 0, 0, time.UTC) // 08:00 +07:00
+	dates, err := DatesToAccrue("2024-02-27", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expects := []string{"2024-02-28", "2024-02-29", "2024-03-01"}
+	if len(dates) != len(expects) {
+		t.Fatalf("expects %v, got %v", expects, dates)
+	}
+	for i, date := range dates {
+		if LocalDate(date) != expects[i] {
+			t.Errorf("expects %s, got %s", expects[i], LocalDate(date))
+		}
+	}
+
+	// catch up is bounded per call
+	dates, _ = DatesToAccrue("2023-01-01", now)
+	if len(dates) != MaxCatchUpDays {
+		t.Errorf("expects %d dates, got %d", MaxCatchUpDays, len(dates))
+	}
+
+	if FirstOfMonth(now) != "2024-03-01" || MonthOf("2024-02-29") != "2024-02" {
+		t.Errorf("wrong month helpers")
+	}
+}
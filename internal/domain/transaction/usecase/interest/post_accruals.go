@@ -0,0 +1,81 @@
+package interest
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type PostAccrualsReq struct {
+	AccountInterest *models.AccountInterest
+	Month           string
+	Accruals        []*models.InterestAccrual
+	// interest transaction created in same tx, 0 when amount is rounded to 0
+	TransactionId uint32
+}
+
+// PostAccrualsUseCase record posting of one month and link its accruals
+// it fail when month is already posted, so caller must rollback transaction created in same tx
+type PostAccrualsUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *PostAccrualsReq, tx TxType) (*models.InterestPosting, error)
+}
+
+type defaultPostAccrualsUseCase[TxType any] struct {
+	persistentRepo repo.InterestRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewPostAccrualsUseCase[TxType any](persistentRepo repo.InterestRepo[TxType], logger *zap.Logger) PostAccrualsUseCase[TxType] {
+	return &defaultPostAccrualsUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+// SumMicro is total of accruals in micro unit
+func SumMicro(accruals []*models.InterestAccrual) int64 {
+	var sum int64
+	for _, accrual := range accruals {
+		sum += accrual.AmountMicro
+	}
+	return sum
+}
+
+func (d *defaultPostAccrualsUseCase[TxType]) Execute(ctx context.Context, req *PostAccrualsReq, tx TxType) (*models.InterestPosting, error) {
+	ctx, span := tracing.Start(ctx, "interest.PostAccruals")
+	defer span.End()
+
+	sum := SumMicro(req.Accruals)
+	posting := &models.InterestPosting{
+		AccountId:     req.AccountInterest.AccountId,
+		Month:         req.Month,
+		AmountMicro:   sum,
+		Amount:        MicroToAmount(sum),
+		TransactionId: req.TransactionId,
+	}
+
+	err := d.persistentRepo.CreatePosting(ctx, posting, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	accrualIds := make([]uint32, 0, len(req.Accruals))
+	for _, accrual := range req.Accruals {
+		accrualIds = append(accrualIds, accrual.ID)
+	}
+
+	err = d.persistentRepo.MarkAccrualsPosted(ctx, accrualIds, posting.ID, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// posted interest is now in balance, it is compounded by balance from here
+	err = d.persistentRepo.SubtractPosted(ctx, req.AccountInterest.AccountId, sum, tx)
+	if err != nil {
+		return nil, err
+	}
+	req.AccountInterest.AccruedMicro -= sum
+	return posting, nil
+}
@@ -0,0 +1,68 @@
+package overdraft
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"money_forward_code_challenge/pkgs/repo_pool_async"
+)
+
+var ErrOverdraftChanged = exception.New(exception.Conflict, "OVERDRAFT_CHANGED", "requested overdraft limit is not the reviewed one")
+
+type ApproveOverdraftReq struct {
+	AccountId uint32 `json:"account_id"`
+	// limit admin reviewed, approval fail when owner requested another limit since
+	Limit    float32 `json:"limit"`
+	Approved bool    `json:"approved"`
+}
+
+// ApproveOverdraftUseCase make requested limit usable, or revoke it
+// limit and rate are never written, only approval of limit which is still requested
+type ApproveOverdraftUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *ApproveOverdraftReq, tx TxType) (*repo_pool_async.Job, error)
+}
+
+type defaultApproveOverdraftUseCase[TxType any] struct {
+	userRepo      repo.UserRepo[TxType]
+	userCacheRepo repo.UserCacheRepo
+	pool          *repo_pool_async.RepoUpdatePoolBusyWaiting
+	logger        *zap.Logger
+}
+
+func NewApproveOverdraftUseCase[TxType any](userRepo repo.UserRepo[TxType], userCacheRepo repo.UserCacheRepo, logger *zap.Logger,
+	poolSizeWorker int,
+) ApproveOverdraftUseCase[TxType] {
+	return &defaultApproveOverdraftUseCase[TxType]{
+		userRepo:      userRepo,
+		userCacheRepo: userCacheRepo,
+		pool:          repo_pool_async.NewPool(context.TODO(), poolSizeWorker, logger),
+		logger:        logger,
+	}
+}
+
+func (d *defaultApproveOverdraftUseCase[TxType]) Execute(ctx context.Context, req *ApproveOverdraftReq, tx TxType) (*repo_pool_async.Job, error) {
+	ctx, span := tracing.Start(ctx, "overdraft.ApproveOverdraft")
+	defer span.End()
+
+	if req.Approved && (req.Limit <= 0 || req.Limit > MaxOverdraftLimit) {
+		return nil, fmt.Errorf("%w: limit must be in (0, %v], !got: [%v]", ErrInvalidOverdraft, MaxOverdraftLimit, req.Limit)
+	}
+
+	updated, err := d.userRepo.ApproveOverdraft(ctx, req.AccountId, req.Limit, req.Approved, tx)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, fmt.Errorf("%w: account %d, reviewed limit %v", ErrOverdraftChanged, req.AccountId, req.Limit)
+	}
+
+	job := d.pool.PushPriority(ctx, fmt.Sprintf("account:%d", req.AccountId), func(ctx context.Context) error {
+		// available balance changed, next read refill cache
+		return d.userCacheRepo.DeleteAccountById(ctx, req.AccountId)
+	})
+
+	return job, nil
+}
@@ -0,0 +1,55 @@
+package overdraft
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type CreateInterestPostingReq struct {
+	Account     *models.Account
+	AccrualDate string
+	Amount      float32
+	// overdraft_interest transaction created in same tx
+	TransactionId uint32
+}
+
+// CreateInterestPostingUseCase record daily charge, it fail when day is already charged
+// so caller must rollback transaction created in same tx
+type CreateInterestPostingUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *CreateInterestPostingReq, tx TxType) (*models.OverdraftInterestPosting, error)
+}
+
+type defaultCreateInterestPostingUseCase[TxType any] struct {
+	persistentRepo repo.OverdraftRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewCreateInterestPostingUseCase[TxType any](persistentRepo repo.OverdraftRepo[TxType], logger *zap.Logger) CreateInterestPostingUseCase[TxType] {
+	return &defaultCreateInterestPostingUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultCreateInterestPostingUseCase[TxType]) Execute(ctx context.Context, req *CreateInterestPostingReq, tx TxType) (*models.OverdraftInterestPosting, error) {
+	ctx, span := tracing.Start(ctx, "overdraft.CreateInterestPosting")
+	defer span.End()
+
+	posting := &models.OverdraftInterestPosting{
+		AccountId:     req.Account.ID,
+		AccrualDate:   req.AccrualDate,
+		Balance:       req.Account.Balance,
+		Rate:          req.Account.OverdraftRate,
+		Amount:        req.Amount,
+		TransactionId: req.TransactionId,
+	}
+
+	err := d.persistentRepo.CreateInterestPosting(ctx, posting, tx)
+	if err != nil {
+		return nil, err
+	}
+	return posting, nil
+}
@@ -0,0 +1,37 @@
+package overdraft
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type GetInterestPostingsReq struct {
+	AccountId uint32
+	Query     *repo.Query
+}
+
+type GetInterestPostings[TxType any] interface {
+	Execute(ctx context.Context, req *GetInterestPostingsReq) ([]*models.OverdraftInterestPosting, error)
+}
+
+type defaultGetInterestPostings[TxType any] struct {
+	persistentRepo repo.OverdraftRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewGetInterestPostings[TxType any](persistentRepo repo.OverdraftRepo[TxType], logger *zap.Logger) GetInterestPostings[TxType] {
+	return &defaultGetInterestPostings[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultGetInterestPostings[TxType]) Execute(ctx context.Context, req *GetInterestPostingsReq) ([]*models.OverdraftInterestPosting, error) {
+	ctx, span := tracing.Start(ctx, "overdraft.GetInterestPostings")
+	defer span.End()
+
+	return d.persistentRepo.GetInterestPostingsByAccountId(ctx, req.AccountId, req.Query)
+}
@@ -0,0 +1,38 @@
+package overdraft
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type GetOverdrawnAccountsReq struct {
+	// paging by id, 0 for first page
+	AfterId uint32
+	Limit   int
+}
+
+type GetOverdrawnAccounts[TxType any] interface {
+	Execute(ctx context.Context, req *GetOverdrawnAccountsReq) ([]*models.Account, error)
+}
+
+type defaultGetOverdrawnAccounts[TxType any] struct {
+	userRepo repo.UserRepo[TxType]
+	logger   *zap.Logger
+}
+
+func NewGetOverdrawnAccounts[TxType any](userRepo repo.UserRepo[TxType], logger *zap.Logger) GetOverdrawnAccounts[TxType] {
+	return &defaultGetOverdrawnAccounts[TxType]{
+		userRepo: userRepo,
+		logger:   logger,
+	}
+}
+
+func (d *defaultGetOverdrawnAccounts[TxType]) Execute(ctx context.Context, req *GetOverdrawnAccountsReq) ([]*models.Account, error) {
+	ctx, span := tracing.Start(ctx, "overdraft.GetOverdrawnAccounts")
+	defer span.End()
+
+	return d.userRepo.GetOverdrawnAccounts(ctx, req.AfterId, req.Limit)
+}
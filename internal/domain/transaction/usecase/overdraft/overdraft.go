@@ -0,0 +1,34 @@
+package overdraft
+
+import (
+	"math"
+	"money_forward_code_challenge/internal/common/exception"
+	"time"
+)
+
+var (
+	ErrInvalidOverdraft = exception.New(exception.Validation, "INVALID_OVERDRAFT", "invalid overdraft")
+)
+
+// max annual rate, 1 mean 100%
+var MaxOverdraftRate float32 = 1
+
+// max limit an account can request, bank still approve it
+var MaxOverdraftLimit float32 = 10000000
+
+// DailyInterest is interest of one day on negative balance (ACT/365), rounded half away from zero to 2 decimals
+// it is 0 when balance is not negative
+func DailyInterest(balance float32, annualRate float32) float32 {
+	if balance >= 0 || annualRate <= 0 {
+		return 0
+	}
+
+	interest := -float64(balance) * float64(annualRate) / 365
+	return float32(math.Round(interest*100) / 100)
+}
+
+// AccrualDate is local date (+07:00) interest of now is charged for
+func AccrualDate(now time.Time) string {
+	loc := time.FixedZone("UTC+7", 7*60*60)
+	return now.In(loc).Format(time.DateOnly)
+}
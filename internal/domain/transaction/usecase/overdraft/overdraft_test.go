@@ -0,0 +1,35 @@
+package overdraft
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDailyInterest(t *testing.T) {
+	cases := []struct {
+		name    string
+		balance float32
+		rate    float32
+		expect  float32
+	}{
+		{"positive balance", 100000, 0.18, 0},
+		{"zero rate", -100000, 0, 0},
+		{"one year of 18% on 365000", -365000, 0.18, 180},
+		{"one unit", -1000, 0.365, 1},
+		{"two decimals", -50000, 0.2, 27.4},
+	}
+
+	for _, c := range cases {
+		if got := DailyInterest(c.balance, c.rate); got != c.expect {
+			t.Errorf("case [%s]: expects %v, got %v", c.name, c.expect, got)
+		}
+	}
+}
+
+func TestAccrualDate(t *testing.T) {
+	// 18:00 UTC is next day in +07:00
+	now := time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC)
+	if got := AccrualDate(now); got != "2024-06-02" {
+		t.Errorf("expects 2024-06-02, got %s", got)
+	}
+}
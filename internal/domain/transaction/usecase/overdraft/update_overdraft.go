@@ -0,0 +1,65 @@
+package overdraft
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"money_forward_code_challenge/pkgs/repo_pool_async"
+)
+
+type UpdateOverdraftReq struct {
+	AccountId uint32  `json:"account_id"`
+	Limit     float32 `json:"limit"`
+	Rate      float32 `json:"rate"`
+	// limit is only usable after approved
+	Approved bool `json:"approved"`
+}
+
+type UpdateOverdraftUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *UpdateOverdraftReq, tx TxType) (*repo_pool_async.Job, error)
+}
+
+type defaultUpdateOverdraftUseCase[TxType any] struct {
+	userRepo      repo.UserRepo[TxType]
+	userCacheRepo repo.UserCacheRepo
+	pool          *repo_pool_async.RepoUpdatePoolBusyWaiting
+	logger        *zap.Logger
+}
+
+func NewUpdateOverdraftUseCase[TxType any](userRepo repo.UserRepo[TxType], userCacheRepo repo.UserCacheRepo, logger *zap.Logger,
+	poolSizeWorker int,
+) UpdateOverdraftUseCase[TxType] {
+	return &defaultUpdateOverdraftUseCase[TxType]{
+		userRepo:      userRepo,
+		userCacheRepo: userCacheRepo,
+		pool:          repo_pool_async.NewPool(context.TODO(), poolSizeWorker, logger),
+		logger:        logger,
+	}
+}
+
+func (d *defaultUpdateOverdraftUseCase[TxType]) Execute(ctx context.Context, req *UpdateOverdraftReq, tx TxType) (*repo_pool_async.Job, error) {
+	ctx, span := tracing.Start(ctx, "overdraft.UpdateOverdraft")
+	defer span.End()
+
+	if req.Limit < 0 || req.Limit > MaxOverdraftLimit {
+		return nil, fmt.Errorf("%w: limit must be in [0, %v], !got: [%v]", ErrInvalidOverdraft, MaxOverdraftLimit, req.Limit)
+	}
+
+	if req.Rate < 0 || req.Rate > MaxOverdraftRate {
+		return nil, fmt.Errorf("%w: rate must be in [0, %v], !got: [%v]", ErrInvalidOverdraft, MaxOverdraftRate, req.Rate)
+	}
+
+	err := d.userRepo.UpdateOverdraft(ctx, req.AccountId, req.Limit, req.Rate, req.Approved, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	job := d.pool.PushPriority(ctx, fmt.Sprintf("account:%d", req.AccountId), func(ctx context.Context) error {
+		// available balance changed, next read refill cache
+		return d.userCacheRepo.DeleteAccountById(ctx, req.AccountId)
+	})
+
+	return job, nil
+}
@@ -0,0 +1,120 @@
+package rule
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/categorization"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type ApplyRulesReq struct {
+	UserId uint32 `json:"user_id"`
+	// dry run only return explanation, nothing be updated
+	DryRun bool `json:"dry_run"`
+}
+
+type ApplyRulesResult struct {
+	TransactionId uint32                      `json:"transaction_id"`
+	OldCategory   string                      `json:"old_category"`
+	NewCategory   string                      `json:"new_category"`
+	OldTags       string                      `json:"old_tags"`
+	NewTags       string                      `json:"new_tags"`
+	Changed       bool                        `json:"changed"`
+	Explanation   *categorization.Explanation `json:"explanation"`
+}
+
+// ApplyRulesToHistory re-run rules over all transactions of user
+// it be batched by page, all updates in one tx controlled from outside
+// cache of changed transactions should be deleted by caller after commit
+type ApplyRulesToHistory[TxType any] interface {
+	Execute(ctx context.Context, req *ApplyRulesReq, tx TxType) ([]*ApplyRulesResult, error)
+}
+
+type defaultApplyRulesToHistory[TxType any] struct {
+	rulePersistentRepo        repo.CategorizationRuleRepo[TxType]
+	transactionPersistentRepo repo.TransactionRepo[TxType]
+	batchSize                 int
+	logger                    *zap.Logger
+}
+
+func NewApplyRulesToHistory[TxType any](rulePersistentRepo repo.CategorizationRuleRepo[TxType],
+	transactionPersistentRepo repo.TransactionRepo[TxType],
+	logger *zap.Logger,
+	batchSize int,
+) ApplyRulesToHistory[TxType] {
+	return &defaultApplyRulesToHistory[TxType]{
+		rulePersistentRepo:        rulePersistentRepo,
+		transactionPersistentRepo: transactionPersistentRepo,
+		batchSize:                 batchSize,
+		logger:                    logger,
+	}
+}
+
+func (d *defaultApplyRulesToHistory[TxType]) Execute(ctx context.Context, req *ApplyRulesReq, tx TxType) ([]*ApplyRulesResult, error) {
+	ctx, span := tracing.Start(ctx, "rule.ApplyRulesToHistory")
+	defer span.End()
+
+	rules, err := d.rulePersistentRepo.GetByUserId(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*ApplyRulesResult
+	query := &repo.Query{
+		SortBy: "transactions.id",
+		Order:  "ASC",
+		Limit:  d.batchSize,
+		Offset: 0,
+	}
+
+	for {
+		transactions, err := d.transactionPersistentRepo.GetByUserId(ctx, req.UserId, query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, transactionDetail := range transactions {
+			at, _ := transactionDetail.CreatedAtTime()
+			explanation := categorization.Evaluate(rules, &categorization.Subject{
+				Amount:          transactionDetail.Amount,
+				TransactionType: transactionDetail.TransactionType,
+				Bank:            transactionDetail.Bank,
+				Memo:            transactionDetail.Memo,
+				Counterparty:    transactionDetail.Counterparty,
+				At:              at,
+			})
+
+			result := &ApplyRulesResult{
+				TransactionId: transactionDetail.Id,
+				OldCategory:   transactionDetail.Category,
+				NewCategory:   transactionDetail.Category,
+				OldTags:       transactionDetail.Tags,
+				NewTags:       transactionDetail.Tags,
+				Explanation:   explanation,
+			}
+
+			if explanation.Matched {
+				result.NewCategory = explanation.Category
+				result.NewTags = categorization.MergeTags(categorization.SplitTags(transactionDetail.Tags), explanation.Tags)
+				result.Changed = result.NewCategory != result.OldCategory || result.NewTags != result.OldTags
+			}
+
+			if result.Changed && !req.DryRun {
+				err = d.transactionPersistentRepo.UpdateCategorization(ctx, result.TransactionId, result.NewCategory, result.NewTags, tx)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			results = append(results, result)
+		}
+
+		if len(transactions) < d.batchSize {
+			break
+		}
+		query.Offset += d.batchSize
+	}
+
+	return results, nil
+}
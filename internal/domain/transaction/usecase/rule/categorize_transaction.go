@@ -0,0 +1,44 @@
+package rule
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/categorization"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type CategorizeReq struct {
+	UserId  uint32
+	Subject *categorization.Subject
+}
+
+// CategorizeUseCase only evaluate rules of user (dry-run)
+// it never write anything, caller decide to apply explanation or not
+type CategorizeUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *CategorizeReq) (*categorization.Explanation, error)
+}
+
+type defaultCategorizeUseCase[TxType any] struct {
+	persistentRepo repo.CategorizationRuleRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewCategorizeUseCase[TxType any](persistentRepo repo.CategorizationRuleRepo[TxType], logger *zap.Logger) CategorizeUseCase[TxType] {
+	return &defaultCategorizeUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultCategorizeUseCase[TxType]) Execute(ctx context.Context, req *CategorizeReq) (*categorization.Explanation, error) {
+	ctx, span := tracing.Start(ctx, "rule.Categorize")
+	defer span.End()
+
+	rules, err := d.persistentRepo.GetByUserId(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	return categorization.Evaluate(rules, req.Subject), nil
+}
@@ -0,0 +1,94 @@
+package rule
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/categorization"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type CreateRuleReq struct {
+	// userId not bound from json
+	// because in url api
+	UserId   uint32 `json:"-"`
+	Name     string `json:"name"`
+	Priority int    `json:"priority"`
+	// nil mean enabled
+	Enabled *bool `json:"enabled"`
+
+	MinAmount           float32 `json:"min_amount"`
+	MaxAmount           float32 `json:"max_amount"`
+	TransactionType     string  `json:"transaction_type"`
+	Bank                string  `json:"bank"`
+	MatchMode           string  `json:"match_mode"`
+	MemoPattern         string  `json:"memo_pattern"`
+	CounterpartyPattern string  `json:"counterparty_pattern"`
+	TimeOfDayStart      string  `json:"time_of_day_start"`
+	TimeOfDayEnd        string  `json:"time_of_day_end"`
+
+	Category string   `json:"category"`
+	Tags     []string `json:"tags"`
+}
+
+type CreateRuleUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *CreateRuleReq, tx TxType) (*models.CategorizationRule, error)
+}
+
+type defaultCreateRuleUseCase[TxType any] struct {
+	persistentRepo repo.CategorizationRuleRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewCreateRuleUseCase[TxType any](persistentRepo repo.CategorizationRuleRepo[TxType], logger *zap.Logger) CreateRuleUseCase[TxType] {
+	return &defaultCreateRuleUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultCreateRuleUseCase[TxType]) Execute(ctx context.Context, req *CreateRuleReq, tx TxType) (*models.CategorizationRule, error) {
+	ctx, span := tracing.Start(ctx, "rule.CreateRule")
+	defer span.End()
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	matchMode := req.MatchMode
+	if matchMode == "" {
+		matchMode = models.RULEMATCHMODECONTAINS
+	}
+
+	ruleModel := &models.CategorizationRule{
+		UserId:              req.UserId,
+		Name:                req.Name,
+		Priority:            req.Priority,
+		Enabled:             enabled,
+		MinAmount:           req.MinAmount,
+		MaxAmount:           req.MaxAmount,
+		TransactionType:     req.TransactionType,
+		Bank:                req.Bank,
+		MatchMode:           matchMode,
+		MemoPattern:         req.MemoPattern,
+		CounterpartyPattern: req.CounterpartyPattern,
+		TimeOfDayStart:      req.TimeOfDayStart,
+		TimeOfDayEnd:        req.TimeOfDayEnd,
+		Category:            req.Category,
+		Tags:                categorization.MergeTags(req.Tags),
+	}
+
+	err := categorization.ValidateRule(ruleModel)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.persistentRepo.Create(ctx, ruleModel, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ruleModel, nil
+}
@@ -0,0 +1,53 @@
+package rule
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type DeleteRuleReq struct {
+	UserId uint32 `json:"user_id"`
+	RuleId uint32 `json:"rule_id"`
+}
+
+type DeleteRuleUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *DeleteRuleReq, tx TxType) (*models.CategorizationRule, error)
+}
+
+type defaultDeleteRuleUseCase[TxType any] struct {
+	persistentRepo repo.CategorizationRuleRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewDeleteRuleUseCase[TxType any](persistentRepo repo.CategorizationRuleRepo[TxType], logger *zap.Logger) DeleteRuleUseCase[TxType] {
+	return &defaultDeleteRuleUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultDeleteRuleUseCase[TxType]) Execute(ctx context.Context, req *DeleteRuleReq, tx TxType) (*models.CategorizationRule, error) {
+	ctx, span := tracing.Start(ctx, "rule.DeleteRule")
+	defer span.End()
+
+	ruleModel, err := d.persistentRepo.GetById(ctx, req.RuleId)
+	if err != nil {
+		return nil, err
+	}
+
+	if ruleModel.UserId != req.UserId {
+		// same as not found, don't expose rule of other user
+		return nil, exception.Newf(exception.NotFound, "RULE_NOT_FOUND", "rule %d not found for user %d", req.RuleId, req.UserId)
+	}
+
+	err = d.persistentRepo.Delete(ctx, ruleModel.ID, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ruleModel, nil
+}
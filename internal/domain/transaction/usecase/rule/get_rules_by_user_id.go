@@ -0,0 +1,36 @@
+package rule
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type GetRulesByUserIdReq struct {
+	UserId uint32
+}
+
+type GetRulesByUserId[TxType any] interface {
+	Execute(ctx context.Context, req *GetRulesByUserIdReq) ([]*models.CategorizationRule, error)
+}
+
+type defaultGetRulesByUserId[TxType any] struct {
+	persistentRepo repo.CategorizationRuleRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewGetRulesByUserId[TxType any](persistentRepo repo.CategorizationRuleRepo[TxType], logger *zap.Logger) GetRulesByUserId[TxType] {
+	return &defaultGetRulesByUserId[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultGetRulesByUserId[TxType]) Execute(ctx context.Context, req *GetRulesByUserIdReq) ([]*models.CategorizationRule, error) {
+	ctx, span := tracing.Start(ctx, "rule.GetRulesByUserId")
+	defer span.End()
+
+	return d.persistentRepo.GetByUserId(ctx, req.UserId)
+}
@@ -0,0 +1,90 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/logging"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type ClaimScheduleRunReq struct {
+	Schedule *models.Schedule
+}
+
+// ClaimScheduleRunUseCase insert run with unique idempotency key of next occurrence
+// and move schedule to the occurrence after it, in same tx as execute and finish of run
+// if key exist (restart or other worker) it return ErrScheduleRunAlreadyClaimed
+// and occurrence must not be executed again
+// schedule paused, resumed or skipped after it was read return ErrScheduleChanged, tx must be rolled back
+type ClaimScheduleRunUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *ClaimScheduleRunReq, tx TxType) (*models.ScheduleRun, error)
+}
+
+type defaultClaimScheduleRunUseCase[TxType any] struct {
+	persistentRepo repo.ScheduleRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewClaimScheduleRunUseCase[TxType any](persistentRepo repo.ScheduleRepo[TxType], logger *zap.Logger) ClaimScheduleRunUseCase[TxType] {
+	return &defaultClaimScheduleRunUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultClaimScheduleRunUseCase[TxType]) Execute(ctx context.Context, req *ClaimScheduleRunReq, tx TxType) (*models.ScheduleRun, error) {
+	ctx, span := tracing.Start(ctx, "schedule.ClaimScheduleRun")
+	defer span.End()
+
+	scheduleModel := req.Schedule
+	readNextRunAt, readStatus := scheduleModel.NextRunAt, scheduleModel.Status
+	key := IdempotencyKey(scheduleModel.ID, scheduleModel.NextRunAt)
+
+	existed, err := d.persistentRepo.GetRunByIdempotencyKey(ctx, key)
+	if err == nil && existed != nil {
+		return existed, fmt.Errorf("%w: %s", ErrScheduleRunAlreadyClaimed, key)
+	}
+
+	run := &models.ScheduleRun{
+		ScheduleId:     scheduleModel.ID,
+		IdempotencyKey: key,
+		ScheduledAt:    scheduleModel.NextRunAt,
+		Status:         models.SCHEDULERUNSTATUSSTARTED,
+	}
+
+	err = d.persistentRepo.CreateRun(ctx, run, tx)
+	if err != nil {
+		// unique index of idempotency key, other worker claimed first
+		logging.FromContext(ctx, d.logger).Info("[ClaimScheduleRun]", zap.String("key", key), zap.String("Error", err.Error()))
+		return nil, fmt.Errorf("%w: %s", ErrScheduleRunAlreadyClaimed, key)
+	}
+
+	lastRunAt := scheduleModel.NextRunAt
+	scheduleModel.LastRunAt = &lastRunAt
+	scheduleModel.RunCount++
+
+	nextRunAt, ok, err := nextRunAfter(scheduleModel, lastRunAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		scheduleModel.NextRunAt = nextRunAt
+	} else {
+		scheduleModel.Status = models.SCHEDULESTATUSCOMPLETED
+	}
+
+	// only fields of claim are saved, pause or resume made since read is never overwritten
+	updated, err := d.persistentRepo.UpdateIfUnchanged(ctx, scheduleModel, readNextRunAt, readStatus, tx)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, fmt.Errorf("%w: schedule %d", ErrScheduleChanged, scheduleModel.ID)
+	}
+
+	return run, nil
+}
@@ -0,0 +1,111 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"time"
+)
+
+var (
+	SCHEDULEACTIONPAUSE    = "pause"
+	SCHEDULEACTIONRESUME   = "resume"
+	SCHEDULEACTIONSKIPNEXT = "skip-next"
+)
+
+type ControlScheduleReq struct {
+	UserId     uint32
+	ScheduleId uint32
+	Action     string
+	Now        time.Time
+}
+
+// ControlScheduleUseCase pause, resume or skip next occurrence
+// resume never replay occurrences missed while paused
+type ControlScheduleUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *ControlScheduleReq, tx TxType) (*models.Schedule, error)
+}
+
+type defaultControlScheduleUseCase[TxType any] struct {
+	persistentRepo repo.ScheduleRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewControlScheduleUseCase[TxType any](persistentRepo repo.ScheduleRepo[TxType], logger *zap.Logger) ControlScheduleUseCase[TxType] {
+	return &defaultControlScheduleUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultControlScheduleUseCase[TxType]) Execute(ctx context.Context, req *ControlScheduleReq, tx TxType) (*models.Schedule, error) {
+	ctx, span := tracing.Start(ctx, "schedule.ControlSchedule")
+	defer span.End()
+
+	scheduleModel, err := d.persistentRepo.GetById(ctx, req.ScheduleId)
+	if err != nil && !errors.Is(err, exception.NotFound) {
+		return nil, err
+	}
+	if err != nil || scheduleModel.UserId != req.UserId {
+		return nil, fmt.Errorf("%w: schedule %d of user %d", ErrScheduleNotFound, req.ScheduleId, req.UserId)
+	}
+
+	readNextRunAt, readStatus := scheduleModel.NextRunAt, scheduleModel.Status
+	if scheduleModel.Status == models.SCHEDULESTATUSCOMPLETED {
+		return nil, fmt.Errorf("%w: schedule %d is completed", ErrScheduleStatusConflict, req.ScheduleId)
+	}
+
+	switch req.Action {
+	case SCHEDULEACTIONPAUSE:
+		if scheduleModel.Status != models.SCHEDULESTATUSACTIVE {
+			return nil, fmt.Errorf("%w: schedule %d is not active", ErrScheduleStatusConflict, req.ScheduleId)
+		}
+		scheduleModel.Status = models.SCHEDULESTATUSPAUSED
+	case SCHEDULEACTIONRESUME:
+		if scheduleModel.Status != models.SCHEDULESTATUSPAUSED {
+			return nil, fmt.Errorf("%w: schedule %d is not paused", ErrScheduleStatusConflict, req.ScheduleId)
+		}
+		scheduleModel.Status = models.SCHEDULESTATUSACTIVE
+		if scheduleModel.NextRunAt.Before(req.Now) {
+			err = d.advance(scheduleModel, req.Now.Add(-time.Nanosecond))
+		}
+	case SCHEDULEACTIONSKIPNEXT:
+		err = d.advance(scheduleModel, scheduleModel.NextRunAt)
+	default:
+		return nil, fmt.Errorf("%w: action expects one of [%s, %s, %s], !got: [%s]", ErrInvalidSchedule,
+			SCHEDULEACTIONPAUSE, SCHEDULEACTIONRESUME, SCHEDULEACTIONSKIPNEXT, req.Action)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// worker can claim occurrence meanwhile, its run is never overwritten by action
+	updated, err := d.persistentRepo.UpdateIfUnchanged(ctx, scheduleModel, readNextRunAt, readStatus, tx)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, fmt.Errorf("%w: schedule %d", ErrScheduleChanged, req.ScheduleId)
+	}
+	return scheduleModel, nil
+}
+
+func (d *defaultControlScheduleUseCase[TxType]) advance(scheduleModel *models.Schedule, after time.Time) error {
+	nextRunAt, ok, err := nextRunAfter(scheduleModel, after)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		scheduleModel.Status = models.SCHEDULESTATUSCOMPLETED
+		return nil
+	}
+	scheduleModel.NextRunAt = nextRunAt
+	return nil
+}
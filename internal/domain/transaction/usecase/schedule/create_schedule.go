@@ -0,0 +1,117 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"money_forward_code_challenge/pkgs/recurrence"
+	"time"
+)
+
+type CreateScheduleReq struct {
+	// userId not bound from json
+	// because in url api
+	UserId          uint32  `json:"-"`
+	AccountId       uint32  `json:"account_id"`
+	TargetAccountId uint32  `json:"target_account_id"`
+	Amount          float32 `json:"amount"`
+	TransactionType string  `json:"transaction_type"`
+	Memo            string  `json:"memo"`
+	Counterparty    string  `json:"counterparty"`
+
+	Frequency  string `json:"frequency"`
+	Interval   int    `json:"interval"`
+	DayOfMonth int    `json:"day_of_month"`
+	RRule      string `json:"rrule"`
+	MaxRuns    int    `json:"max_runs"`
+	// zero mean now
+	StartAt time.Time `json:"start_at"`
+}
+
+type CreateScheduleUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *CreateScheduleReq, tx TxType) (*models.Schedule, error)
+}
+
+type defaultCreateScheduleUseCase[TxType any] struct {
+	persistentRepo repo.ScheduleRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewCreateScheduleUseCase[TxType any](persistentRepo repo.ScheduleRepo[TxType], logger *zap.Logger) CreateScheduleUseCase[TxType] {
+	return &defaultCreateScheduleUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultCreateScheduleUseCase[TxType]) Execute(ctx context.Context, req *CreateScheduleReq, tx TxType) (*models.Schedule, error) {
+	ctx, span := tracing.Start(ctx, "schedule.CreateSchedule")
+	defer span.End()
+
+	startAt := req.StartAt
+	if startAt.IsZero() {
+		startAt = time.Now()
+	}
+
+	interval := req.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+
+	if req.DayOfMonth < 0 || req.DayOfMonth > 31 {
+		return nil, fmt.Errorf("%w: day_of_month must be in [1, 31]", ErrInvalidSchedule)
+	}
+
+	if req.TransactionType == models.TRANSACTIONTYPETRANSFER {
+		if req.TargetAccountId == 0 || req.TargetAccountId == req.AccountId {
+			return nil, fmt.Errorf("%w: transfer needs target_account_id other than account_id", ErrInvalidSchedule)
+		}
+	}
+
+	scheduleModel := &models.Schedule{
+		UserId:          req.UserId,
+		AccountId:       req.AccountId,
+		TargetAccountId: req.TargetAccountId,
+		Amount:          req.Amount,
+		TransactionType: req.TransactionType,
+		Memo:            req.Memo,
+		Counterparty:    req.Counterparty,
+		Frequency:       req.Frequency,
+		Interval:        interval,
+		DayOfMonth:      req.DayOfMonth,
+		RRule:           req.RRule,
+		MaxRuns:         req.MaxRuns,
+		StartAt:         startAt,
+		Status:          models.SCHEDULESTATUSACTIVE,
+	}
+
+	if scheduleModel.Frequency == models.SCHEDULEFREQUENCYRRULE {
+		// COUNT of rrule is kept by max_runs
+		rule, err := recurrence.Parse(scheduleModel.RRule)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+		}
+		if rule.Count > 0 && scheduleModel.MaxRuns == 0 {
+			scheduleModel.MaxRuns = rule.Count
+		}
+	}
+
+	nextRunAt, ok, err := nextRunAfter(scheduleModel, startAt.Add(-time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: schedule has no occurrence from start_at", ErrInvalidSchedule)
+	}
+	scheduleModel.NextRunAt = nextRunAt
+
+	err = d.persistentRepo.Create(ctx, scheduleModel, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduleModel, nil
+}
@@ -0,0 +1,58 @@
+package schedule
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type FinishScheduleRunReq struct {
+	Run           *models.ScheduleRun
+	TransactionId uint32
+	// error of execute, run is failed and never be retried
+	Err error
+}
+
+// FinishScheduleRunUseCase save result of claimed run
+type FinishScheduleRunUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *FinishScheduleRunReq, tx TxType) (*models.ScheduleRun, error)
+}
+
+type defaultFinishScheduleRunUseCase[TxType any] struct {
+	persistentRepo repo.ScheduleRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewFinishScheduleRunUseCase[TxType any](persistentRepo repo.ScheduleRepo[TxType], logger *zap.Logger) FinishScheduleRunUseCase[TxType] {
+	return &defaultFinishScheduleRunUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultFinishScheduleRunUseCase[TxType]) Execute(ctx context.Context, req *FinishScheduleRunReq, tx TxType) (*models.ScheduleRun, error) {
+	ctx, span := tracing.Start(ctx, "schedule.FinishScheduleRun")
+	defer span.End()
+
+	req.Run.TransactionId = req.TransactionId
+	req.Run.Status = models.SCHEDULERUNSTATUSSUCCEEDED
+	if req.Err != nil {
+		req.Run.Status = models.SCHEDULERUNSTATUSFAILED
+		req.Run.Error = truncate(req.Err.Error(), 255)
+	}
+
+	err := d.persistentRepo.UpdateRun(ctx, req.Run, tx)
+	if err != nil {
+		return nil, err
+	}
+	return req.Run, nil
+}
+
+func truncate(value string, size int) string {
+	if len(value) <= size {
+		return value
+	}
+	return value[:size]
+}
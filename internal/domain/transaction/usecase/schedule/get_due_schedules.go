@@ -0,0 +1,38 @@
+package schedule
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"time"
+)
+
+type GetDueSchedulesReq struct {
+	Now   time.Time
+	Limit int
+}
+
+type GetDueSchedules[TxType any] interface {
+	Execute(ctx context.Context, req *GetDueSchedulesReq) ([]*models.Schedule, error)
+}
+
+type defaultGetDueSchedules[TxType any] struct {
+	persistentRepo repo.ScheduleRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewGetDueSchedules[TxType any](persistentRepo repo.ScheduleRepo[TxType], logger *zap.Logger) GetDueSchedules[TxType] {
+	return &defaultGetDueSchedules[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultGetDueSchedules[TxType]) Execute(ctx context.Context, req *GetDueSchedulesReq) ([]*models.Schedule, error) {
+	ctx, span := tracing.Start(ctx, "schedule.GetDueSchedules")
+	defer span.End()
+
+	return d.persistentRepo.GetDue(ctx, req.Now, req.Limit)
+}
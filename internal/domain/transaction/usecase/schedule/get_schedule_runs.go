@@ -0,0 +1,49 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type GetScheduleRunsReq struct {
+	UserId     uint32
+	ScheduleId uint32
+	Query      *repo.Query
+}
+
+type GetScheduleRuns[TxType any] interface {
+	Execute(ctx context.Context, req *GetScheduleRunsReq) ([]*models.ScheduleRun, error)
+}
+
+type defaultGetScheduleRuns[TxType any] struct {
+	persistentRepo repo.ScheduleRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewGetScheduleRuns[TxType any](persistentRepo repo.ScheduleRepo[TxType], logger *zap.Logger) GetScheduleRuns[TxType] {
+	return &defaultGetScheduleRuns[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultGetScheduleRuns[TxType]) Execute(ctx context.Context, req *GetScheduleRunsReq) ([]*models.ScheduleRun, error) {
+	ctx, span := tracing.Start(ctx, "schedule.GetScheduleRuns")
+	defer span.End()
+
+	scheduleModel, err := d.persistentRepo.GetById(ctx, req.ScheduleId)
+	if err != nil && !errors.Is(err, exception.NotFound) {
+		return nil, err
+	}
+	if err != nil || scheduleModel.UserId != req.UserId {
+		return nil, fmt.Errorf("%w: schedule %d of user %d", ErrScheduleNotFound, req.ScheduleId, req.UserId)
+	}
+
+	return d.persistentRepo.GetRunsByScheduleId(ctx, req.ScheduleId, req.Query)
+}
@@ -0,0 +1,37 @@
+package schedule
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type GetSchedulesByUserIdReq struct {
+	UserId uint32
+	Query  *repo.Query
+}
+
+type GetSchedulesByUserId[TxType any] interface {
+	Execute(ctx context.Context, req *GetSchedulesByUserIdReq) ([]*models.Schedule, error)
+}
+
+type defaultGetSchedulesByUserId[TxType any] struct {
+	persistentRepo repo.ScheduleRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewGetSchedulesByUserId[TxType any](persistentRepo repo.ScheduleRepo[TxType], logger *zap.Logger) GetSchedulesByUserId[TxType] {
+	return &defaultGetSchedulesByUserId[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultGetSchedulesByUserId[TxType]) Execute(ctx context.Context, req *GetSchedulesByUserIdReq) ([]*models.Schedule, error) {
+	ctx, span := tracing.Start(ctx, "schedule.GetSchedulesByUserId")
+	defer span.End()
+
+	return d.persistentRepo.GetByUserId(ctx, req.UserId, req.Query)
+}
@@ -0,0 +1,45 @@
+package schedule
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"time"
+)
+
+// StaleRunAfter is how long a run can stay started, execute of one occurrence take seconds
+var StaleRunAfter = 10 * time.Minute
+
+// staleRunError is error of run interrupted between claim and finish (crash, deploy)
+const staleRunError = "interrupted before result was saved, transaction may exist, check account before creating it again"
+
+type RecoverStaleRunsReq struct {
+	Now time.Time
+}
+
+// RecoverStaleRunsUseCase fail runs which stay started longer than StaleRunAfter
+// run is committed started only by builds which claimed before execute, now claim, transaction and result commit together
+// result of execute is unknown (transaction can be committed before crash), so occurrence is not executed again
+type RecoverStaleRunsUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *RecoverStaleRunsReq) (int64, error)
+}
+
+type defaultRecoverStaleRunsUseCase[TxType any] struct {
+	persistentRepo repo.ScheduleRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewRecoverStaleRunsUseCase[TxType any](persistentRepo repo.ScheduleRepo[TxType], logger *zap.Logger) RecoverStaleRunsUseCase[TxType] {
+	return &defaultRecoverStaleRunsUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultRecoverStaleRunsUseCase[TxType]) Execute(ctx context.Context, req *RecoverStaleRunsReq) (int64, error) {
+	ctx, span := tracing.Start(ctx, "schedule.RecoverStaleRuns")
+	defer span.End()
+
+	return d.persistentRepo.FailStaleRuns(ctx, req.Now.Add(-StaleRunAfter), staleRunError)
+}
@@ -0,0 +1,80 @@
+package schedule
+
+import (
+	"fmt"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/pkgs/recurrence"
+	"time"
+)
+
+var (
+	ErrInvalidSchedule           = exception.New(exception.Validation, "INVALID_SCHEDULE", "invalid schedule")
+	ErrScheduleNotFound          = exception.New(exception.NotFound, "SCHEDULE_NOT_FOUND", "schedule not found")
+	ErrScheduleRunAlreadyClaimed = exception.New(exception.Conflict, "SCHEDULE_RUN_ALREADY_CLAIMED", "schedule run already claimed")
+	// action is valid but not in current status of schedule
+	ErrScheduleStatusConflict = exception.New(exception.Conflict, "SCHEDULE_STATUS_CONFLICT", "schedule status does not allow action")
+	// schedule was claimed, paused or resumed after it was read
+	ErrScheduleChanged = exception.New(exception.Conflict, "SCHEDULE_CHANGED", "schedule changed since it was read, try again")
+)
+
+// IdempotencyKey is unique per occurrence of schedule
+func IdempotencyKey(scheduleId uint32, scheduledAt time.Time) string {
+	return fmt.Sprintf("schedule:%d:%d", scheduleId, scheduledAt.Unix())
+}
+
+// recurrenceOf map frequency of schedule into rrule subset, nil mean run once
+func recurrenceOf(schedule *models.Schedule) (*recurrence.Rule, error) {
+	interval := schedule.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+
+	var rule *recurrence.Rule
+	switch schedule.Frequency {
+	case models.SCHEDULEFREQUENCYONCE:
+		return nil, nil
+	case models.SCHEDULEFREQUENCYDAILY:
+		rule = &recurrence.Rule{Freq: recurrence.FREQDAILY, Interval: interval}
+	case models.SCHEDULEFREQUENCYWEEKLY:
+		rule = &recurrence.Rule{Freq: recurrence.FREQWEEKLY, Interval: interval}
+	case models.SCHEDULEFREQUENCYMONTHLY:
+		rule = &recurrence.Rule{Freq: recurrence.FREQMONTHLY, Interval: interval, ByMonthDay: schedule.DayOfMonth}
+	case models.SCHEDULEFREQUENCYRRULE:
+		parsed, err := recurrence.Parse(schedule.RRule)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+		}
+		rule = parsed
+	default:
+		return nil, fmt.Errorf("%w: frequency expects one of %v, !got: [%s]", ErrInvalidSchedule, models.SCHEDULEFREQUENCYEXPECTS, schedule.Frequency)
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+	return rule, nil
+}
+
+// nextRunAfter return next occurrence after `after`, false when schedule is finished
+func nextRunAfter(schedule *models.Schedule, after time.Time) (time.Time, bool, error) {
+	if schedule.MaxRuns > 0 && schedule.RunCount >= schedule.MaxRuns {
+		return time.Time{}, false, nil
+	}
+
+	rule, err := recurrenceOf(schedule)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	if rule == nil {
+		// once, only start_at
+		if after.Before(schedule.StartAt) {
+			return schedule.StartAt, true, nil
+		}
+		return time.Time{}, false, nil
+	}
+
+	next, ok := rule.Next(schedule.StartAt, after)
+	return next, ok, nil
+}
@@ -2,22 +2,45 @@ package transaction
 
 import (
 	"context"
+	"fmt"
 	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/common/validation"
 	"money_forward_code_challenge/internal/domain/transaction/aggregate"
+	"money_forward_code_challenge/internal/domain/transaction/categorization"
 	"money_forward_code_challenge/internal/domain/transaction/models"
 	"money_forward_code_challenge/internal/domain/transaction/repo"
 	"money_forward_code_challenge/pkgs/repo_pool_async"
 )
 
 type CreateReq struct {
-	// userId, BankTypeName not required in json binding
-	// because in url api
+	// userId, BankTypeName not bound from json
+	// user is in url api, bank is of account
 	// it be checked
-	UserId          uint32  `json:"user_id"`
-	BankType        string  `json:"bank_type"`
-	AccountId       uint32  `json:"account_id,binding:required"`
-	Amount          float32 `json:"amount,binding:required"`
-	TransactionType string  `json:"transaction_type,binding:required"`
+	UserId   uint32 `json:"-"`
+	BankType string `json:"-"`
+	// required fields are checked by Validate with other field errors, not by binding
+	AccountId       uint32  `json:"account_id"`
+	Amount          float32 `json:"amount"`
+	TransactionType string  `json:"transaction_type"`
+
+	Memo         string `json:"memo"`
+	Counterparty string `json:"counterparty"`
+	// category and tags can be set by client
+	// or by categorization rules of user on create
+	Category string   `json:"category"`
+	Tags     []string `json:"tags"`
+	// set by system on fee transaction, not bound from json
+	ParentTransactionId uint32 `json:"-"`
+}
+
+// Validate create of client, transactions posted by system (fee, interest) are not validated
+func (r *CreateReq) Validate() error {
+	return validation.New().
+		Field("account_id", r.AccountId, validation.Required()).
+		Field("transaction_type", r.TransactionType, validation.TransactionType(models.TRANSACTIONTYPEEXPECTS)).
+		Field("amount", r.Amount, validation.Amount(models.TRANSACTIONAMOUNTMIN, models.TRANSACTIONAMOUNTMAX)).
+		Err()
 }
 
 type CreateUseCase[TxType any] interface {
@@ -51,11 +74,19 @@ func (d *defaultCreateUseCase[TxType]) Execute(ctx context.Context, req *CreateR
 	*aggregate.TransactionByDetails,
 	*repo_pool_async.Job,
 	error) {
+	ctx, span := tracing.Start(ctx, "transaction.Create")
+	defer span.End()
 
 	transactionModel := &models.Transaction{
 		AccountID:       req.AccountId,
 		Amount:          req.Amount,
 		TransactionType: req.TransactionType,
+		Memo:            req.Memo,
+		Counterparty:    req.Counterparty,
+		Category:        req.Category,
+		Tags:            categorization.MergeTags(req.Tags),
+
+		ParentTransactionId: req.ParentTransactionId,
 	}
 
 	err := d.persistentRepo.Create(ctx, transactionModel, tx)
@@ -74,11 +105,17 @@ func (d *defaultCreateUseCase[TxType]) Execute(ctx context.Context, req *CreateR
 		TransactionType: req.TransactionType,
 		Bank:            req.BankType,
 		CreatedAt:       transactionModel.CreatedAt.String(),
+		Memo:            transactionModel.Memo,
+		Counterparty:    transactionModel.Counterparty,
+		Category:        transactionModel.Category,
+		Tags:            transactionModel.Tags,
+
+		ParentTransactionId: transactionModel.ParentTransactionId,
 	}
 
 	_ = details.FormatDateHCM()
-	asyncUpdate := d.pool.PushPriority(ctx, func(ctx context.Context) {
-		_ = d.cacheRepo.Set(ctx, details)
+	asyncUpdate := d.pool.PushPriority(ctx, fmt.Sprintf("transaction:%d", details.Id), func(ctx context.Context) error {
+		return d.cacheRepo.Set(ctx, details)
 	})
 	return details, asyncUpdate, nil
 }
@@ -2,7 +2,12 @@ package transaction
 
 import (
 	"context"
+	"fmt"
 	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/logging"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/common/validation"
 	"money_forward_code_challenge/internal/domain/transaction/aggregate"
 	"money_forward_code_challenge/internal/domain/transaction/models"
 	"money_forward_code_challenge/internal/domain/transaction/repo"
@@ -13,6 +18,17 @@ type DeleteReq struct {
 	AccountId     uint32 `json:"account_id"`
 	TransactionId uint32 `json:"transaction_id"`
 }
+
+func (r *DeleteReq) Validate() error {
+	return validation.New().
+		Field("account_id", r.AccountId, validation.Required()).
+		Field("transaction_id", r.TransactionId, validation.Required()).
+		Err()
+}
+
+var ErrSystemTransaction = exception.New(exception.Forbidden, "SYSTEM_TRANSACTION", "transaction posted by system can not be reversed")
+
+// DeleteTransactionById reverse transaction and its fee in tx, fee is returned as FeeTransaction of detail
 type DeleteTransactionById[TxType any] interface {
 	Execute(ctx context.Context, req *DeleteReq, tx TxType) (*aggregate.TransactionByDetails, *repo_pool_async.Job, error)
 }
@@ -41,6 +57,8 @@ func (d *defaultDeleteTransactionByAccountIdUseCase[TxType]) Execute(ctx context
 	*repo_pool_async.Job,
 	error,
 ) {
+	ctx, span := tracing.Start(ctx, "transaction.DeleteTransactionById")
+	defer span.End()
 
 	detail, err := d.persistentRepo.GetById(ctx, req.TransactionId)
 	if err != nil {
@@ -48,14 +66,37 @@ func (d *defaultDeleteTransactionByAccountIdUseCase[TxType]) Execute(ctx context
 		return nil, nil, err
 	}
 
+	if models.IsSystemTransactionType(detail.TransactionType) {
+		return nil, nil, fmt.Errorf("%w: transaction %d is %s", ErrSystemTransaction, detail.Id, detail.TransactionType)
+	}
+
 	err = d.persistentRepo.Delete(ctx, &models.Transaction{ID: detail.Id}, tx)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	asyncDeleteJob := d.pool.PushPriority(ctx, func(ctx context.Context) {
-		d.logger.Info("Delete Transaction From Cache [cacheRepo.Delete(ctx, detail.Id]")
-		_ = d.cacheRepo.Delete(ctx, detail.Id)
+	// create post at most one fee per transaction, it is given back with transaction
+	fees, err := d.persistentRepo.GetFeesByParentId(ctx, detail.Id, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, fee := range fees {
+		err = d.persistentRepo.Delete(ctx, &models.Transaction{ID: fee.Id}, tx)
+		if err != nil {
+			return nil, nil, err
+		}
+		detail.FeeTransaction = fee
+	}
+
+	asyncDeleteJob := d.pool.PushPriority(ctx, fmt.Sprintf("transaction:%d", detail.Id), func(ctx context.Context) error {
+		logging.FromContext(ctx, d.logger).Info("Delete Transaction From Cache [cacheRepo.Delete(ctx, detail.Id]")
+		for _, fee := range fees {
+			err := d.cacheRepo.Delete(ctx, fee.Id)
+			if err != nil {
+				return err
+			}
+		}
+		return d.cacheRepo.Delete(ctx, detail.Id)
 	})
 
 	return detail, asyncDeleteJob, nil
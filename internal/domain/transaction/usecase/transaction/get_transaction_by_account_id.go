@@ -3,6 +3,7 @@ package transaction
 import (
 	"context"
 	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
 	"money_forward_code_challenge/internal/domain/transaction/aggregate"
 	"money_forward_code_challenge/internal/domain/transaction/repo"
 )
@@ -32,5 +33,8 @@ func NewDefaultGetTransactionsByAccountId[TxType any](persistentRepo repo.Transa
 }
 
 func (d *defaultGetTransactionsByAccountIdUseCase[TxType]) Execute(ctx context.Context, req *GetTransactionByAccountIdReq) ([]*aggregate.TransactionByDetails, error) {
+	ctx, span := tracing.Start(ctx, "transaction.GetTransactionsByAccountId")
+	defer span.End()
+
 	return d.persistentRepo.GetByAccountId(ctx, req.AccountId, req.Query)
 }
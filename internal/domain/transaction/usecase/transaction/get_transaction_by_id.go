@@ -3,6 +3,8 @@ package transaction
 import (
 	"context"
 	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/metrics"
+	"money_forward_code_challenge/internal/common/tracing"
 	"money_forward_code_challenge/internal/domain/transaction/aggregate"
 	"money_forward_code_challenge/internal/domain/transaction/repo"
 )
@@ -30,7 +32,11 @@ func NewDefaultGetTransactionById[TxType any](persistentRepo repo.TransactionRep
 }
 
 func (d *defaultGetTransactionByIdUseCase[TxType]) Execute(ctx context.Context, req *GetTransactionByIdReq) (*aggregate.TransactionByDetails, error) {
+	ctx, span := tracing.Start(ctx, "transaction.GetTransactionById")
+	defer span.End()
+
 	transactionDetail, err := d.cacheRepo.GetById(ctx, req.Id)
+	metrics.ObserveCache(metrics.CacheTransaction, err == nil)
 	if err != nil {
 		transactionDetail, err = d.persistentRepo.GetById(ctx, req.Id)
 		if err != nil {
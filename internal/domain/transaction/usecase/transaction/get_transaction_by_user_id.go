@@ -3,6 +3,7 @@ package transaction
 import (
 	"context"
 	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
 	"money_forward_code_challenge/internal/domain/transaction/aggregate"
 	"money_forward_code_challenge/internal/domain/transaction/repo"
 )
@@ -31,5 +32,8 @@ func NewGetTransactionsByUserId[TxType any](persistentRepo repo.TransactionRepo[
 }
 
 func (d *defaultGetTransactionsByUserId[TxType]) Execute(ctx context.Context, req *GetTransactionByUserIdReq) ([]*aggregate.TransactionByDetails, error) {
+	ctx, span := tracing.Start(ctx, "transaction.GetTransactionsByUserId")
+	defer span.End()
+
 	return d.persistentRepo.GetByUserId(ctx, req.UserId, req.Query)
 }
@@ -2,7 +2,10 @@ package user
 
 import (
 	"context"
-	"fmt"
+	"money_forward_code_challenge/internal/common/logging"
+	"money_forward_code_challenge/internal/common/metrics"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/common/validation"
 	"money_forward_code_challenge/internal/domain/transaction/aggregate"
 	"money_forward_code_challenge/internal/domain/transaction/repo"
 
@@ -17,6 +20,12 @@ type GetAccountByAccountIdReq struct {
 	AccountId uint32 `json:"account_id"`
 }
 
+func (r *GetAccountByAccountIdReq) Validate() error {
+	return validation.New().
+		Field("account_id", r.AccountId, validation.Required()).
+		Err()
+}
+
 type defaultGetAccountByAccountId[TxType any] struct {
 	persistentRepo repo.UserRepo[TxType]
 	cacheRepo      repo.UserCacheRepo
@@ -33,18 +42,21 @@ func NewGetAccountByAccountId[TxType any](persistentRepo repo.UserRepo[TxType],
 }
 
 func (d *defaultGetAccountByAccountId[TxType]) Execute(ctx context.Context, req *GetAccountByAccountIdReq) (*aggregate.AccountByDetails, error) {
+	ctx, span := tracing.Start(ctx, "user.GetAccountByAccountId")
+	defer span.End()
+
 	accountDetail, err := d.cacheRepo.GetAccountByAccountId(ctx, req.AccountId)
+	metrics.ObserveCache(metrics.CacheAccount, err == nil)
 	if err != nil {
-		d.logger.Error("Get From Cache Failed, Try To Get From Persistent DB")
+		logging.FromContext(ctx, d.logger).Error("Get From Cache Failed, Try To Get From Persistent DB")
 		accountDetail, err = d.persistentRepo.GetAccountByAccountId(ctx, req.AccountId)
-		fmt.Println(accountDetail, req)
 		if err != nil {
 			return nil, err
 		}
 
 		_ = d.cacheRepo.SetAccount(ctx, accountDetail)
 	} else {
-		d.logger.Info("Get From Cache Success")
+		logging.FromContext(ctx, d.logger).Info("Get From Cache Success")
 	}
 	return accountDetail, nil
 }
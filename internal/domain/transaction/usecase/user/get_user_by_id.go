@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
 	"money_forward_code_challenge/internal/domain/transaction/models"
 	"money_forward_code_challenge/internal/domain/transaction/repo"
 )
@@ -29,5 +30,8 @@ func NewGetUserByIdUseCase[TxType any](persistentRepo repo.UserRepo[TxType], cac
 }
 
 func (u *GetUserByIdUseCase[TxType]) Execute(ctx context.Context, req *GetUserByIdReq) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "user.GetUserById")
+	defer span.End()
+
 	return u.persistentRepo.GetUserById(ctx, req.UserId)
 }
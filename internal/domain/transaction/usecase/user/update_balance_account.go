@@ -2,6 +2,10 @@ package user
 
 import (
 	"context"
+	"fmt"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/logging"
+	"money_forward_code_challenge/internal/common/tracing"
 	"money_forward_code_challenge/internal/domain/transaction/models"
 	"money_forward_code_challenge/internal/domain/transaction/repo"
 	"money_forward_code_challenge/pkgs/repo_pool_async"
@@ -9,11 +13,23 @@ import (
 	"go.uber.org/zap"
 )
 
+var (
+	ErrInsufficientBalance = exception.New(exception.InsufficientFunds, "INSUFFICIENT_FUNDS", "available balance is not enough")
+	// account is found but it is of other user than url param <user_id>
+	ErrAccountOwner = exception.New(exception.Forbidden, "ACCOUNT_FORBIDDEN", "user account owner is not same as url param <user_id>")
+)
+
 type UpdateBalanceAccountReq struct {
 	AccountId       uint32
 	Amount          float32
 	OldBalance      float32
 	TransactionType string
+	// system charges (overdraft interest) are debited even over overdraft limit
+	AllowOverLimit bool
+	// fee of transaction, always debited in same statement so cache entry is changed once
+	Fee float32
+	// undo effect of transaction with Amount and TransactionType (reverse, delete)
+	Reverse bool
 }
 type UpdateBalanceAccountUseCase[TxType any] interface {
 	Execute(ctx context.Context, req *UpdateBalanceAccountReq, tx TxType) (*repo_pool_async.Job, error)
@@ -39,27 +55,48 @@ func NewUpdateBalanceAccountUseCase[TxType any](persistentRepo repo.UserRepo[TxT
 }
 
 func (d *defaultUpdateBalanceAccountUseCase[TxType]) Execute(ctx context.Context, req *UpdateBalanceAccountReq, tx TxType) (*repo_pool_async.Job, error) {
+	ctx, span := tracing.Start(ctx, "user.UpdateBalanceAccount")
+	defer span.End()
 
-	account, err := d.cacheRepo.GetAccountByAccountId(ctx, req.AccountId)
+	// account must exist, relative update of unknown account change no row
+	_, err := d.cacheRepo.GetAccountByAccountId(ctx, req.AccountId)
 	if err != nil {
-		return nil, err
+		// redis down or entry evicted, balance change does not depend on cache
+		_, err = d.persistentRepo.GetAccountByAccountId(ctx, req.AccountId)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if req.TransactionType == models.TRANSACTIONTYPEDEPOSIT {
-		account.Balance += req.Amount
-	} else if req.TransactionType == models.TRANSACTIONTYPEWITHDRAW {
-		account.Balance -= req.Amount
+	// balance is changed relatively in db, never overwritten from cache
+	// debit check available balance (holds, overdraft) in same statement
+	delta := req.Amount - req.Fee
+	if models.IsDebitTransactionType(req.TransactionType) {
+		delta = -req.Amount - req.Fee
+	}
+	if req.Reverse {
+		delta = -delta
 	}
 
-	err = d.persistentRepo.UpdateBalance(ctx, req.AccountId, account.Balance, tx)
-
+	if delta >= 0 || req.AllowOverLimit {
+		// deposit, interest, or system charges
+		err = d.persistentRepo.AddBalance(ctx, req.AccountId, delta, tx)
+	} else {
+		var debited bool
+		debited, err = d.persistentRepo.DebitBalance(ctx, req.AccountId, -delta, tx)
+		if err == nil && !debited {
+			err = fmt.Errorf("%w: account %d", ErrInsufficientBalance, req.AccountId)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	job := d.pool.PushPriority(ctx, func(ctx context.Context) {
-		d.logger.Info("update balance account")
-		d.cacheRepo.SetAccount(ctx, account)
+	// balance read before update is stale when another transaction changed it concurrently,
+	// cache is invalidated and next read refill it from committed row
+	job := d.pool.PushPriority(ctx, fmt.Sprintf("account:%d", req.AccountId), func(ctx context.Context) error {
+		logging.FromContext(ctx, d.logger).Info("invalidate balance account")
+		return d.cacheRepo.DeleteAccountById(ctx, req.AccountId)
 	})
 
 	return job, nil
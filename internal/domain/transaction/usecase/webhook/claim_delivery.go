@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"time"
+)
+
+type ClaimDeliveryReq struct {
+	Delivery *models.WebhookDelivery
+	Now      time.Time
+}
+
+// ClaimDeliveryUseCase lease delivery to one worker and return its subscription
+// lease end is next attempt, so delivery claimed by crashed worker is retried after lease
+type ClaimDeliveryUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *ClaimDeliveryReq, tx TxType) (*models.WebhookSubscription, error)
+}
+
+type defaultClaimDeliveryUseCase[TxType any] struct {
+	persistentRepo repo.WebhookRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewClaimDeliveryUseCase[TxType any](persistentRepo repo.WebhookRepo[TxType], logger *zap.Logger) ClaimDeliveryUseCase[TxType] {
+	return &defaultClaimDeliveryUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultClaimDeliveryUseCase[TxType]) Execute(ctx context.Context, req *ClaimDeliveryReq, tx TxType) (*models.WebhookSubscription, error) {
+	ctx, span := tracing.Start(ctx, "webhook.ClaimDelivery")
+	defer span.End()
+
+	claimed, err := d.persistentRepo.ClaimDelivery(ctx, req.Delivery, req.Now.Add(DeliveryLease), tx)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, fmt.Errorf("delivery %d is claimed by other worker", req.Delivery.ID)
+	}
+
+	subscription, err := d.persistentRepo.GetSubscriptionById(ctx, req.Delivery.SubscriptionId)
+	if err != nil && !errors.Is(err, exception.NotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %d", ErrSubscriptionNotFound, req.Delivery.SubscriptionId)
+	}
+	return subscription, nil
+}
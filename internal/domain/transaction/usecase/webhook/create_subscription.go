@@ -0,0 +1,72 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	webhookpkg "money_forward_code_challenge/pkgs/webhook"
+)
+
+type CreateSubscriptionReq struct {
+	UserId     uint32   `json:"-"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	// generated when empty, it is only returned on create
+	Secret string `json:"secret"`
+}
+
+type CreateSubscriptionUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *CreateSubscriptionReq, tx TxType) (*models.WebhookSubscription, error)
+}
+
+type defaultCreateSubscriptionUseCase[TxType any] struct {
+	persistentRepo repo.WebhookRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewCreateSubscriptionUseCase[TxType any](persistentRepo repo.WebhookRepo[TxType], logger *zap.Logger) CreateSubscriptionUseCase[TxType] {
+	return &defaultCreateSubscriptionUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultCreateSubscriptionUseCase[TxType]) Execute(ctx context.Context, req *CreateSubscriptionReq, tx TxType) (*models.WebhookSubscription, error) {
+	ctx, span := tracing.Start(ctx, "webhook.CreateSubscription")
+	defer span.End()
+
+	err := ValidateURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes, err := ParseEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		secret, err = webhookpkg.NewSecret()
+		if err != nil {
+			return nil, err
+		}
+	} else if len(secret) < 16 || len(secret) > 100 {
+		return nil, fmt.Errorf("%w: secret length must be in [16, 100]", ErrInvalidSubscription)
+	}
+
+	subscription := &models.WebhookSubscription{
+		UserId:     req.UserId,
+		URL:        req.URL,
+		EventTypes: eventTypes,
+		Secret:     secret,
+	}
+	err = d.persistentRepo.CreateSubscription(ctx, subscription, tx)
+	if err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type DeleteSubscriptionReq struct {
+	UserId         uint32
+	SubscriptionId uint32
+}
+
+// DeleteSubscriptionUseCase stop new deliveries, pending deliveries of it are failed by worker
+type DeleteSubscriptionUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *DeleteSubscriptionReq, tx TxType) error
+}
+
+type defaultDeleteSubscriptionUseCase[TxType any] struct {
+	persistentRepo repo.WebhookRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewDeleteSubscriptionUseCase[TxType any](persistentRepo repo.WebhookRepo[TxType], logger *zap.Logger) DeleteSubscriptionUseCase[TxType] {
+	return &defaultDeleteSubscriptionUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultDeleteSubscriptionUseCase[TxType]) Execute(ctx context.Context, req *DeleteSubscriptionReq, tx TxType) error {
+	ctx, span := tracing.Start(ctx, "webhook.DeleteSubscription")
+	defer span.End()
+
+	deleted, err := d.persistentRepo.DeleteSubscription(ctx, req.UserId, req.SubscriptionId, tx)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("%w: %d", ErrSubscriptionNotFound, req.SubscriptionId)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"time"
+)
+
+type EnqueueEventsReq struct {
+	UserId uint32
+	Events []*Event
+	Now    time.Time
+}
+
+// EnqueueEventsUseCase write one delivery per (subscription, event) in tx of event
+// deliveries are sent by worker only after tx is committed
+type EnqueueEventsUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *EnqueueEventsReq, tx TxType) (int, error)
+}
+
+type defaultEnqueueEventsUseCase[TxType any] struct {
+	persistentRepo repo.WebhookRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewEnqueueEventsUseCase[TxType any](persistentRepo repo.WebhookRepo[TxType], logger *zap.Logger) EnqueueEventsUseCase[TxType] {
+	return &defaultEnqueueEventsUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultEnqueueEventsUseCase[TxType]) Execute(ctx context.Context, req *EnqueueEventsReq, tx TxType) (int, error) {
+	ctx, span := tracing.Start(ctx, "webhook.EnqueueEvents")
+	defer span.End()
+
+	subscriptions, err := d.persistentRepo.GetSubscriptionsByUserId(ctx, req.UserId)
+	if err != nil || len(subscriptions) == 0 {
+		return 0, err
+	}
+
+	var deliveries []*models.WebhookDelivery
+	for _, event := range req.Events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, subscription := range subscriptions {
+			if !Subscribed(subscription, event.Type) {
+				continue
+			}
+			deliveries = append(deliveries, &models.WebhookDelivery{
+				SubscriptionId: subscription.ID,
+				UserId:         req.UserId,
+				EventId:        event.Id,
+				EventType:      event.Type,
+				Payload:        string(payload),
+				Status:         models.WEBHOOKDELIVERYSTATUSPENDING,
+				NextAttemptAt:  req.Now,
+			})
+		}
+	}
+
+	err = d.persistentRepo.CreateDeliveries(ctx, deliveries, tx)
+	if err != nil {
+		return 0, err
+	}
+	return len(deliveries), nil
+}
@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type GetAttemptsReq struct {
+	UserId         uint32
+	SubscriptionId uint32
+	DeliveryId     uint32
+}
+
+// GetAttempts return every http call of one delivery, oldest first
+type GetAttempts[TxType any] interface {
+	Execute(ctx context.Context, req *GetAttemptsReq) ([]*models.WebhookAttempt, error)
+}
+
+type defaultGetAttempts[TxType any] struct {
+	persistentRepo repo.WebhookRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewGetAttempts[TxType any](persistentRepo repo.WebhookRepo[TxType], logger *zap.Logger) GetAttempts[TxType] {
+	return &defaultGetAttempts[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultGetAttempts[TxType]) Execute(ctx context.Context, req *GetAttemptsReq) ([]*models.WebhookAttempt, error) {
+	ctx, span := tracing.Start(ctx, "webhook.GetAttempts")
+	defer span.End()
+
+	delivery, err := d.persistentRepo.GetDeliveryById(ctx, req.DeliveryId)
+	if err != nil && !errors.Is(err, exception.NotFound) {
+		return nil, err
+	}
+	if err != nil || delivery.UserId != req.UserId || delivery.SubscriptionId != req.SubscriptionId {
+		return nil, fmt.Errorf("%w: %d", ErrDeliveryNotFound, req.DeliveryId)
+	}
+
+	return d.persistentRepo.GetAttemptsByDeliveryId(ctx, req.DeliveryId)
+}
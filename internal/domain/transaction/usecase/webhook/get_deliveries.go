@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type GetDeliveriesReq struct {
+	UserId         uint32
+	SubscriptionId uint32
+	Query          *repo.Query
+}
+
+// GetDeliveries is delivery log of one subscription, newest first
+type GetDeliveries[TxType any] interface {
+	Execute(ctx context.Context, req *GetDeliveriesReq) ([]*models.WebhookDelivery, error)
+}
+
+type defaultGetDeliveries[TxType any] struct {
+	persistentRepo repo.WebhookRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewGetDeliveries[TxType any](persistentRepo repo.WebhookRepo[TxType], logger *zap.Logger) GetDeliveries[TxType] {
+	return &defaultGetDeliveries[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultGetDeliveries[TxType]) Execute(ctx context.Context, req *GetDeliveriesReq) ([]*models.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "webhook.GetDeliveries")
+	defer span.End()
+
+	subscription, err := d.persistentRepo.GetSubscriptionById(ctx, req.SubscriptionId)
+	if err != nil && !errors.Is(err, exception.NotFound) {
+		return nil, err
+	}
+	if err != nil || subscription.UserId != req.UserId {
+		return nil, fmt.Errorf("%w: %d", ErrSubscriptionNotFound, req.SubscriptionId)
+	}
+
+	return d.persistentRepo.GetDeliveriesBySubscriptionId(ctx, req.SubscriptionId, req.Query)
+}
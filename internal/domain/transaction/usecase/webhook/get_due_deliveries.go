@@ -0,0 +1,38 @@
+package webhook
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"time"
+)
+
+type GetDueDeliveriesReq struct {
+	Now   time.Time
+	Limit int
+}
+
+type GetDueDeliveries[TxType any] interface {
+	Execute(ctx context.Context, req *GetDueDeliveriesReq) ([]*models.WebhookDelivery, error)
+}
+
+type defaultGetDueDeliveries[TxType any] struct {
+	persistentRepo repo.WebhookRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewGetDueDeliveries[TxType any](persistentRepo repo.WebhookRepo[TxType], logger *zap.Logger) GetDueDeliveries[TxType] {
+	return &defaultGetDueDeliveries[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultGetDueDeliveries[TxType]) Execute(ctx context.Context, req *GetDueDeliveriesReq) ([]*models.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "webhook.GetDueDeliveries")
+	defer span.End()
+
+	return d.persistentRepo.GetDueDeliveries(ctx, req.Now, req.Limit)
+}
@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+)
+
+type GetSubscriptionsReq struct {
+	UserId uint32
+}
+
+// GetSubscriptions return subscriptions of user without secret
+type GetSubscriptions[TxType any] interface {
+	Execute(ctx context.Context, req *GetSubscriptionsReq) ([]*models.WebhookSubscription, error)
+}
+
+type defaultGetSubscriptions[TxType any] struct {
+	persistentRepo repo.WebhookRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewGetSubscriptions[TxType any](persistentRepo repo.WebhookRepo[TxType], logger *zap.Logger) GetSubscriptions[TxType] {
+	return &defaultGetSubscriptions[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultGetSubscriptions[TxType]) Execute(ctx context.Context, req *GetSubscriptionsReq) ([]*models.WebhookSubscription, error) {
+	ctx, span := tracing.Start(ctx, "webhook.GetSubscriptions")
+	defer span.End()
+
+	subscriptions, err := d.persistentRepo.GetSubscriptionsByUserId(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, subscription := range subscriptions {
+		subscription.Secret = ""
+	}
+	return subscriptions, nil
+}
@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/tracing"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	webhookpkg "money_forward_code_challenge/pkgs/webhook"
+	"time"
+)
+
+type RecordAttemptReq struct {
+	Delivery *models.WebhookDelivery
+	Result   *webhookpkg.Result
+	Now      time.Time
+}
+
+// RecordAttemptUseCase log attempt and schedule retry or close delivery
+type RecordAttemptUseCase[TxType any] interface {
+	Execute(ctx context.Context, req *RecordAttemptReq, tx TxType) (*models.WebhookAttempt, error)
+}
+
+type defaultRecordAttemptUseCase[TxType any] struct {
+	persistentRepo repo.WebhookRepo[TxType]
+	logger         *zap.Logger
+}
+
+func NewRecordAttemptUseCase[TxType any](persistentRepo repo.WebhookRepo[TxType], logger *zap.Logger) RecordAttemptUseCase[TxType] {
+	return &defaultRecordAttemptUseCase[TxType]{
+		persistentRepo: persistentRepo,
+		logger:         logger,
+	}
+}
+
+func (d *defaultRecordAttemptUseCase[TxType]) Execute(ctx context.Context, req *RecordAttemptReq, tx TxType) (*models.WebhookAttempt, error) {
+	ctx, span := tracing.Start(ctx, "webhook.RecordAttempt")
+	defer span.End()
+
+	attempt := ApplyResult(req.Delivery, req.Result, req.Now)
+
+	err := d.persistentRepo.UpdateDelivery(ctx, req.Delivery, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.persistentRepo.CreateAttempt(ctx, attempt, tx)
+	if err != nil {
+		return nil, err
+	}
+	return attempt, nil
+}
@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/domain/transaction/aggregate"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	webhookpkg "money_forward_code_challenge/pkgs/webhook"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSubscription  = exception.New(exception.Validation, "INVALID_WEBHOOK_SUBSCRIPTION", "invalid webhook subscription")
+	ErrSubscriptionNotFound = exception.New(exception.NotFound, "WEBHOOK_SUBSCRIPTION_NOT_FOUND", "webhook subscription not found")
+	ErrDeliveryNotFound     = exception.New(exception.NotFound, "WEBHOOK_DELIVERY_NOT_FOUND", "webhook delivery not found")
+)
+
+var (
+	// delivery is failed after this many attempts
+	MaxAttempts = 8
+	RetryBase   = 30 * time.Second
+	RetryMax    = 6 * time.Hour
+	// worker own claimed delivery until lease end, then other worker can retry it
+	DeliveryLease = 2 * time.Minute
+)
+
+// Event is body of every delivery
+type Event struct {
+	Id        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// BalanceChange is data of balance.updated
+type BalanceChange struct {
+	AccountId       uint32 `json:"account_id"`
+	UserId          uint32 `json:"user_id"`
+	TransactionId   uint32 `json:"transaction_id"`
+	TransactionType string `json:"transaction_type"`
+	// signed, fee included
+	Change float32 `json:"change"`
+}
+
+func newEventId() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func NewTransactionEvent(eventType string, transactionDetail *aggregate.TransactionByDetails, now time.Time) *Event {
+	return &Event{
+		Id:        newEventId(),
+		Type:      eventType,
+		CreatedAt: now,
+		Data:      transactionDetail,
+	}
+}
+
+func NewBalanceEvent(change *BalanceChange, now time.Time) *Event {
+	return &Event{
+		Id:        newEventId(),
+		Type:      models.WEBHOOKEVENTBALANCEUPDATED,
+		CreatedAt: now,
+		Data:      change,
+	}
+}
+
+// ParseEventTypes validate and join event types of subscription
+func ParseEventTypes(eventTypes []string) (string, error) {
+	if len(eventTypes) == 0 {
+		return "", fmt.Errorf("%w: event_types is required", ErrInvalidSubscription)
+	}
+
+	var cleaned []string
+	for _, eventType := range eventTypes {
+		eventType = strings.TrimSpace(eventType)
+		if !slices.Contains(models.WEBHOOKEVENTEXPECTS, eventType) {
+			return "", fmt.Errorf("%w: event type expects one of %v, !got: [%s]", ErrInvalidSubscription, models.WEBHOOKEVENTEXPECTS, eventType)
+		}
+		if !slices.Contains(cleaned, eventType) {
+			cleaned = append(cleaned, eventType)
+		}
+	}
+	return strings.Join(cleaned, ","), nil
+}
+
+// ValidateURL accept absolute http or https url whose host is not a local name or not public ip
+// it is early feedback only, sender check resolved address again on every dial
+func ValidateURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return fmt.Errorf("%w: url must be absolute http(s) url, !got: [%s]", ErrInvalidSubscription, rawURL)
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(parsed.Hostname(), "."))
+	ip := net.ParseIP(host)
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || (ip != nil && !webhookpkg.PublicIP(ip)) {
+		return fmt.Errorf("%w: url must be public address, !got: [%s]", ErrInvalidSubscription, rawURL)
+	}
+	return nil
+}
+
+// Subscribed is true when subscription want event type
+func Subscribed(subscription *models.WebhookSubscription, eventType string) bool {
+	return slices.Contains(strings.Split(subscription.EventTypes, ","), eventType)
+}
+
+// ApplyResult move delivery after one attempt
+// delivered, or retried with exponential backoff, or failed when attempts are exhausted
+func ApplyResult(delivery *models.WebhookDelivery, result *webhookpkg.Result, now time.Time) *models.WebhookAttempt {
+	delivery.Attempts++
+	delivery.LastStatusCode = result.StatusCode
+	delivery.LastError = ""
+	if result.Err != nil {
+		delivery.LastError = truncate(result.Err.Error(), 500)
+	}
+
+	switch {
+	case result.Delivered():
+		delivery.Status = models.WEBHOOKDELIVERYSTATUSDELIVERED
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= MaxAttempts:
+		delivery.Status = models.WEBHOOKDELIVERYSTATUSFAILED
+	default:
+		delivery.Status = models.WEBHOOKDELIVERYSTATUSPENDING
+		delivery.NextAttemptAt = now.Add(webhookpkg.Backoff(delivery.Attempts, RetryBase, RetryMax))
+	}
+
+	return &models.WebhookAttempt{
+		DeliveryId: delivery.ID,
+		Attempt:    delivery.Attempts,
+		StatusCode: result.StatusCode,
+		Error:      delivery.LastError,
+		DurationMs: result.Duration.Milliseconds(),
+	}
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
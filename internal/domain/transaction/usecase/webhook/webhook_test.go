@@ -0,0 +1,285 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/domain/transaction/aggregate"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	webhookpkg "money_forward_code_challenge/pkgs/webhook"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// memoryWebhookRepo keep rows in memory, tx is unused
+type memoryWebhookRepo struct {
+	subscriptions []*models.WebhookSubscription
+	deliveries    []*models.WebhookDelivery
+	attempts      []*models.WebhookAttempt
+}
+
+func (m *memoryWebhookRepo) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription, tx any) error {
+	subscription.ID = uint32(len(m.subscriptions) + 1)
+	m.subscriptions = append(m.subscriptions, subscription)
+	return nil
+}
+
+func (m *memoryWebhookRepo) DeleteSubscription(ctx context.Context, user_id uint32, subscription_id uint32, tx any) (bool, error) {
+	for i, subscription := range m.subscriptions {
+		if subscription.ID == subscription_id && subscription.UserId == user_id {
+			m.subscriptions = append(m.subscriptions[:i], m.subscriptions[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memoryWebhookRepo) GetSubscriptionById(ctx context.Context, subscription_id uint32) (*models.WebhookSubscription, error) {
+	for _, subscription := range m.subscriptions {
+		if subscription.ID == subscription_id {
+			copied := *subscription
+			return &copied, nil
+		}
+	}
+	return nil, exception.New(exception.NotFound, "", "not found")
+}
+
+func (m *memoryWebhookRepo) GetSubscriptionsByUserId(ctx context.Context, user_id uint32) ([]*models.WebhookSubscription, error) {
+	var subscriptions []*models.WebhookSubscription
+	for _, subscription := range m.subscriptions {
+		if subscription.UserId == user_id {
+			copied := *subscription
+			subscriptions = append(subscriptions, &copied)
+		}
+	}
+	return subscriptions, nil
+}
+
+func (m *memoryWebhookRepo) CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery, tx any) error {
+	for _, delivery := range deliveries {
+		delivery.ID = uint32(len(m.deliveries) + 1)
+		m.deliveries = append(m.deliveries, delivery)
+	}
+	return nil
+}
+
+func (m *memoryWebhookRepo) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	for _, delivery := range m.deliveries {
+		if delivery.Status == models.WEBHOOKDELIVERYSTATUSPENDING && !delivery.NextAttemptAt.After(now) {
+			copied := *delivery
+			deliveries = append(deliveries, &copied)
+		}
+	}
+	return deliveries, nil
+}
+
+func (m *memoryWebhookRepo) ClaimDelivery(ctx context.Context, delivery *models.WebhookDelivery, lease_until time.Time, tx any) (bool, error) {
+	stored := m.deliveries[delivery.ID-1]
+	if stored.Status != models.WEBHOOKDELIVERYSTATUSPENDING || !stored.NextAttemptAt.Equal(delivery.NextAttemptAt) {
+		return false, nil
+	}
+	stored.NextAttemptAt = lease_until
+	delivery.NextAttemptAt = lease_until
+	return true, nil
+}
+
+func (m *memoryWebhookRepo) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery, tx any) error {
+	copied := *delivery
+	m.deliveries[delivery.ID-1] = &copied
+	return nil
+}
+
+func (m *memoryWebhookRepo) CreateAttempt(ctx context.Context, attempt *models.WebhookAttempt, tx any) error {
+	attempt.ID = uint32(len(m.attempts) + 1)
+	m.attempts = append(m.attempts, attempt)
+	return nil
+}
+
+func (m *memoryWebhookRepo) GetDeliveriesBySubscriptionId(ctx context.Context, subscription_id uint32, query *repo.Query) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	for _, delivery := range m.deliveries {
+		if delivery.SubscriptionId == subscription_id {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+func (m *memoryWebhookRepo) GetDeliveryById(ctx context.Context, delivery_id uint32) (*models.WebhookDelivery, error) {
+	if int(delivery_id) > len(m.deliveries) || delivery_id == 0 {
+		return nil, exception.New(exception.NotFound, "", "not found")
+	}
+	return m.deliveries[delivery_id-1], nil
+}
+
+func (m *memoryWebhookRepo) GetAttemptsByDeliveryId(ctx context.Context, delivery_id uint32) ([]*models.WebhookAttempt, error) {
+	var attempts []*models.WebhookAttempt
+	for _, attempt := range m.attempts {
+		if attempt.DeliveryId == delivery_id {
+			attempts = append(attempts, attempt)
+		}
+	}
+	return attempts, nil
+}
+
+func (m *memoryWebhookRepo) BeginTx() any {
+	return nil
+}
+
+func TestParseEventTypes(t *testing.T) {
+	eventTypes, err := ParseEventTypes([]string{"transaction.created", " balance.updated", "transaction.created"})
+	if err != nil || eventTypes != "transaction.created,balance.updated" {
+		t.Fatalf("expects deduplicated event types, got %q %v", eventTypes, err)
+	}
+
+	for _, invalid := range [][]string{nil, {"transaction.updated"}} {
+		if _, err := ParseEventTypes(invalid); !errors.Is(err, ErrInvalidSubscription) {
+			t.Errorf("expects ErrInvalidSubscription on %v, got %v", invalid, err)
+		}
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	if err := ValidateURL("https://partner.example.com/hooks"); err != nil {
+		t.Errorf("expects valid url, got %v", err)
+	}
+	for _, invalid := range []string{"", "ftp://partner.example.com", "/hooks", "http://",
+		"http://127.0.0.1:8080/api/admin/accounts/1/overdraft/approve", "http://localhost/hooks", "http://169.254.169.254/latest", "http://10.0.0.5/hooks", "http://[::1]/hooks"} {
+		if err := ValidateURL(invalid); !errors.Is(err, ErrInvalidSubscription) {
+			t.Errorf("expects ErrInvalidSubscription on %q, got %v", invalid, err)
+		}
+	}
+}
+
+func TestApplyResultRetryThenFail(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	delivery := &models.WebhookDelivery{ID: 1, Status: models.WEBHOOKDELIVERYSTATUSPENDING}
+	failure := &webhookpkg.Result{StatusCode: http.StatusBadGateway, Err: errors.New("receiver answered 502")}
+
+	attempt := ApplyResult(delivery, failure, now)
+	if delivery.Status != models.WEBHOOKDELIVERYSTATUSPENDING || !delivery.NextAttemptAt.Equal(now.Add(RetryBase)) {
+		t.Fatalf("expects retry after %v, got %+v", RetryBase, delivery)
+	}
+	if attempt.Attempt != 1 || attempt.StatusCode != http.StatusBadGateway || attempt.Error == "" {
+		t.Errorf("expects attempt log of first failure, got %+v", attempt)
+	}
+
+	ApplyResult(delivery, failure, now)
+	if !delivery.NextAttemptAt.Equal(now.Add(2 * RetryBase)) {
+		t.Errorf("expects exponential backoff, got %v", delivery.NextAttemptAt.Sub(now))
+	}
+
+	for delivery.Attempts < MaxAttempts {
+		ApplyResult(delivery, failure, now)
+	}
+	if delivery.Status != models.WEBHOOKDELIVERYSTATUSFAILED {
+		t.Errorf("expects failed after %d attempts, got %s", MaxAttempts, delivery.Status)
+	}
+}
+
+func TestDeliveryFlowWithReceiver(t *testing.T) {
+	ctx := context.Background()
+	logger := zap.NewNop()
+	memoryRepo := &memoryWebhookRepo{}
+	now := time.Now()
+
+	failFirst := true
+	var verified []string
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failFirst {
+			failFirst = false
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		payload, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhookpkg.HeaderTimestamp), 10, 64)
+		if err := webhookpkg.Verify("0123456789abcdef", r.Header.Get(webhookpkg.HeaderSignature), timestamp, payload, time.Now(), time.Minute); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		verified = append(verified, r.Header.Get(webhookpkg.HeaderEventType))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	_, err := NewCreateSubscriptionUseCase[any](memoryRepo, logger).Execute(ctx, &CreateSubscriptionReq{
+		UserId:     1,
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []string{models.WEBHOOKEVENTTRANSACTIONCREATED},
+		Secret:     "0123456789abcdef",
+	}, nil)
+	if err != nil {
+		t.Fatalf("create subscription: %v", err)
+	}
+	// loopback url is rejected on subscribe, receiver of test listen on loopback
+	memoryRepo.subscriptions[0].URL = receiver.URL
+
+	enqueued, err := NewEnqueueEventsUseCase[any](memoryRepo, logger).Execute(ctx, &EnqueueEventsReq{
+		UserId: 1,
+		Events: []*Event{
+			NewTransactionEvent(models.WEBHOOKEVENTTRANSACTIONCREATED, &aggregate.TransactionByDetails{Id: 10, UserId: 1}, now),
+			// not subscribed
+			NewBalanceEvent(&BalanceChange{AccountId: 1, UserId: 1, TransactionId: 10, Change: -100}, now),
+		},
+		Now: now,
+	}, nil)
+	if err != nil || enqueued != 1 {
+		t.Fatalf("expects 1 delivery, got %d %v", enqueued, err)
+	}
+
+	sender := webhookpkg.NewSenderAllowIP(time.Second, func(ip net.IP) bool { return ip.IsLoopback() })
+	deliverDue := func(at time.Time) int {
+		due, _ := NewGetDueDeliveries[any](memoryRepo, logger).Execute(ctx, &GetDueDeliveriesReq{Now: at, Limit: 10})
+		for _, delivery := range due {
+			subscription, err := NewClaimDeliveryUseCase[any](memoryRepo, logger).Execute(ctx, &ClaimDeliveryReq{Delivery: delivery, Now: at}, nil)
+			if err != nil {
+				t.Fatalf("claim: %v", err)
+			}
+			result := sender.Send(ctx, &webhookpkg.Request{
+				URL:        subscription.URL,
+				Secret:     subscription.Secret,
+				EventType:  delivery.EventType,
+				DeliveryId: delivery.ID,
+				Body:       []byte(delivery.Payload),
+				Now:        time.Now(),
+			})
+			_, _ = NewRecordAttemptUseCase[any](memoryRepo, logger).Execute(ctx, &RecordAttemptReq{Delivery: delivery, Result: result, Now: at}, nil)
+		}
+		return len(due)
+	}
+
+	if deliverDue(now) != 1 {
+		t.Fatal("expects first attempt")
+	}
+	if deliverDue(now.Add(time.Second)) != 0 {
+		t.Fatal("expects no attempt before backoff")
+	}
+	if deliverDue(now.Add(RetryBase)) != 1 {
+		t.Fatal("expects retry after backoff")
+	}
+
+	if len(verified) != 1 || verified[0] != models.WEBHOOKEVENTTRANSACTIONCREATED {
+		t.Fatalf("expects one verified delivery, got %v", verified)
+	}
+
+	attempts, _ := NewGetAttempts[any](memoryRepo, logger).Execute(ctx, &GetAttemptsReq{UserId: 1, SubscriptionId: 1, DeliveryId: 1})
+	if len(attempts) != 2 || attempts[0].StatusCode != http.StatusServiceUnavailable || attempts[1].StatusCode != http.StatusOK {
+		t.Fatalf("expects delivery log [503, 200], got %d attempts", len(attempts))
+	}
+	if memoryRepo.deliveries[0].Status != models.WEBHOOKDELIVERYSTATUSDELIVERED || memoryRepo.deliveries[0].DeliveredAt == nil {
+		t.Errorf("expects delivered, got %+v", memoryRepo.deliveries[0])
+	}
+
+	if _, err := NewGetAttempts[any](memoryRepo, logger).Execute(ctx, &GetAttemptsReq{UserId: 2, SubscriptionId: 1, DeliveryId: 1}); !errors.Is(err, ErrDeliveryNotFound) {
+		t.Errorf("expects other user not see delivery log, got %v", err)
+	}
+}
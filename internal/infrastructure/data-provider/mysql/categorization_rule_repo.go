@@ -0,0 +1,73 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/logging"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+
+	"gorm.io/gorm"
+)
+
+type mysqlCategorizationRuleRepoImpl struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewMysqlCategorizationRuleRepo(db *gorm.DB, logger *zap.Logger) repo.CategorizationRuleRepo[*gorm.DB] {
+	return &mysqlCategorizationRuleRepoImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (m *mysqlCategorizationRuleRepoImpl) Create(ctx context.Context, rule *models.CategorizationRule, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).Create(rule).Error
+}
+
+func (m *mysqlCategorizationRuleRepoImpl) Delete(ctx context.Context, rule_id uint32, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).
+		Where(fmt.Sprintf("%s = ?", models.CATEGORIZATIONRULECOLUMN_ID), rule_id).
+		Delete(&models.CategorizationRule{}).Error
+}
+
+func (m *mysqlCategorizationRuleRepoImpl) GetById(ctx context.Context, rule_id uint32) (*models.CategorizationRule, error) {
+	var rule models.CategorizationRule
+	err := m.db.WithContext(ctx).
+		Table(models.CATEGORIZATIONRULETABLE).
+		Where(fmt.Sprintf("%s = ?", models.CATEGORIZATIONRULECOLUMN_ID), rule_id).
+		Find(&rule).Error
+	if err != nil {
+		logging.FromContext(ctx, m.logger).Info("[MYSQLCategorizationRuleRepo-GET-RULE]", zap.String("Error", err.Error()))
+		return nil, err
+	}
+
+	if rule.ID == 0 {
+		return nil, notFound("RULE_NOT_FOUND", "rule %d not found", rule_id)
+	}
+	return &rule, nil
+}
+
+func (m *mysqlCategorizationRuleRepoImpl) GetByUserId(ctx context.Context, user_id uint32) ([]*models.CategorizationRule, error) {
+	var rules []*models.CategorizationRule
+	err := m.db.WithContext(ctx).
+		Table(models.CATEGORIZATIONRULETABLE).
+		Where(fmt.Sprintf("%s = ?", models.CATEGORIZATIONRULECOLUMN_USER_ID), user_id).
+		Order(models.CATEGORIZATIONRULECOLUMN_PRIORITY + " ASC").
+		Order(models.CATEGORIZATIONRULECOLUMN_ID + " ASC").
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
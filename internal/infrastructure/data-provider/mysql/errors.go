@@ -0,0 +1,91 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"money_forward_code_challenge/internal/common/exception"
+)
+
+// mysql server error numbers, https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+const (
+	errNumberDuplicateEntry    = 1062
+	errNumberRowIsReferenced   = 1451
+	errNumberNoReferencedRow   = 1452
+	errNumberLockWaitTimeout   = 1205
+	errNumberDeadlock          = 1213
+	errNumberTooManyConnection = 1040
+)
+
+// TranslateError wrap gorm and driver errors into exception kinds, cause is kept for errors.Is
+// errors which are already domain errors or unknown are returned as is
+func TranslateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var domainErr *exception.DomainError
+	if errors.As(err, &domainErr) {
+		return err
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return exception.Wrap(exception.NotFound, "RECORD_NOT_FOUND", err)
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return exception.Wrap(exception.Conflict, "DUPLICATE_KEY", err)
+	}
+
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case errNumberDuplicateEntry:
+			return exception.Wrap(exception.Conflict, "DUPLICATE_KEY", err)
+		case errNumberRowIsReferenced, errNumberNoReferencedRow:
+			return exception.Wrap(exception.Conflict, "FOREIGN_KEY", err)
+		case errNumberLockWaitTimeout, errNumberDeadlock, errNumberTooManyConnection:
+			return exception.Wrap(exception.Unavailable, "DATABASE_BUSY", err)
+		}
+		return err
+	}
+
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysqldriver.ErrInvalidConn) || errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) {
+		return exception.Wrap(exception.Unavailable, "DATABASE_UNAVAILABLE", err)
+	}
+	return err
+}
+
+// notFound is NotFound error of repo lookups made with Find, cause is gorm.ErrRecordNotFound
+func notFound(code string, format string, args ...any) error {
+	err := exception.Newf(exception.NotFound, code, format, args...)
+	err.Err = gorm.ErrRecordNotFound
+	return err
+}
+
+// ErrorPlugin translate error of every statement with TranslateError, register with db.Use
+type ErrorPlugin struct{}
+
+func (ErrorPlugin) Name() string {
+	return "exception"
+}
+
+func (ErrorPlugin) Initialize(db *gorm.DB) error {
+	translate := func(db *gorm.DB) {
+		db.Error = TranslateError(db.Error)
+	}
+
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().After("*").Register("exception:translate_create", translate),
+		callback.Query().After("*").Register("exception:translate_query", translate),
+		callback.Update().After("*").Register("exception:translate_update", translate),
+		callback.Delete().After("*").Register("exception:translate_delete", translate),
+		callback.Row().After("*").Register("exception:translate_row", translate),
+		callback.Raw().After("*").Register("exception:translate_raw", translate),
+	)
+}
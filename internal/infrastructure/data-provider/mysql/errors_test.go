@@ -0,0 +1,47 @@
+package mysql
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"money_forward_code_challenge/internal/common/exception"
+)
+
+func TestTranslateError(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		kind exception.Kind
+		code string
+	}{
+		{name: "record not found", err: gorm.ErrRecordNotFound, kind: exception.NotFound, code: "RECORD_NOT_FOUND"},
+		{name: "duplicate entry", err: &mysqldriver.MySQLError{Number: 1062, Message: "Duplicate entry"}, kind: exception.Conflict, code: "DUPLICATE_KEY"},
+		{name: "deadlock", err: &mysqldriver.MySQLError{Number: 1213, Message: "Deadlock found"}, kind: exception.Unavailable, code: "DATABASE_BUSY"},
+		{name: "bad connection", err: fmt.Errorf("query: %w", driver.ErrBadConn), kind: exception.Unavailable, code: "DATABASE_UNAVAILABLE"},
+		{name: "other mysql error", err: &mysqldriver.MySQLError{Number: 1064, Message: "syntax"}, kind: exception.Internal, code: "INTERNAL"},
+		{name: "domain error is kept", err: notFound("ACCOUNT_NOT_FOUND", "account %d not found", 1), kind: exception.NotFound, code: "ACCOUNT_NOT_FOUND"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := TranslateError(tc.err)
+			if exception.KindOf(err) != tc.kind || exception.CodeOf(err) != tc.code {
+				t.Errorf("got %s %s want %s %s", exception.KindOf(err), exception.CodeOf(err), tc.kind, tc.code)
+			}
+			if !errors.Is(err, tc.err) {
+				t.Error("cause must be kept")
+			}
+		})
+	}
+
+	if !errors.Is(notFound("USER_NOT_FOUND", "user %d not found", 1), gorm.ErrRecordNotFound) {
+		t.Error("not found of repo must match gorm.ErrRecordNotFound")
+	}
+	if TranslateError(nil) != nil {
+		t.Error("nil must stay nil")
+	}
+}
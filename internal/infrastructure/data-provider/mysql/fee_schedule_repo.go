@@ -0,0 +1,108 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type mysqlFeeScheduleRepoImpl struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewMysqlFeeScheduleRepo(db *gorm.DB, logger *zap.Logger) repo.FeeScheduleRepo[*gorm.DB] {
+	return &mysqlFeeScheduleRepoImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (m *mysqlFeeScheduleRepoImpl) Create(ctx context.Context, schedule *models.FeeSchedule, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).Create(schedule).Error
+}
+
+func (m *mysqlFeeScheduleRepoImpl) Deactivate(ctx context.Context, schedule_id uint32, tx *gorm.DB) (bool, error) {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	result := defaultTx.WithContext(ctx).
+		Table(models.FEESCHEDULETABLE).
+		Where(fmt.Sprintf("%s = ? AND %s = ?", models.FEESCHEDULECOLUMN_ID, models.FEESCHEDULECOLUMN_ACTIVE), schedule_id, true).
+		Update(models.FEESCHEDULECOLUMN_ACTIVE, false)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (m *mysqlFeeScheduleRepoImpl) GetAll(ctx context.Context, query *repo.Query) ([]*models.FeeSchedule, error) {
+	var schedules []*models.FeeSchedule
+	builder := m.db.WithContext(ctx).
+		Table(models.FEESCHEDULETABLE).
+		Order(models.FEESCHEDULECOLUMN_ID + " ASC")
+	if query.Limit != 0 {
+		builder.Limit(query.Limit).Offset(query.Offset)
+	}
+
+	err := builder.Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (m *mysqlFeeScheduleRepoImpl) GetActiveByTransactionType(ctx context.Context, transaction_type string) ([]*models.FeeSchedule, error) {
+	var schedules []*models.FeeSchedule
+	err := m.db.WithContext(ctx).
+		Table(models.FEESCHEDULETABLE).
+		Where(fmt.Sprintf("%s = ? AND %s = ?", models.FEESCHEDULECOLUMN_TRANSACTION_TYPE, models.FEESCHEDULECOLUMN_ACTIVE), transaction_type, true).
+		Order(models.FEESCHEDULECOLUMN_ID + " ASC").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (m *mysqlFeeScheduleRepoImpl) CountTransactionsSince(ctx context.Context, account_id uint32, transaction_type string, since time.Time, tx *gorm.DB) (int, error) {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+		var id uint32
+		err := defaultTx.WithContext(ctx).
+			Table(models.ACCOUNTTABLE).
+			Select(models.ACCOUNTCOLUMN_ID).
+			Where(fmt.Sprintf("%s = ?", models.ACCOUNTCOLUMN_ID), account_id).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scan(&id).Error
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// reversed transactions still count, reversal does not give free quota back
+	var count int64
+	err := defaultTx.WithContext(ctx).
+		Table(models.TRANSACTIONTABLE).
+		Where(fmt.Sprintf("%s = ? AND %s = ? AND %s >= ?",
+			models.TRANSACTIONCOLUMN_ACCOUNT_ID,
+			models.TRANSACTIONCOLUMN_TRANSACTION_TYPE,
+			models.TRANSACTIONCOLUMN_CREATED_AT), account_id, transaction_type, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
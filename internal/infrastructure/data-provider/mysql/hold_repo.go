@@ -0,0 +1,106 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/logging"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type mysqlHoldRepoImpl struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewMysqlHoldRepo(db *gorm.DB, logger *zap.Logger) repo.HoldRepo[*gorm.DB] {
+	return &mysqlHoldRepoImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (m *mysqlHoldRepoImpl) Create(ctx context.Context, hold *models.Hold, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).Create(hold).Error
+}
+
+func (m *mysqlHoldRepoImpl) Transition(ctx context.Context, hold *models.Hold, from_status string, tx *gorm.DB) (bool, error) {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+
+	result := defaultTx.WithContext(ctx).
+		Table(models.HOLDTABLE).
+		Where(fmt.Sprintf("%s = ? AND %s = ?", models.HOLDCOLUMN_ID, models.HOLDCOLUMN_STATUS), hold.ID, from_status).
+		Updates(map[string]interface{}{
+			models.HOLDCOLUMN_STATUS:          hold.Status,
+			models.HOLDCOLUMN_CAPTURED_AMOUNT: hold.CapturedAmount,
+			models.HOLDCOLUMN_TRANSACTION_ID:  hold.TransactionId,
+			models.HOLDCOLUMN_CLOSED_AT:       hold.ClosedAt,
+		})
+	if result.Error != nil {
+		logging.FromContext(ctx, m.logger).Info("[MYSQLHoldRepo-TRANSITION]", zap.String("Error", result.Error.Error()))
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (m *mysqlHoldRepoImpl) GetById(ctx context.Context, hold_id uint32) (*models.Hold, error) {
+	var hold models.Hold
+	err := m.db.WithContext(ctx).
+		Table(models.HOLDTABLE).
+		Where(fmt.Sprintf("%s = ?", models.HOLDCOLUMN_ID), hold_id).
+		Find(&hold).Error
+	if err != nil {
+		logging.FromContext(ctx, m.logger).Info("[MYSQLHoldRepo-GET-HOLD]", zap.String("Error", err.Error()))
+		return nil, err
+	}
+
+	if hold.ID == 0 {
+		return nil, notFound("HOLD_NOT_FOUND", "hold %d not found", hold_id)
+	}
+	return &hold, nil
+}
+
+func (m *mysqlHoldRepoImpl) GetByUserId(ctx context.Context, user_id uint32, query *repo.Query) ([]*models.Hold, error) {
+	var holds []*models.Hold
+	err := m.db.WithContext(ctx).
+		Table(models.HOLDTABLE).
+		Where(fmt.Sprintf("%s = ?", models.HOLDCOLUMN_USER_ID), user_id).
+		Order(models.HOLDCOLUMN_ID + " DESC").
+		Limit(query.Limit).
+		Offset(query.Offset).
+		Find(&holds).Error
+	if err != nil {
+		return nil, err
+	}
+	return holds, nil
+}
+
+func (m *mysqlHoldRepoImpl) GetExpired(ctx context.Context, now time.Time, limit int) ([]*models.Hold, error) {
+	var holds []*models.Hold
+	err := m.db.WithContext(ctx).
+		Table(models.HOLDTABLE).
+		Where(fmt.Sprintf("%s = ? AND %s <= ?", models.HOLDCOLUMN_STATUS, models.HOLDCOLUMN_EXPIRES_AT), models.HOLDSTATUSAUTHORIZED, now).
+		Order(models.HOLDCOLUMN_EXPIRES_AT + " ASC").
+		Limit(limit).
+		Find(&holds).Error
+	if err != nil {
+		return nil, err
+	}
+	return holds, nil
+}
+
+func (m *mysqlHoldRepoImpl) BeginTx() *gorm.DB {
+	return m.db.Begin()
+}
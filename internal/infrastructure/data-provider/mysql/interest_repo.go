@@ -0,0 +1,268 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/logging"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type mysqlInterestRepoImpl struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewMysqlInterestRepo(db *gorm.DB, logger *zap.Logger) repo.InterestRepo[*gorm.DB] {
+	return &mysqlInterestRepoImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (m *mysqlInterestRepoImpl) CreateProduct(ctx context.Context, product *models.InterestProduct, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).Create(product).Error
+}
+
+func (m *mysqlInterestRepoImpl) GetProductById(ctx context.Context, product_id uint32) (*models.InterestProduct, error) {
+	var product models.InterestProduct
+	err := m.db.WithContext(ctx).
+		Table(models.INTERESTPRODUCTTABLE).
+		Where(fmt.Sprintf("%s = ?", models.INTERESTPRODUCTCOLUMN_ID), product_id).
+		Find(&product).Error
+	if err != nil {
+		logging.FromContext(ctx, m.logger).Info("[MYSQLInterestRepo-GET-PRODUCT]", zap.String("Error", err.Error()))
+		return nil, err
+	}
+
+	if product.ID == 0 {
+		return nil, notFound("INTEREST_PRODUCT_NOT_FOUND", "interest product %d not found", product_id)
+	}
+	return &product, nil
+}
+
+func (m *mysqlInterestRepoImpl) GetProducts(ctx context.Context, query *repo.Query) ([]*models.InterestProduct, error) {
+	var products []*models.InterestProduct
+	err := m.db.WithContext(ctx).
+		Table(models.INTERESTPRODUCTTABLE).
+		Order(models.INTERESTPRODUCTCOLUMN_ID + " ASC").
+		Limit(query.Limit).
+		Offset(query.Offset).
+		Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (m *mysqlInterestRepoImpl) SaveAccountInterest(ctx context.Context, accountInterest *models.AccountInterest, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).Save(accountInterest).Error
+}
+
+func (m *mysqlInterestRepoImpl) UpdateProduct(ctx context.Context, account_id uint32, product_id uint32, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).
+		Table(models.ACCOUNTINTERESTTABLE).
+		Where(fmt.Sprintf("%s = ?", models.ACCOUNTINTERESTCOLUMN_ACCOUNT_ID), account_id).
+		Updates(map[string]interface{}{
+			models.ACCOUNTINTERESTCOLUMN_PRODUCT_ID: product_id,
+			models.ACCOUNTINTERESTCOLUMN_UPDATED_AT: time.Now(),
+		}).Error
+}
+
+func (m *mysqlInterestRepoImpl) AddAccrued(ctx context.Context, account_id uint32, last_accrued_date string, micro int64, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).
+		Table(models.ACCOUNTINTERESTTABLE).
+		Where(fmt.Sprintf("%s = ?", models.ACCOUNTINTERESTCOLUMN_ACCOUNT_ID), account_id).
+		Updates(map[string]interface{}{
+			models.ACCOUNTINTERESTCOLUMN_LAST_ACCRUED_DATE: last_accrued_date,
+			models.ACCOUNTINTERESTCOLUMN_ACCRUED_MICRO:     gorm.Expr(fmt.Sprintf("%s + ?", models.ACCOUNTINTERESTCOLUMN_ACCRUED_MICRO), micro),
+			models.ACCOUNTINTERESTCOLUMN_UPDATED_AT:        time.Now(),
+		}).Error
+}
+
+func (m *mysqlInterestRepoImpl) SubtractPosted(ctx context.Context, account_id uint32, micro int64, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).
+		Table(models.ACCOUNTINTERESTTABLE).
+		Where(fmt.Sprintf("%s = ?", models.ACCOUNTINTERESTCOLUMN_ACCOUNT_ID), account_id).
+		Updates(map[string]interface{}{
+			models.ACCOUNTINTERESTCOLUMN_ACCRUED_MICRO: gorm.Expr(fmt.Sprintf("%s - ?", models.ACCOUNTINTERESTCOLUMN_ACCRUED_MICRO), micro),
+			models.ACCOUNTINTERESTCOLUMN_UPDATED_AT:    time.Now(),
+		}).Error
+}
+
+func (m *mysqlInterestRepoImpl) GetAccountInterest(ctx context.Context, account_id uint32) (*models.AccountInterest, error) {
+	var accountInterest models.AccountInterest
+	err := m.db.WithContext(ctx).
+		Table(models.ACCOUNTINTERESTTABLE).
+		Where(fmt.Sprintf("%s = ?", models.ACCOUNTINTERESTCOLUMN_ACCOUNT_ID), account_id).
+		Find(&accountInterest).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if accountInterest.AccountId == 0 {
+		return nil, notFound("ACCOUNT_INTEREST_NOT_FOUND", "account %d has no interest product", account_id)
+	}
+	return &accountInterest, nil
+}
+
+func (m *mysqlInterestRepoImpl) GetAccountInterests(ctx context.Context, after_account_id uint32, limit int) ([]*models.AccountInterest, error) {
+	var accountInterests []*models.AccountInterest
+	err := m.db.WithContext(ctx).
+		Table(models.ACCOUNTINTERESTTABLE).
+		Where(fmt.Sprintf("%s > ?", models.ACCOUNTINTERESTCOLUMN_ACCOUNT_ID), after_account_id).
+		Order(models.ACCOUNTINTERESTCOLUMN_ACCOUNT_ID + " ASC").
+		Limit(limit).
+		Find(&accountInterests).Error
+	if err != nil {
+		return nil, err
+	}
+	return accountInterests, nil
+}
+
+func (m *mysqlInterestRepoImpl) CreateAccrual(ctx context.Context, accrual *models.InterestAccrual, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).Create(accrual).Error
+}
+
+func (m *mysqlInterestRepoImpl) GetUnpostedAccruals(ctx context.Context, account_id uint32, before_date string) ([]*models.InterestAccrual, error) {
+	var accruals []*models.InterestAccrual
+	err := m.db.WithContext(ctx).
+		Table(models.INTERESTACCRUALTABLE).
+		Where(fmt.Sprintf("%s = ? AND %s = 0 AND %s < ?",
+			models.INTERESTACCRUALCOLUMN_ACCOUNT_ID, models.INTERESTACCRUALCOLUMN_POSTING_ID, models.INTERESTACCRUALCOLUMN_ACCRUAL_DATE),
+			account_id, before_date).
+		Order(models.INTERESTACCRUALCOLUMN_ACCRUAL_DATE + " ASC").
+		Find(&accruals).Error
+	if err != nil {
+		return nil, err
+	}
+	return accruals, nil
+}
+
+func (m *mysqlInterestRepoImpl) GetAccrualsByAccountId(ctx context.Context, account_id uint32, query *repo.Query) ([]*models.InterestAccrual, error) {
+	var accruals []*models.InterestAccrual
+	err := m.db.WithContext(ctx).
+		Table(models.INTERESTACCRUALTABLE).
+		Where(fmt.Sprintf("%s = ?", models.INTERESTACCRUALCOLUMN_ACCOUNT_ID), account_id).
+		Order(models.INTERESTACCRUALCOLUMN_ACCRUAL_DATE + " DESC").
+		Limit(query.Limit).
+		Offset(query.Offset).
+		Find(&accruals).Error
+	if err != nil {
+		return nil, err
+	}
+	return accruals, nil
+}
+
+func (m *mysqlInterestRepoImpl) GetBalanceForUpdate(ctx context.Context, account_id uint32, tx *gorm.DB) (float32, error) {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	var account models.Account
+	err := defaultTx.WithContext(ctx).
+		Table(models.ACCOUNTTABLE).
+		Select(models.ACCOUNTCOLUMN_ID, models.ACCOUNTCOLUMN_BALANCE).
+		Where(fmt.Sprintf("%s = ?", models.ACCOUNTCOLUMN_ID), account_id).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Find(&account).Error
+	if err != nil {
+		return 0, err
+	}
+
+	if account.ID == 0 {
+		return 0, notFound("ACCOUNT_NOT_FOUND", "account %d not found", account_id)
+	}
+	return account.Balance, nil
+}
+
+func (m *mysqlInterestRepoImpl) SumBalanceChangesSince(ctx context.Context, account_id uint32, since time.Time, tx *gorm.DB) (float64, error) {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	// reversal change balance when it is made, so transaction reversed since still count before since
+	var sum float64
+	err := defaultTx.WithContext(ctx).
+		Table(models.TRANSACTIONTABLE).
+		Select(fmt.Sprintf("COALESCE(SUM(CASE WHEN %s IN ? THEN -%s ELSE %s END * CASE WHEN %s = ? AND %s >= ? THEN 1 WHEN %s = ? AND %s < ? AND %s >= ? THEN -1 ELSE 0 END), 0)",
+			models.TRANSACTIONCOLUMN_TRANSACTION_TYPE, models.TRANSACTIONCOLUMN_AMOUNT, models.TRANSACTIONCOLUMN_AMOUNT,
+			models.TRANSACTIONCOLUMN_DELETED, models.TRANSACTIONCOLUMN_CREATED_AT,
+			models.TRANSACTIONCOLUMN_DELETED, models.TRANSACTIONCOLUMN_CREATED_AT, models.TRANSACTIONCOLUMN_REVERSED_AT),
+			models.TRANSACTIONDEBITTYPES, false, since, true, since, since).
+		Where(fmt.Sprintf("%s = ? AND (%s >= ? OR %s >= ?)",
+			models.TRANSACTIONCOLUMN_ACCOUNT_ID, models.TRANSACTIONCOLUMN_CREATED_AT, models.TRANSACTIONCOLUMN_REVERSED_AT),
+			account_id, since, since).
+		Scan(&sum).Error
+	if err != nil {
+		return 0, err
+	}
+	return sum, nil
+}
+
+func (m *mysqlInterestRepoImpl) CreatePosting(ctx context.Context, posting *models.InterestPosting, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).Create(posting).Error
+}
+
+func (m *mysqlInterestRepoImpl) MarkAccrualsPosted(ctx context.Context, accrual_ids []uint32, posting_id uint32, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).
+		Table(models.INTERESTACCRUALTABLE).
+		Where(fmt.Sprintf("%s IN ?", models.INTERESTACCRUALCOLUMN_ID), accrual_ids).
+		Update(models.INTERESTACCRUALCOLUMN_POSTING_ID, posting_id).Error
+}
+
+func (m *mysqlInterestRepoImpl) GetPostingsByAccountId(ctx context.Context, account_id uint32, query *repo.Query) ([]*models.InterestPosting, error) {
+	var postings []*models.InterestPosting
+	err := m.db.WithContext(ctx).
+		Table(models.INTERESTPOSTINGTABLE).
+		Where(fmt.Sprintf("%s = ?", models.INTERESTPOSTINGCOLUMN_ACCOUNT_ID), account_id).
+		Order(models.INTERESTPOSTINGCOLUMN_MONTH + " DESC").
+		Limit(query.Limit).
+		Offset(query.Offset).
+		Find(&postings).Error
+	if err != nil {
+		return nil, err
+	}
+	return postings, nil
+}
+
+func (m *mysqlInterestRepoImpl) BeginTx() *gorm.DB {
+	return m.db.Begin()
+}
@@ -0,0 +1,50 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+
+	"gorm.io/gorm"
+)
+
+type mysqlOverdraftRepoImpl struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewMysqlOverdraftRepo(db *gorm.DB, logger *zap.Logger) repo.OverdraftRepo[*gorm.DB] {
+	return &mysqlOverdraftRepoImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (m *mysqlOverdraftRepoImpl) CreateInterestPosting(ctx context.Context, posting *models.OverdraftInterestPosting, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).Create(posting).Error
+}
+
+func (m *mysqlOverdraftRepoImpl) GetInterestPostingsByAccountId(ctx context.Context, account_id uint32, query *repo.Query) ([]*models.OverdraftInterestPosting, error) {
+	var postings []*models.OverdraftInterestPosting
+	err := m.db.WithContext(ctx).
+		Table(models.OVERDRAFTINTERESTTABLE).
+		Where(fmt.Sprintf("%s = ?", models.OVERDRAFTINTERESTCOLUMN_ACCOUNT_ID), account_id).
+		Order(models.OVERDRAFTINTERESTCOLUMN_ACCRUAL_DATE + " DESC").
+		Limit(query.Limit).
+		Offset(query.Offset).
+		Find(&postings).Error
+	if err != nil {
+		return nil, err
+	}
+	return postings, nil
+}
+
+func (m *mysqlOverdraftRepoImpl) BeginTx() *gorm.DB {
+	return m.db.Begin()
+}
@@ -0,0 +1,176 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/logging"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type mysqlScheduleRepoImpl struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewMysqlScheduleRepo(db *gorm.DB, logger *zap.Logger) repo.ScheduleRepo[*gorm.DB] {
+	return &mysqlScheduleRepoImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (m *mysqlScheduleRepoImpl) Create(ctx context.Context, schedule *models.Schedule, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).Create(schedule).Error
+}
+
+func (m *mysqlScheduleRepoImpl) Update(ctx context.Context, schedule *models.Schedule, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).Save(schedule).Error
+}
+
+func (m *mysqlScheduleRepoImpl) UpdateIfUnchanged(ctx context.Context, schedule *models.Schedule, read_next_run_at time.Time, read_status string, tx *gorm.DB) (bool, error) {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+
+	result := defaultTx.WithContext(ctx).
+		Table(models.SCHEDULETABLE).
+		Where(fmt.Sprintf("%s = ? AND %s = ? AND %s = ?", models.SCHEDULECOLUMN_ID, models.SCHEDULECOLUMN_NEXT_RUN_AT, models.SCHEDULECOLUMN_STATUS),
+			schedule.ID, read_next_run_at, read_status).
+		Updates(map[string]interface{}{
+			models.SCHEDULECOLUMN_NEXT_RUN_AT: schedule.NextRunAt,
+			models.SCHEDULECOLUMN_LAST_RUN_AT: schedule.LastRunAt,
+			models.SCHEDULECOLUMN_RUN_COUNT:   schedule.RunCount,
+			models.SCHEDULECOLUMN_STATUS:      schedule.Status,
+			models.SCHEDULECOLUMN_UPDATED_AT:  time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (m *mysqlScheduleRepoImpl) GetById(ctx context.Context, schedule_id uint32) (*models.Schedule, error) {
+	var schedule models.Schedule
+	err := m.db.WithContext(ctx).
+		Table(models.SCHEDULETABLE).
+		Where(fmt.Sprintf("%s = ?", models.SCHEDULECOLUMN_ID), schedule_id).
+		Find(&schedule).Error
+	if err != nil {
+		logging.FromContext(ctx, m.logger).Info("[MYSQLScheduleRepo-GET-SCHEDULE]", zap.String("Error", err.Error()))
+		return nil, err
+	}
+
+	if schedule.ID == 0 {
+		return nil, notFound("SCHEDULE_NOT_FOUND", "schedule %d not found", schedule_id)
+	}
+	return &schedule, nil
+}
+
+func (m *mysqlScheduleRepoImpl) GetByUserId(ctx context.Context, user_id uint32, query *repo.Query) ([]*models.Schedule, error) {
+	var schedules []*models.Schedule
+	err := m.db.WithContext(ctx).
+		Table(models.SCHEDULETABLE).
+		Where(fmt.Sprintf("%s = ?", models.SCHEDULECOLUMN_USER_ID), user_id).
+		Order(models.SCHEDULECOLUMN_ID + " DESC").
+		Limit(query.Limit).
+		Offset(query.Offset).
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (m *mysqlScheduleRepoImpl) GetDue(ctx context.Context, now time.Time, limit int) ([]*models.Schedule, error) {
+	var schedules []*models.Schedule
+	err := m.db.WithContext(ctx).
+		Table(models.SCHEDULETABLE).
+		Where(fmt.Sprintf("%s = ? AND %s <= ?", models.SCHEDULECOLUMN_STATUS, models.SCHEDULECOLUMN_NEXT_RUN_AT), models.SCHEDULESTATUSACTIVE, now).
+		Order(models.SCHEDULECOLUMN_NEXT_RUN_AT + " ASC").
+		Limit(limit).
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (m *mysqlScheduleRepoImpl) CreateRun(ctx context.Context, run *models.ScheduleRun, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).Create(run).Error
+}
+
+func (m *mysqlScheduleRepoImpl) UpdateRun(ctx context.Context, run *models.ScheduleRun, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).Save(run).Error
+}
+
+func (m *mysqlScheduleRepoImpl) GetRunByIdempotencyKey(ctx context.Context, key string) (*models.ScheduleRun, error) {
+	var run models.ScheduleRun
+	err := m.db.WithContext(ctx).
+		Table(models.SCHEDULERUNTABLE).
+		Where(fmt.Sprintf("%s = ?", models.SCHEDULERUNCOLUMN_IDEMPOTENCY_KEY), key).
+		Find(&run).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if run.ID == 0 {
+		return nil, notFound("SCHEDULE_RUN_NOT_FOUND", "schedule run %s not found", key)
+	}
+	return &run, nil
+}
+
+func (m *mysqlScheduleRepoImpl) FailStaleRuns(ctx context.Context, started_before time.Time, reason string) (int64, error) {
+	result := m.db.WithContext(ctx).
+		Table(models.SCHEDULERUNTABLE).
+		Where(fmt.Sprintf("%s = ? AND %s < ?", models.SCHEDULERUNCOLUMN_STATUS, models.SCHEDULERUNCOLUMN_UPDATED_AT), models.SCHEDULERUNSTATUSSTARTED, started_before).
+		Updates(map[string]interface{}{
+			models.SCHEDULERUNCOLUMN_STATUS:     models.SCHEDULERUNSTATUSFAILED,
+			models.SCHEDULERUNCOLUMN_ERROR:      reason,
+			models.SCHEDULERUNCOLUMN_UPDATED_AT: time.Now(),
+		})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+func (m *mysqlScheduleRepoImpl) GetRunsByScheduleId(ctx context.Context, schedule_id uint32, query *repo.Query) ([]*models.ScheduleRun, error) {
+	var runs []*models.ScheduleRun
+	err := m.db.WithContext(ctx).
+		Table(models.SCHEDULERUNTABLE).
+		Where(fmt.Sprintf("%s = ?", models.SCHEDULERUNCOLUMN_SCHEDULE_ID), schedule_id).
+		Order(models.SCHEDULERUNCOLUMN_ID + " DESC").
+		Limit(query.Limit).
+		Offset(query.Offset).
+		Find(&runs).Error
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+func (m *mysqlScheduleRepoImpl) BeginTx() *gorm.DB {
+	return m.db.Begin()
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"go.uber.org/zap"
-	"log"
+	"money_forward_code_challenge/internal/common/exception"
+	"money_forward_code_challenge/internal/common/logging"
 	"money_forward_code_challenge/internal/domain/transaction/aggregate"
 	"money_forward_code_challenge/internal/domain/transaction/models"
 	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -34,24 +36,33 @@ func (r *mysqlTransactionRepoImpl) GetById(ctx context.Context, id uint32) (*agg
 			models.TRANSACTIONCOLUMN_ACCOUNT_ID,
 			models.ACCOUNTCOLUMN_BANK,
 			models.ACCOUNTCOLUMN_USER_ID,
+			models.TRANSACTIONCOLUMN_MEMO,
+			models.TRANSACTIONCOLUMN_COUNTERPARTY,
+			models.TRANSACTIONCOLUMN_CATEGORY,
+			models.TRANSACTIONCOLUMN_TAGS,
+			models.TRANSACTIONCOLUMN_PARENT_ID,
 		).
 		Joins(fmt.Sprintf("INNER JOIN %s ON %s = %s",
 			models.ACCOUNTTABLE,
 			models.TRANSACTIONCOLUMN_ACCOUNT_ID, // transactions.account_id
 			models.ACCOUNTCOLUMN_ID),            // accounts.id
-		).Where(fmt.Sprintf("%s = ?", models.TRANSACTIONCOLUMN_ID), id).
+		).Where(fmt.Sprintf("%s = ? AND %s = ?", models.TRANSACTIONCOLUMN_ID, models.TRANSACTIONCOLUMN_DELETED), id, false).
 		Table(models.TRANSACTIONTABLE).
 		Find(&transaction).Error
 
 	if err != nil {
 		return nil, err
 	}
+
+	if transaction.Id == 0 {
+		return nil, notFound("TRANSACTION_NOT_FOUND", "transaction %d not found", id)
+	}
 	return &transaction, nil
 }
 
 func (r *mysqlTransactionRepoImpl) GetByUserId(ctx context.Context, user_id uint32, query *repo.Query) ([]*aggregate.TransactionByDetails, error) {
 	var transactions []*aggregate.TransactionByDetails
-	log.Println("[repo] GetByUserId: ", user_id)
+	logging.FromContext(ctx, r.logger).Debug("[MYSQLTransactionRepo-GET-BY-USER-ID]", zap.Uint32("user_id", user_id))
 	builder := r.db.WithContext(ctx).
 		Select(models.TRANSACTIONCOLUMN_ID,
 			models.TRANSACTIONCOLUMN_CREATED_AT,
@@ -60,6 +71,11 @@ func (r *mysqlTransactionRepoImpl) GetByUserId(ctx context.Context, user_id uint
 			models.TRANSACTIONCOLUMN_ACCOUNT_ID,
 			models.ACCOUNTCOLUMN_BANK,
 			models.ACCOUNTCOLUMN_USER_ID,
+			models.TRANSACTIONCOLUMN_MEMO,
+			models.TRANSACTIONCOLUMN_COUNTERPARTY,
+			models.TRANSACTIONCOLUMN_CATEGORY,
+			models.TRANSACTIONCOLUMN_TAGS,
+			models.TRANSACTIONCOLUMN_PARENT_ID,
 		).
 		Joins(fmt.Sprintf("INNER JOIN %s ON %s = %s",
 			models.ACCOUNTTABLE,
@@ -84,7 +100,7 @@ func (r *mysqlTransactionRepoImpl) GetByUserId(ctx context.Context, user_id uint
 	for i := 0; i < len(transactions); i++ {
 		err := transactions[i].FormatDateHCM()
 		if err != nil {
-			fmt.Println("parse error layout: ", err)
+			logging.FromContext(ctx, r.logger).Warn("[MYSQLTransactionRepo-FORMAT-DATE]", zap.String("Error", err.Error()))
 		}
 	}
 	return transactions, nil
@@ -101,6 +117,11 @@ func (r *mysqlTransactionRepoImpl) GetByAccountId(ctx context.Context, account_i
 			models.TRANSACTIONCOLUMN_ACCOUNT_ID,
 			models.ACCOUNTCOLUMN_BANK,
 			models.ACCOUNTCOLUMN_USER_ID,
+			models.TRANSACTIONCOLUMN_MEMO,
+			models.TRANSACTIONCOLUMN_COUNTERPARTY,
+			models.TRANSACTIONCOLUMN_CATEGORY,
+			models.TRANSACTIONCOLUMN_TAGS,
+			models.TRANSACTIONCOLUMN_PARENT_ID,
 		).
 		Joins(fmt.Sprintf("INNER JOIN %s ON %s = %s",
 			models.ACCOUNTTABLE,
@@ -119,7 +140,9 @@ func (r *mysqlTransactionRepoImpl) GetByAccountId(ctx context.Context, account_i
 
 	for _, transactionDetail := range transactions {
 		err := transactionDetail.FormatDateHCM()
-		fmt.Println(err)
+		if err != nil {
+			logging.FromContext(ctx, r.logger).Warn("[MYSQLTransactionRepo-FORMAT-DATE]", zap.String("Error", err.Error()))
+		}
 	}
 	return transactions, err
 }
@@ -141,24 +164,82 @@ func (r *mysqlTransactionRepoImpl) Update(ctx context.Context, transaction *mode
 }
 
 func (r *mysqlTransactionRepoImpl) Delete(ctx context.Context, transaction *models.Transaction, tx *gorm.DB) error {
-	r.logger.Info("[MYSQLTransactionRepo-DELETE-TRANSACTION]", zap.Any("transaction", transaction))
+	logging.FromContext(ctx, r.logger).Info("[MYSQLTransactionRepo-DELETE-TRANSACTION]", zap.Any("transaction", transaction))
 	txDB := r.db
 	if tx != nil {
 		txDB = tx
 	}
 
-	err := txDB.WithContext(ctx).Table(models.TRANSACTIONTABLE).
-		Where(fmt.Sprintf("%s = ?", models.TRANSACTIONCOLUMN_ID), transaction.ID).
-		Update(models.TRANSACTIONCOLUMN_DELETED, true).Error
-	if err != nil {
-		r.logger.Error("[MYSQLTransactionRepo-DELETE-TRANSACTION]", zap.Any("transaction", transaction))
-		return err
+	// guard on deleted, replayed delete must not reverse balance again
+	result := txDB.WithContext(ctx).Table(models.TRANSACTIONTABLE).
+		Where(fmt.Sprintf("%s = ? AND %s = ?", models.TRANSACTIONCOLUMN_ID, models.TRANSACTIONCOLUMN_DELETED), transaction.ID, false).
+		Updates(map[string]interface{}{
+			models.TRANSACTIONCOLUMN_DELETED:     true,
+			models.TRANSACTIONCOLUMN_REVERSED_AT: time.Now(),
+		})
+	if result.Error != nil {
+		logging.FromContext(ctx, r.logger).Error("[MYSQLTransactionRepo-DELETE-TRANSACTION]", zap.Any("transaction", transaction))
+		return result.Error
+	}
+	if result.RowsAffected != 1 {
+		return exception.Newf(exception.Conflict, "TRANSACTION_ALREADY_REVERSED", "transaction %d is already reversed", transaction.ID)
 	}
 
-	r.logger.Info("[MYSQLTransactionRepo-DELETE-TRANSACTION]", zap.Any("transaction", transaction))
+	logging.FromContext(ctx, r.logger).Info("[MYSQLTransactionRepo-DELETE-TRANSACTION]", zap.Any("transaction", transaction))
 	return nil
 }
 
+func (r *mysqlTransactionRepoImpl) GetFeesByParentId(ctx context.Context, parent_id uint32, tx *gorm.DB) ([]*aggregate.TransactionByDetails, error) {
+	txDB := r.db
+	if tx != nil {
+		txDB = tx
+	}
+
+	var fees []*aggregate.TransactionByDetails
+	err := txDB.WithContext(ctx).
+		Select(models.TRANSACTIONCOLUMN_ID,
+			models.TRANSACTIONCOLUMN_CREATED_AT,
+			models.TRANSACTIONCOLUMN_TRANSACTION_TYPE,
+			models.TRANSACTIONCOLUMN_AMOUNT,
+			models.TRANSACTIONCOLUMN_ACCOUNT_ID,
+			models.ACCOUNTCOLUMN_BANK,
+			models.ACCOUNTCOLUMN_USER_ID,
+			models.TRANSACTIONCOLUMN_MEMO,
+			models.TRANSACTIONCOLUMN_COUNTERPARTY,
+			models.TRANSACTIONCOLUMN_CATEGORY,
+			models.TRANSACTIONCOLUMN_TAGS,
+			models.TRANSACTIONCOLUMN_PARENT_ID,
+		).
+		Joins(fmt.Sprintf("INNER JOIN %s ON %s = %s",
+			models.ACCOUNTTABLE,
+			models.TRANSACTIONCOLUMN_ACCOUNT_ID,
+			models.ACCOUNTCOLUMN_ID)).
+		Where(fmt.Sprintf("%s = ? AND %s = ? AND %s = ?",
+			models.TRANSACTIONCOLUMN_PARENT_ID, models.TRANSACTIONCOLUMN_TRANSACTION_TYPE, models.TRANSACTIONCOLUMN_DELETED),
+			parent_id, models.TRANSACTIONTYPEFEE, false).
+		Table(models.TRANSACTIONTABLE).
+		Order(models.TRANSACTIONCOLUMN_ID + " ASC").
+		Find(&fees).Error
+	if err != nil {
+		return nil, err
+	}
+	return fees, nil
+}
+
+func (r *mysqlTransactionRepoImpl) UpdateCategorization(ctx context.Context, transaction_id uint32, category string, tags string, tx *gorm.DB) error {
+	txDB := r.db
+	if tx != nil {
+		txDB = tx
+	}
+
+	return txDB.WithContext(ctx).Table(models.TRANSACTIONTABLE).
+		Where(fmt.Sprintf("%s = ?", models.TRANSACTIONCOLUMN_ID), transaction_id).
+		Updates(map[string]any{
+			"category": category,
+			"tags":     tags,
+		}).Error
+}
+
 func (r *mysqlTransactionRepoImpl) BeginTx() *gorm.DB {
 	return r.db.Begin()
 }
@@ -4,13 +4,19 @@ import (
 	"context"
 	"fmt"
 	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/logging"
 	"money_forward_code_challenge/internal/domain/transaction/aggregate"
 	"money_forward_code_challenge/internal/domain/transaction/models"
 	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+// balance - held amount + approved overdraft limit, same as AccountByDetails.ComputeAvailableBalance
+var availableBalanceExpr = fmt.Sprintf("%s - %s + IF(%s, %s, 0)",
+	models.ACCOUNTCOLUMN_BALANCE, models.ACCOUNTCOLUMN_HELD, models.ACCOUNTCOLUMN_OVERDRAFT_APPROVED, models.ACCOUNTCOLUMN_OVERDRAFT_LIMIT)
+
 type mysqlUserRepoImpl struct {
 	db     *gorm.DB
 	logger *zap.Logger
@@ -50,22 +56,22 @@ func (m *mysqlUserRepoImpl) DeleteAccountById(ctx context.Context, account_id ui
 
 func (m *mysqlUserRepoImpl) GetUserById(ctx context.Context, user_id uint32) (*models.User, error) {
 	var userModel models.User
-	m.logger.Info("[MYSQLUserRepo-GET-USER]", zap.Uint32("user_id", user_id))
+	logging.FromContext(ctx, m.logger).Info("[MYSQLUserRepo-GET-USER]", zap.Uint32("user_id", user_id))
 	err := m.db.WithContext(ctx).
 		Select(models.USERCOLUMN_ID).
 		Table(models.USERTABLE).
 		Where(fmt.Sprintf("%s = ?", models.USERCOLUMN_ID), user_id).
 		Find(&userModel).Error
 	if err != nil {
-		m.logger.Info("[MYSQLUserRepo-GET-USER]", zap.String("Error", err.Error()))
+		logging.FromContext(ctx, m.logger).Info("[MYSQLUserRepo-GET-USER]", zap.String("Error", err.Error()))
 		return nil, err
 	}
 
 	if userModel.ID == 0 {
-		return nil, gorm.ErrRecordNotFound
+		return nil, notFound("USER_NOT_FOUND", "user %d not found", user_id)
 	}
 
-	m.logger.Info("[MYSQLUserRepo-GET-USER]", zap.Any("Found", userModel))
+	logging.FromContext(ctx, m.logger).Info("[MYSQLUserRepo-GET-USER]", zap.Any("Found", userModel))
 	return &userModel, err
 }
 
@@ -75,6 +81,10 @@ func (m *mysqlUserRepoImpl) GetAccountByAccountId(ctx context.Context, account_i
 		Select(models.ACCOUNTCOLUMN_ID,
 			models.ACCOUNTCOLUMN_USER_ID,
 			models.ACCOUNTCOLUMN_BALANCE,
+			models.ACCOUNTCOLUMN_HELD,
+			models.ACCOUNTCOLUMN_OVERDRAFT_LIMIT,
+			models.ACCOUNTCOLUMN_OVERDRAFT_APPROVED,
+			models.ACCOUNTCOLUMN_OVERDRAFT_RATE,
 			models.ACCOUNTCOLUMN_CREATED_AT,
 			models.ACCOUNTCOLUMN_UPDATED_AT,
 			models.ACCOUNTCOLUMN_BANK,
@@ -83,13 +93,14 @@ func (m *mysqlUserRepoImpl) GetAccountByAccountId(ctx context.Context, account_i
 		Table("accounts").
 		Find(&account).Error
 	if err != nil {
-		m.logger.Info("[MYSQLUserRepo-GET-ACCOUNT]", zap.String("Error", err.Error()))
+		logging.FromContext(ctx, m.logger).Info("[MYSQLUserRepo-GET-ACCOUNT]", zap.String("Error", err.Error()))
 		return nil, err
 	}
 
 	if account.Id == 0 {
-		return nil, gorm.ErrRecordNotFound
+		return nil, notFound("ACCOUNT_NOT_FOUND", "account %d not found", account_id)
 	}
+	account.ComputeAvailableBalance()
 	return &account, nil
 }
 
@@ -98,7 +109,11 @@ func (m *mysqlUserRepoImpl) BeginTx() *gorm.DB {
 }
 
 func (m *mysqlUserRepoImpl) UpdateBalance(ctx context.Context, account_id uint32, new_balance float32, tx *gorm.DB) error {
-	err := m.db.WithContext(ctx).
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	err := defaultTx.WithContext(ctx).
 		Table(models.ACCOUNTTABLE).
 		Where(fmt.Sprintf("%s = ?", models.ACCOUNTCOLUMN_ID), account_id).
 		Update(models.ACCOUNTCOLUMN_BALANCE, new_balance).Error
@@ -110,6 +125,123 @@ func (m *mysqlUserRepoImpl) UpdateBalance(ctx context.Context, account_id uint32
 	return nil
 }
 
+func (m *mysqlUserRepoImpl) DebitBalance(ctx context.Context, account_id uint32, amount float32, tx *gorm.DB) (bool, error) {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+
+	// check and debit in one statement, so concurrent withdrawals never pass overdraft limit
+	result := defaultTx.WithContext(ctx).
+		Table(models.ACCOUNTTABLE).
+		Where(fmt.Sprintf("%s = ? AND %s >= ?", models.ACCOUNTCOLUMN_ID, availableBalanceExpr), account_id, amount).
+		Update(models.ACCOUNTCOLUMN_BALANCE, gorm.Expr(fmt.Sprintf("%s - ?", models.ACCOUNTCOLUMN_BALANCE), amount))
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (m *mysqlUserRepoImpl) AddBalance(ctx context.Context, account_id uint32, delta float32, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+
+	return defaultTx.WithContext(ctx).
+		Table(models.ACCOUNTTABLE).
+		Where(fmt.Sprintf("%s = ?", models.ACCOUNTCOLUMN_ID), account_id).
+		Update(models.ACCOUNTCOLUMN_BALANCE, gorm.Expr(fmt.Sprintf("%s + ?", models.ACCOUNTCOLUMN_BALANCE), delta)).Error
+}
+
+func (m *mysqlUserRepoImpl) UpdateOverdraft(ctx context.Context, account_id uint32, limit float32, rate float32, approved bool, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+
+	return defaultTx.WithContext(ctx).
+		Table(models.ACCOUNTTABLE).
+		Where(fmt.Sprintf("%s = ?", models.ACCOUNTCOLUMN_ID), account_id).
+		Updates(map[string]interface{}{
+			models.ACCOUNTCOLUMN_OVERDRAFT_LIMIT:    limit,
+			models.ACCOUNTCOLUMN_OVERDRAFT_RATE:     rate,
+			models.ACCOUNTCOLUMN_OVERDRAFT_APPROVED: approved,
+		}).Error
+}
+
+func (m *mysqlUserRepoImpl) ApproveOverdraft(ctx context.Context, account_id uint32, reviewed_limit float32, approved bool, tx *gorm.DB) (bool, error) {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+
+	builder := defaultTx.WithContext(ctx).
+		Table(models.ACCOUNTTABLE).
+		Where(fmt.Sprintf("%s = ?", models.ACCOUNTCOLUMN_ID), account_id)
+	if approved {
+		// limit is float, compared to cent
+		builder = builder.Where(fmt.Sprintf("%s > 0 AND ABS(%s - ?) < 0.005", models.ACCOUNTCOLUMN_OVERDRAFT_LIMIT, models.ACCOUNTCOLUMN_OVERDRAFT_LIMIT), reviewed_limit)
+	}
+	// updated_at always change, so affected rows is 1 also when approval was already same
+	result := builder.Updates(map[string]interface{}{
+		models.ACCOUNTCOLUMN_OVERDRAFT_APPROVED: approved,
+		models.ACCOUNTCOLUMN_UPDATED_AT:         time.Now(),
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (m *mysqlUserRepoImpl) GetOverdrawnAccounts(ctx context.Context, after_id uint32, limit int) ([]*models.Account, error) {
+	var accounts []*models.Account
+	err := m.db.WithContext(ctx).
+		Table(models.ACCOUNTTABLE).
+		Where(fmt.Sprintf("%s > ? AND %s < 0", models.ACCOUNTCOLUMN_ID, models.ACCOUNTCOLUMN_BALANCE), after_id).
+		Order(models.ACCOUNTCOLUMN_ID + " ASC").
+		Limit(limit).
+		Find(&accounts).Error
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+func (m *mysqlUserRepoImpl) ReserveHeldAmount(ctx context.Context, account_id uint32, amount float32, tx *gorm.DB) (bool, error) {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+
+	// check and reserve in one statement, so concurrent holds never over reserve
+	result := defaultTx.WithContext(ctx).
+		Table(models.ACCOUNTTABLE).
+		Where(fmt.Sprintf("%s = ? AND %s >= ?", models.ACCOUNTCOLUMN_ID, availableBalanceExpr), account_id, amount).
+		Update(models.ACCOUNTCOLUMN_HELD, gorm.Expr(fmt.Sprintf("%s + ?", models.ACCOUNTCOLUMN_HELD), amount))
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (m *mysqlUserRepoImpl) ReleaseHeldAmount(ctx context.Context, account_id uint32, released float32, captured float32, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+
+	return defaultTx.WithContext(ctx).
+		Table(models.ACCOUNTTABLE).
+		Where(fmt.Sprintf("%s = ?", models.ACCOUNTCOLUMN_ID), account_id).
+		Updates(map[string]interface{}{
+			models.ACCOUNTCOLUMN_HELD:    gorm.Expr(fmt.Sprintf("%s - ?", models.ACCOUNTCOLUMN_HELD), released),
+			models.ACCOUNTCOLUMN_BALANCE: gorm.Expr(fmt.Sprintf("%s - ?", models.ACCOUNTCOLUMN_BALANCE), captured),
+		}).Error
+}
+
 func NewMysqlUserRepo(db *gorm.DB, logger *zap.Logger) repo.UserRepo[*gorm.DB] {
 	return &mysqlUserRepoImpl{
 		db:     db,
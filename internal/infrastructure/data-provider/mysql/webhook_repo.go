@@ -0,0 +1,205 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"money_forward_code_challenge/internal/common/logging"
+	"money_forward_code_challenge/internal/domain/transaction/models"
+	"money_forward_code_challenge/internal/domain/transaction/repo"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type mysqlWebhookRepoImpl struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewMysqlWebhookRepo(db *gorm.DB, logger *zap.Logger) repo.WebhookRepo[*gorm.DB] {
+	return &mysqlWebhookRepoImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (m *mysqlWebhookRepoImpl) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).Create(subscription).Error
+}
+
+func (m *mysqlWebhookRepoImpl) DeleteSubscription(ctx context.Context, user_id uint32, subscription_id uint32, tx *gorm.DB) (bool, error) {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	result := defaultTx.WithContext(ctx).
+		Where(fmt.Sprintf("%s = ? AND %s = ?", models.WEBHOOKSUBSCRIPTIONCOLUMN_ID, models.WEBHOOKSUBSCRIPTIONCOLUMN_USER_ID), subscription_id, user_id).
+		Delete(&models.WebhookSubscription{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (m *mysqlWebhookRepoImpl) GetSubscriptionById(ctx context.Context, subscription_id uint32) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	err := m.db.WithContext(ctx).
+		Table(models.WEBHOOKSUBSCRIPTIONTABLE).
+		Where(fmt.Sprintf("%s = ?", models.WEBHOOKSUBSCRIPTIONCOLUMN_ID), subscription_id).
+		Find(&subscription).Error
+	if err != nil {
+		logging.FromContext(ctx, m.logger).Info("[MYSQLWebhookRepo-GET-SUBSCRIPTION]", zap.String("Error", err.Error()))
+		return nil, err
+	}
+
+	if subscription.ID == 0 {
+		return nil, notFound("WEBHOOK_SUBSCRIPTION_NOT_FOUND", "webhook subscription %d not found", subscription_id)
+	}
+	return &subscription, nil
+}
+
+func (m *mysqlWebhookRepoImpl) GetSubscriptionsByUserId(ctx context.Context, user_id uint32) ([]*models.WebhookSubscription, error) {
+	var subscriptions []*models.WebhookSubscription
+	err := m.db.WithContext(ctx).
+		Table(models.WEBHOOKSUBSCRIPTIONTABLE).
+		Where(fmt.Sprintf("%s = ?", models.WEBHOOKSUBSCRIPTIONCOLUMN_USER_ID), user_id).
+		Order(models.WEBHOOKSUBSCRIPTIONCOLUMN_ID + " ASC").
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (m *mysqlWebhookRepoImpl) CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery, tx *gorm.DB) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).Create(deliveries).Error
+}
+
+func (m *mysqlWebhookRepoImpl) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := m.db.WithContext(ctx).
+		Table(models.WEBHOOKDELIVERYTABLE).
+		Where(fmt.Sprintf("%s = ? AND %s <= ?", models.WEBHOOKDELIVERYCOLUMN_STATUS, models.WEBHOOKDELIVERYCOLUMN_NEXT_ATTEMPT_AT), models.WEBHOOKDELIVERYSTATUSPENDING, now).
+		Order(models.WEBHOOKDELIVERYCOLUMN_NEXT_ATTEMPT_AT + " ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (m *mysqlWebhookRepoImpl) ClaimDelivery(ctx context.Context, delivery *models.WebhookDelivery, lease_until time.Time, tx *gorm.DB) (bool, error) {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+
+	result := defaultTx.WithContext(ctx).
+		Table(models.WEBHOOKDELIVERYTABLE).
+		Where(fmt.Sprintf("%s = ? AND %s = ? AND %s = ?",
+			models.WEBHOOKDELIVERYCOLUMN_ID,
+			models.WEBHOOKDELIVERYCOLUMN_STATUS,
+			models.WEBHOOKDELIVERYCOLUMN_NEXT_ATTEMPT_AT), delivery.ID, models.WEBHOOKDELIVERYSTATUSPENDING, delivery.NextAttemptAt).
+		Update(models.WEBHOOKDELIVERYCOLUMN_NEXT_ATTEMPT_AT, lease_until)
+	if result.Error != nil {
+		logging.FromContext(ctx, m.logger).Info("[MYSQLWebhookRepo-CLAIM-DELIVERY]", zap.String("Error", result.Error.Error()))
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 1 {
+		delivery.NextAttemptAt = lease_until
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (m *mysqlWebhookRepoImpl) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).
+		Table(models.WEBHOOKDELIVERYTABLE).
+		Where(fmt.Sprintf("%s = ?", models.WEBHOOKDELIVERYCOLUMN_ID), delivery.ID).
+		Updates(map[string]interface{}{
+			"status":           delivery.Status,
+			"attempts":         delivery.Attempts,
+			"next_attempt_at":  delivery.NextAttemptAt,
+			"last_status_code": delivery.LastStatusCode,
+			"last_error":       delivery.LastError,
+			"delivered_at":     delivery.DeliveredAt,
+		}).Error
+}
+
+func (m *mysqlWebhookRepoImpl) CreateAttempt(ctx context.Context, attempt *models.WebhookAttempt, tx *gorm.DB) error {
+	defaultTx := m.db
+	if tx != nil {
+		defaultTx = tx
+	}
+	return defaultTx.WithContext(ctx).Create(attempt).Error
+}
+
+func (m *mysqlWebhookRepoImpl) GetDeliveriesBySubscriptionId(ctx context.Context, subscription_id uint32, query *repo.Query) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	builder := m.db.WithContext(ctx).
+		Table(models.WEBHOOKDELIVERYTABLE).
+		Where(fmt.Sprintf("%s = ?", models.WEBHOOKDELIVERYCOLUMN_SUBSCRIPTION_ID), subscription_id).
+		Order(models.WEBHOOKDELIVERYCOLUMN_ID + " DESC")
+	if query.Limit != 0 {
+		builder.Limit(query.Limit).Offset(query.Offset)
+	} else {
+		builder.Limit(10).Offset(0)
+	}
+
+	err := builder.Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (m *mysqlWebhookRepoImpl) GetDeliveryById(ctx context.Context, delivery_id uint32) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := m.db.WithContext(ctx).
+		Table(models.WEBHOOKDELIVERYTABLE).
+		Where(fmt.Sprintf("%s = ?", models.WEBHOOKDELIVERYCOLUMN_ID), delivery_id).
+		Find(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.ID == 0 {
+		return nil, notFound("WEBHOOK_DELIVERY_NOT_FOUND", "webhook delivery %d not found", delivery_id)
+	}
+	return &delivery, nil
+}
+
+func (m *mysqlWebhookRepoImpl) GetAttemptsByDeliveryId(ctx context.Context, delivery_id uint32) ([]*models.WebhookAttempt, error) {
+	var attempts []*models.WebhookAttempt
+	err := m.db.WithContext(ctx).
+		Table(models.WEBHOOKATTEMPTTABLE).
+		Where(fmt.Sprintf("%s = ?", models.WEBHOOKATTEMPTCOLUMN_DELIVERY_ID), delivery_id).
+		Order(models.WEBHOOKATTEMPTCOLUMN_ID + " ASC").
+		Find(&attempts).Error
+	if err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+func (m *mysqlWebhookRepoImpl) BeginTx() *gorm.DB {
+	return m.db.Begin()
+}
@@ -0,0 +1,26 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/go-redis/redis/v8"
+	"money_forward_code_challenge/internal/common/exception"
+)
+
+// translateError wrap redis errors into exception kinds, missing key is NotFound with code
+// use cases treat any cache error as a miss, kinds matter when cache error reach a caller
+func translateError(err error, code string) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, redis.Nil) {
+		return exception.Wrap(exception.NotFound, code, err)
+	}
+	var netErr net.Error
+	if errors.Is(err, redis.ErrClosed) || errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) {
+		return exception.Wrap(exception.Unavailable, "CACHE_UNAVAILABLE", err)
+	}
+	return err
+}
@@ -17,7 +17,7 @@ type redisTransactionCacheRepoImpl struct {
 }
 
 func (t *redisTransactionCacheRepoImpl) Set(ctx context.Context, details *aggregate.TransactionByDetails) error {
-	KeyId := fmt.Sprintf("%s", details.Id)
+	KeyId := fmt.Sprintf("%d", details.Id)
 	buf, err := data_provider_conversion.SerializeGOB[*aggregate.TransactionByDetails](details)
 
 	if err != nil {
@@ -28,9 +28,12 @@ func (t *redisTransactionCacheRepoImpl) Set(ctx context.Context, details *aggreg
 }
 
 func (t *redisTransactionCacheRepoImpl) GetById(ctx context.Context, id uint32) (*aggregate.TransactionByDetails, error) {
-	keyId := fmt.Sprintf("%s", id)
+	keyId := fmt.Sprintf("%d", id)
 
-	bufString := t.client.HGet(ctx, t.transactionDetailKey, keyId).Val()
+	bufString, err := t.client.HGet(ctx, t.transactionDetailKey, keyId).Result()
+	if err != nil {
+		return nil, translateError(err, "TRANSACTION_NOT_FOUND")
+	}
 	transactionDetail, err := data_provider_conversion.DeserializeGOB[*aggregate.TransactionByDetails](&bufString)
 	if err != nil {
 		return nil, err
@@ -40,7 +43,7 @@ func (t *redisTransactionCacheRepoImpl) GetById(ctx context.Context, id uint32)
 }
 
 func (t *redisTransactionCacheRepoImpl) Delete(ctx context.Context, transactionId uint32) error {
-	id := fmt.Sprintf("%s", transactionId)
+	id := fmt.Sprintf("%d", transactionId)
 
 	return t.client.HDel(ctx, t.transactionDetailKey, id).Err()
 }
@@ -30,7 +30,7 @@ func (r *redisUserCacheRepoImpl) CreateUser(ctx context.Context, user *models.Us
 }
 
 func (r *redisUserCacheRepoImpl) SetAccount(ctx context.Context, details *aggregate.AccountByDetails) error {
-	keyId := fmt.Sprintf("%s", details.Id)
+	keyId := fmt.Sprintf("%d", details.Id)
 	buf, err := data_provider_conversion.SerializeGOB[*aggregate.AccountByDetails](details)
 	if err != nil {
 		return err
@@ -42,7 +42,7 @@ func (r *redisUserCacheRepoImpl) SetAccount(ctx context.Context, details *aggreg
 }
 
 func (r *redisUserCacheRepoImpl) DeleteAccountById(ctx context.Context, id uint32) error {
-	keyId := fmt.Sprintf("%s", id)
+	keyId := fmt.Sprintf("%d", id)
 
 	return r.db.HDel(ctx, r.accountDetailKey, keyId).Err()
 }
@@ -53,16 +53,18 @@ func (r *redisUserCacheRepoImpl) GetUserById(ctx context.Context, user_id uint32
 }
 
 func (r *redisUserCacheRepoImpl) GetAccountByAccountId(ctx context.Context, account_id uint32) (*aggregate.AccountByDetails, error) {
-	keyId := fmt.Sprintf("%s", account_id)
+	keyId := fmt.Sprintf("%d", account_id)
 
-	bufString := r.db.HGet(ctx, r.accountDetailKey, keyId).Val()
-	if bufString == "" {
-		return nil, fmt.Errorf("account not found")
+	bufString, err := r.db.HGet(ctx, r.accountDetailKey, keyId).Result()
+	if err != nil {
+		return nil, translateError(err, "ACCOUNT_NOT_FOUND")
 	}
 	accountDetail, err := data_provider_conversion.DeserializeGOB[*aggregate.AccountByDetails](&bufString)
 	if err != nil {
 		return nil, err
 	}
 
+	// entries cached before held amount existed have zero available balance
+	accountDetail.ComputeAvailableBalance()
 	return accountDetail, nil
 }
@@ -0,0 +1,27 @@
+package migration
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// mysql/<version>_<name>.up.sql and .down.sql are applied in version order by pkgs/migrate
+// applied files must never be edited, add a new version instead (checksum is verified)
+//
+//go:embed mysql/*.sql
+var schemaFiles embed.FS
+
+// seed/*.sql are run in name order on demand, every file must be safe to run again
+//
+//go:embed seed/*.sql
+var seedFiles embed.FS
+
+func Schema() fs.FS {
+	sub, _ := fs.Sub(schemaFiles, "mysql")
+	return sub
+}
+
+func Seed() fs.FS {
+	sub, _ := fs.Sub(seedFiles, "seed")
+	return sub
+}
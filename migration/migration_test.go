@@ -0,0 +1,32 @@
+package migration
+
+import (
+	"io/fs"
+	"money_forward_code_challenge/pkgs/migrate"
+	"testing"
+)
+
+func TestSchema(t *testing.T) {
+	migrations, err := migrate.Load(Schema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no migration is embedded")
+	}
+	for _, migration := range migrations {
+		if len(migrate.SplitStatements(migration.Up)) == 0 || len(migrate.SplitStatements(migration.Down)) == 0 {
+			t.Errorf("migration %d_%s has empty script", migration.Version, migration.Name)
+		}
+	}
+}
+
+func TestSeed(t *testing.T) {
+	names, err := fs.Glob(Seed(), "*.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) == 0 {
+		t.Fatal("no seed is embedded")
+	}
+}
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS `categorization_rules`;
+
+ALTER TABLE `transactions`
+  DROP KEY `idx_transactions_category`,
+  DROP COLUMN `memo`,
+  DROP COLUMN `counterparty`,
+  DROP COLUMN `category`,
+  DROP COLUMN `tags`;
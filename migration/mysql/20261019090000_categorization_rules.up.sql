@@ -0,0 +1,68 @@
+--
+-- Categorization fields on transactions and table `categorization_rules`
+-- tables, columns and indexes which AutoMigrate of builds before migrations already created are kept
+-- previous-checksum: 3ddf5a855c60d57b0aec1a2205eb959d024543f16d9571c65562bea659fc5cf6
+--
+
+SET @ddl = IF((SELECT COUNT(*) FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'transactions' AND column_name = 'memo') = 0,
+  'ALTER TABLE `transactions` ADD COLUMN `memo` varchar(255) NOT NULL DEFAULT ''''',
+  'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @ddl = IF((SELECT COUNT(*) FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'transactions' AND column_name = 'counterparty') = 0,
+  'ALTER TABLE `transactions` ADD COLUMN `counterparty` varchar(255) NOT NULL DEFAULT ''''',
+  'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @ddl = IF((SELECT COUNT(*) FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'transactions' AND column_name = 'category') = 0,
+  'ALTER TABLE `transactions` ADD COLUMN `category` varchar(50) NOT NULL DEFAULT ''''',
+  'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @ddl = IF((SELECT COUNT(*) FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'transactions' AND column_name = 'tags') = 0,
+  'ALTER TABLE `transactions` ADD COLUMN `tags` varchar(255) NOT NULL DEFAULT ''''',
+  'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @ddl = IF((SELECT COUNT(*) FROM information_schema.statistics
+    WHERE table_schema = DATABASE() AND table_name = 'transactions' AND index_name = 'idx_transactions_category') = 0,
+  'ALTER TABLE `transactions` ADD KEY `idx_transactions_category` (`category`)',
+  'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+CREATE TABLE IF NOT EXISTS `categorization_rules` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` int unsigned NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `priority` bigint NOT NULL DEFAULT '0',
+  `enabled` tinyint(1) NOT NULL,
+  `min_amount` float NOT NULL DEFAULT '0',
+  `max_amount` float NOT NULL DEFAULT '0',
+  `transaction_type` varchar(15) NOT NULL DEFAULT '',
+  `bank` char(3) NOT NULL DEFAULT '',
+  `match_mode` varchar(10) NOT NULL,
+  `memo_pattern` varchar(255) NOT NULL DEFAULT '',
+  `counterparty_pattern` varchar(255) NOT NULL DEFAULT '',
+  `time_of_day_start` char(5) NOT NULL DEFAULT '',
+  `time_of_day_end` char(5) NOT NULL DEFAULT '',
+  `category` varchar(50) NOT NULL,
+  `tags` varchar(255) NOT NULL DEFAULT '',
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_categorization_rules_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,47 @@
+--
+-- Table structure for table `schedules` and `schedule_runs`
+-- tables, columns and indexes which AutoMigrate of builds before migrations already created are kept
+-- previous-checksum: 351dc33869597025bedff19a8ccaf26bdd537860d64a67f64ad29505ba03d2fd
+--
+
+CREATE TABLE IF NOT EXISTS `schedules` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` int unsigned NOT NULL,
+  `account_id` int unsigned NOT NULL,
+  `target_account_id` int unsigned NOT NULL DEFAULT '0',
+  `amount` float NOT NULL,
+  `transaction_type` varchar(15) NOT NULL,
+  `memo` varchar(255) NOT NULL DEFAULT '',
+  `counterparty` varchar(255) NOT NULL DEFAULT '',
+  `frequency` varchar(10) NOT NULL,
+  `repeat_interval` bigint NOT NULL DEFAULT '1',
+  `day_of_month` bigint NOT NULL DEFAULT '0',
+  `rrule` varchar(255) NOT NULL DEFAULT '',
+  `max_runs` bigint NOT NULL DEFAULT '0',
+  `start_at` datetime(3) NOT NULL,
+  `next_run_at` datetime(3) NOT NULL,
+  `last_run_at` datetime(3) DEFAULT NULL,
+  `run_count` bigint NOT NULL DEFAULT '0',
+  `status` varchar(10) NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_schedules_user_id` (`user_id`),
+  KEY `idx_schedules_next_run_at` (`next_run_at`),
+  KEY `idx_schedules_status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE IF NOT EXISTS `schedule_runs` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT,
+  `schedule_id` int unsigned NOT NULL,
+  `idempotency_key` varchar(100) NOT NULL,
+  `scheduled_at` datetime(3) NOT NULL,
+  `status` varchar(10) NOT NULL,
+  `transaction_id` int unsigned NOT NULL DEFAULT '0',
+  `error` varchar(255) NOT NULL DEFAULT '',
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_schedule_runs_idempotency_key` (`idempotency_key`),
+  KEY `idx_schedule_runs_schedule_id` (`schedule_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
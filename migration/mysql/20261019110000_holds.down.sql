@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS `holds`;
+
+ALTER TABLE `accounts`
+  DROP COLUMN `held_amount`;
@@ -0,0 +1,34 @@
+--
+-- Held amount on accounts and table `holds`
+-- tables, columns and indexes which AutoMigrate of builds before migrations already created are kept
+-- previous-checksum: 191909f59142f0dd510ebfcba62feeaa7787238abb5f106fa05b90a72a24b216
+--
+
+SET @ddl = IF((SELECT COUNT(*) FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'accounts' AND column_name = 'held_amount') = 0,
+  'ALTER TABLE `accounts` ADD COLUMN `held_amount` float NOT NULL DEFAULT ''0'' AFTER `balance`',
+  'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+CREATE TABLE IF NOT EXISTS `holds` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` int unsigned NOT NULL,
+  `account_id` int unsigned NOT NULL,
+  `amount` float NOT NULL,
+  `memo` varchar(255) NOT NULL DEFAULT '',
+  `counterparty` varchar(255) NOT NULL DEFAULT '',
+  `status` varchar(10) NOT NULL,
+  `captured_amount` float NOT NULL DEFAULT '0',
+  `transaction_id` int unsigned NOT NULL DEFAULT '0',
+  `expires_at` datetime(3) NOT NULL,
+  `closed_at` datetime(3) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_holds_user_id` (`user_id`),
+  KEY `idx_holds_account_id` (`account_id`),
+  KEY `idx_holds_status` (`status`),
+  KEY `idx_holds_expires_at` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS `overdraft_interest_postings`;
+
+-- fails while overdraft_interest transactions exist
+ALTER TABLE `transactions`
+  MODIFY COLUMN `transaction_type` varchar(15) NOT NULL;
+
+ALTER TABLE `accounts`
+  DROP COLUMN `overdraft_limit`,
+  DROP COLUMN `overdraft_approved`,
+  DROP COLUMN `overdraft_rate`;
@@ -0,0 +1,46 @@
+--
+-- Overdraft facility on accounts and table `overdraft_interest_postings`
+-- tables, columns and indexes which AutoMigrate of builds before migrations already created are kept
+-- previous-checksum: 9deb02a7d21bab7de720f5cd1a211b3cb1e5740e2d8c27e91d8a368a57591364
+--
+
+SET @ddl = IF((SELECT COUNT(*) FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'accounts' AND column_name = 'overdraft_limit') = 0,
+  'ALTER TABLE `accounts` ADD COLUMN `overdraft_limit` float NOT NULL DEFAULT ''0'' AFTER `held_amount`',
+  'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @ddl = IF((SELECT COUNT(*) FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'accounts' AND column_name = 'overdraft_approved') = 0,
+  'ALTER TABLE `accounts` ADD COLUMN `overdraft_approved` tinyint(1) NOT NULL DEFAULT ''0'' AFTER `overdraft_limit`',
+  'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @ddl = IF((SELECT COUNT(*) FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'accounts' AND column_name = 'overdraft_rate') = 0,
+  'ALTER TABLE `accounts` ADD COLUMN `overdraft_rate` float NOT NULL DEFAULT ''0'' AFTER `overdraft_approved`',
+  'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+-- overdraft_interest does not fit varchar(15)
+ALTER TABLE `transactions`
+  MODIFY COLUMN `transaction_type` varchar(30) NOT NULL;
+
+CREATE TABLE IF NOT EXISTS `overdraft_interest_postings` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT,
+  `account_id` int unsigned NOT NULL,
+  `accrual_date` char(10) NOT NULL,
+  `balance` float NOT NULL,
+  `rate` float NOT NULL,
+  `amount` float NOT NULL,
+  `transaction_id` int unsigned NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_overdraft_interest_account_date` (`account_id`, `accrual_date`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
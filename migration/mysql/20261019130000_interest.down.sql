@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS `interest_postings`;
+DROP TABLE IF EXISTS `interest_accruals`;
+DROP TABLE IF EXISTS `account_interests`;
+DROP TABLE IF EXISTS `interest_products`;
@@ -0,0 +1,52 @@
+--
+-- Interest products, account assignment, daily accruals and monthly postings
+-- tables, columns and indexes which AutoMigrate of builds before migrations already created are kept
+-- previous-checksum: 4fd3350671d94e02b4f17644b13360ed9f1f08f838fbc6f48bf8ac2db0964fbf
+--
+
+CREATE TABLE IF NOT EXISTS `interest_products` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) NOT NULL,
+  `rate` float NOT NULL,
+  `day_count` varchar(10) NOT NULL,
+  `compounding` varchar(10) NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE IF NOT EXISTS `account_interests` (
+  `account_id` int unsigned NOT NULL,
+  `product_id` int unsigned NOT NULL,
+  `last_accrued_date` char(10) NOT NULL,
+  `accrued_micro` bigint NOT NULL DEFAULT '0',
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`account_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE IF NOT EXISTS `interest_accruals` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT,
+  `account_id` int unsigned NOT NULL,
+  `accrual_date` char(10) NOT NULL,
+  `base` double NOT NULL,
+  `rate` float NOT NULL,
+  `amount_micro` bigint NOT NULL,
+  `posting_id` int unsigned NOT NULL DEFAULT '0',
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_interest_accruals_account_date` (`account_id`, `accrual_date`),
+  KEY `idx_interest_accruals_posting_id` (`posting_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE IF NOT EXISTS `interest_postings` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT,
+  `account_id` int unsigned NOT NULL,
+  `month` char(7) NOT NULL,
+  `amount_micro` bigint NOT NULL,
+  `amount` float NOT NULL,
+  `transaction_id` int unsigned NOT NULL DEFAULT '0',
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_interest_postings_account_month` (`account_id`, `month`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS `fee_schedules`;
+
+ALTER TABLE `transactions`
+  DROP KEY `idx_transactions_parent_transaction_id`,
+  DROP COLUMN `parent_transaction_id`;
@@ -0,0 +1,40 @@
+--
+-- Fee schedules and link of fee transactions to transaction they are charged for
+-- tables, columns and indexes which AutoMigrate of builds before migrations already created are kept
+-- previous-checksum: 9f029a596ba6fb89454ed232efb9f83829d86a0d26486e668db46723a2619911
+--
+
+SET @ddl = IF((SELECT COUNT(*) FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'transactions' AND column_name = 'parent_transaction_id') = 0,
+  'ALTER TABLE `transactions` ADD COLUMN `parent_transaction_id` int unsigned NOT NULL DEFAULT ''0''',
+  'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @ddl = IF((SELECT COUNT(*) FROM information_schema.statistics
+    WHERE table_schema = DATABASE() AND table_name = 'transactions' AND index_name = 'idx_transactions_parent_transaction_id') = 0,
+  'ALTER TABLE `transactions` ADD KEY `idx_transactions_parent_transaction_id` (`parent_transaction_id`)',
+  'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+CREATE TABLE IF NOT EXISTS `fee_schedules` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) NOT NULL,
+  `transaction_type` varchar(30) NOT NULL,
+  `bank` char(3) NOT NULL DEFAULT '',
+  `min_amount` float NOT NULL DEFAULT '0',
+  `max_amount` float NOT NULL DEFAULT '0',
+  `flat_fee` float NOT NULL DEFAULT '0',
+  `percentage` float NOT NULL DEFAULT '0',
+  `min_fee` float NOT NULL DEFAULT '0',
+  `max_fee` float NOT NULL DEFAULT '0',
+  `free_quota_per_month` bigint NOT NULL DEFAULT '0',
+  `active` tinyint(1) NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_fee_schedules_transaction_type` (`transaction_type`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
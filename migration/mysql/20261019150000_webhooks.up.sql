@@ -0,0 +1,49 @@
+--
+-- Webhook subscriptions, persistent delivery queue and delivery log
+-- tables, columns and indexes which AutoMigrate of builds before migrations already created are kept
+-- previous-checksum: 04465ef80f3aaaf9179a8d2bf8aa9156195ac1d5ce702e910469dc8650ee6444
+--
+
+CREATE TABLE IF NOT EXISTS `webhook_subscriptions` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` int unsigned NOT NULL,
+  `url` varchar(500) NOT NULL,
+  `event_types` varchar(255) NOT NULL,
+  `secret` varchar(100) NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_webhook_subscriptions_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE IF NOT EXISTS `webhook_deliveries` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT,
+  `subscription_id` int unsigned NOT NULL,
+  `user_id` int unsigned NOT NULL,
+  `event_id` char(32) NOT NULL,
+  `event_type` varchar(50) NOT NULL,
+  `payload` text NOT NULL,
+  `status` varchar(10) NOT NULL,
+  `attempts` bigint NOT NULL DEFAULT '0',
+  `next_attempt_at` datetime(3) NOT NULL,
+  `last_status_code` bigint NOT NULL DEFAULT '0',
+  `last_error` varchar(500) NOT NULL DEFAULT '',
+  `delivered_at` datetime(3) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_webhook_deliveries_subscription_id` (`subscription_id`),
+  KEY `idx_webhook_deliveries_due` (`status`, `next_attempt_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE IF NOT EXISTS `webhook_attempts` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT,
+  `delivery_id` int unsigned NOT NULL,
+  `attempt` bigint NOT NULL,
+  `status_code` bigint NOT NULL DEFAULT '0',
+  `error` varchar(500) NOT NULL DEFAULT '',
+  `duration_ms` bigint NOT NULL DEFAULT '0',
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_webhook_attempts_delivery_id` (`delivery_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
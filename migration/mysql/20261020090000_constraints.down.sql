@@ -0,0 +1,8 @@
+ALTER TABLE `transactions`
+  DROP FOREIGN KEY `fk_transactions_account_id`;
+
+ALTER TABLE `transactions`
+  DROP KEY `idx_transactions_account_id`;
+
+ALTER TABLE `accounts`
+  DROP KEY `idx_accounts_user_id`;
@@ -0,0 +1,33 @@
+--
+-- Indexes and foreign keys which queries and deletes rely on
+-- database created by AutoMigrate already has indexes of model tags with same names, they are added only when missing
+-- orphan transactions are reported before this version runs (MigrateUp), foreign key is added only when missing
+-- previous-checksum: 128549fab6770ee29f111e7f85d32fa94f0390e4ba440e942405ba9df8cea80f
+-- previous-checksum: fd04af52b8c1c5b75833c5a2d07a569c782bafd0cb1d18c9efa1274761f1bd90
+--
+
+-- accounts of user (transactions by user, user show)
+SET @ddl = IF((SELECT COUNT(*) FROM information_schema.statistics
+    WHERE table_schema = DATABASE() AND table_name = 'accounts' AND index_name = 'idx_accounts_user_id') = 0,
+  'ALTER TABLE `accounts` ADD KEY `idx_accounts_user_id` (`user_id`)',
+  'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+-- every transaction belong to an account, accounts are only soft deleted
+SET @ddl = IF((SELECT COUNT(*) FROM information_schema.statistics
+    WHERE table_schema = DATABASE() AND table_name = 'transactions' AND index_name = 'idx_transactions_account_id') = 0,
+  'ALTER TABLE `transactions` ADD KEY `idx_transactions_account_id` (`account_id`)',
+  'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @ddl = IF((SELECT COUNT(*) FROM information_schema.referential_constraints
+    WHERE constraint_schema = DATABASE() AND table_name = 'transactions' AND constraint_name = 'fk_transactions_account_id') = 0,
+  'ALTER TABLE `transactions` ADD CONSTRAINT `fk_transactions_account_id` FOREIGN KEY (`account_id`) REFERENCES `accounts` (`id`)',
+  'DO 0');
+PREPARE stmt FROM @ddl;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
@@ -0,0 +1,9 @@
+--
+-- Reversal time of transactions, balance at end of a past day count reversals made after that day
+-- transactions reversed before this version take updated_at, last change of row
+--
+
+ALTER TABLE `transactions`
+  ADD COLUMN `reversed_at` datetime(3) DEFAULT NULL AFTER `deleted`;
+
+UPDATE `transactions` SET `reversed_at` = `updated_at` WHERE `deleted` = 1;
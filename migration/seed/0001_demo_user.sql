@@ -0,0 +1,18 @@
+--
+-- Demo user 1 with three accounts, safe to run again
+--
+
+INSERT IGNORE INTO `users` (`id`, `first_name`, `last_name`, `created_at`, `updated_at`, `deleted`) VALUES
+(1, 'THAI', 'LE', NOW(3), NOW(3), 0);
+
+INSERT IGNORE INTO `accounts` (`id`, `bank`, `balance`, `name`, `user_id`, `created_at`, `updated_at`) VALUES
+(1, 'VIB', 10000, '', 1, NOW(3), NOW(3)),
+(2, 'ACB', 500000, '', 1, NOW(3), NOW(3)),
+(3, 'VCB', 300000, '', 1, NOW(3), NOW(3));
+
+-- opening balance as deposit so seeded accounts reconcile, only for accounts without transactions
+INSERT INTO `transactions` (`account_id`, `amount`, `transaction_type`, `memo`, `created_at`, `updated_at`, `deleted`)
+SELECT `accounts`.`id`, `accounts`.`balance`, 'deposit', 'opening balance', NOW(3), NOW(3), 0
+FROM `accounts`
+WHERE `accounts`.`id` IN (1, 2, 3)
+  AND NOT EXISTS (SELECT 1 FROM `transactions` WHERE `transactions`.`account_id` = `accounts`.`id`);
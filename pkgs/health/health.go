@@ -0,0 +1,104 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+	// non critical dependency is down, service still serve requests
+	StatusDegraded Status = "degraded"
+)
+
+type Probe func(ctx context.Context) error
+
+type Check struct {
+	Name string
+	// failed critical check make service not ready, others make it degraded
+	Critical bool
+	Probe    Probe
+}
+
+type Result struct {
+	Name      string  `json:"name"`
+	Status    Status  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status Status    `json:"status"`
+	Checks []*Result `json:"checks"`
+}
+
+// Ready is false only when a critical check failed
+func (r *Report) Ready() bool {
+	return r.Status != StatusDown
+}
+
+// Run probe every check at same time, each one within timeout
+// results keep order of checks
+func Run(ctx context.Context, timeout time.Duration, checks ...Check) *Report {
+	report := &Report{
+		Status: StatusUp,
+		Checks: make([]*Result, len(checks)),
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(checks))
+	for i, check := range checks {
+		go func(i int, check Check) {
+			defer wg.Done()
+			report.Checks[i] = probe(ctx, timeout, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status == StatusUp {
+			continue
+		}
+		if result.Critical {
+			report.Status = StatusDown
+		} else if report.Status == StatusUp {
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+func probe(ctx context.Context, timeout time.Duration, check Check) *Result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result := &Result{
+		Name:     check.Name,
+		Status:   StatusUp,
+		Critical: check.Critical,
+	}
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- check.Probe(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		// probe which ignore ctx does not block report
+		err = ctx.Err()
+	}
+	result.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func up(ctx context.Context) error {
+	return nil
+}
+
+func down(ctx context.Context) error {
+	return errors.New("connection refused")
+}
+
+func hang(ctx context.Context) error {
+	time.Sleep(time.Second)
+	return nil
+}
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		name   string
+		checks []Check
+		status Status
+		ready  bool
+	}{
+		{
+			name:   "all up",
+			checks: []Check{{Name: "mysql", Critical: true, Probe: up}, {Name: "redis", Probe: up}},
+			status: StatusUp,
+			ready:  true,
+		},
+		{
+			name:   "non critical down",
+			checks: []Check{{Name: "mysql", Critical: true, Probe: up}, {Name: "redis", Probe: down}},
+			status: StatusDegraded,
+			ready:  true,
+		},
+		{
+			name:   "critical down",
+			checks: []Check{{Name: "mysql", Critical: true, Probe: down}, {Name: "redis", Probe: down}},
+			status: StatusDown,
+			ready:  false,
+		},
+		{
+			name:   "critical timeout",
+			checks: []Check{{Name: "mysql", Critical: true, Probe: hang}},
+			status: StatusDown,
+			ready:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Now()
+			report := Run(context.Background(), 50*time.Millisecond, tc.checks...)
+			if time.Since(start) > 500*time.Millisecond {
+				t.Errorf("report waited for hanging probe")
+			}
+			if report.Status != tc.status || report.Ready() != tc.ready {
+				t.Fatalf("got %s ready %v want %s ready %v", report.Status, report.Ready(), tc.status, tc.ready)
+			}
+			for i, result := range report.Checks {
+				if result.Name != tc.checks[i].Name {
+					t.Errorf("result %d is %s want %s", i, result.Name, tc.checks[i].Name)
+				}
+				if (result.Status == StatusDown) != (result.Error != "") {
+					t.Errorf("%s: status %s with error %q", result.Name, result.Status, result.Error)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package migrate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestSplitStatements(t *testing.T) {
+	testCases := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{
+			name:   "comments and blank",
+			script: "-- header\n\nCREATE TABLE a (id int);\n# mysql comment\n/* block; */ DROP TABLE b;\n",
+			want:   []string{"CREATE TABLE a (id int)", "DROP TABLE b"},
+		},
+		{
+			name:   "delimiter in quotes",
+			script: "INSERT INTO a VALUES ('x;y', \"it''s;\", 'a\\'b;');SELECT `c;d` FROM a",
+			want:   []string{"INSERT INTO a VALUES ('x;y', \"it''s;\", 'a\\'b;')", "SELECT `c;d` FROM a"},
+		},
+		{
+			name:   "comment marker in quotes",
+			script: "INSERT INTO a VALUES ('-- not comment', '# no');",
+			want:   []string{"INSERT INTO a VALUES ('-- not comment', '# no')"},
+		},
+		{
+			name:   "empty",
+			script: "  -- only comment\n ; ;",
+			want:   []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SplitStatements(tc.script)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %q want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(fstest.MapFS{
+		"2_accounts.up.sql":   {Data: []byte("CREATE TABLE accounts (id int);")},
+		"2_accounts.down.sql": {Data: []byte("DROP TABLE accounts;")},
+		"1_users.up.sql":      {Data: []byte("CREATE TABLE users (id int);")},
+		"1_users.down.sql":    {Data: []byte("DROP TABLE users;")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 2 || migrations[0].Version != 1 || migrations[1].Name != "accounts" {
+		t.Fatalf("unexpected migrations %+v", migrations)
+	}
+	if migrations[0].Checksum == "" || migrations[0].Checksum == migrations[1].Checksum {
+		t.Errorf("unexpected checksums %q %q", migrations[0].Checksum, migrations[1].Checksum)
+	}
+
+	previous := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	edited, err := Load(fstest.MapFS{
+		"1_users.up.sql":   {Data: []byte("-- previous-checksum: " + previous + "\nCREATE TABLE IF NOT EXISTS users (id int);")},
+		"1_users.down.sql": {Data: []byte("DROP TABLE users;")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(edited[0].PreviousChecksums, []string{previous}) {
+		t.Errorf("unexpected previous checksums %q", edited[0].PreviousChecksums)
+	}
+
+	invalids := map[string]fstest.MapFS{
+		"missing down": {"1_users.up.sql": {Data: []byte("x")}},
+		"bad name":     {"users.up.sql": {Data: []byte("x")}, "users.down.sql": {Data: []byte("x")}},
+		"two names": {
+			"1_users.up.sql":    {Data: []byte("x")},
+			"1_people.down.sql": {Data: []byte("x")},
+		},
+	}
+	for name, fsys := range invalids {
+		_, err := Load(fsys)
+		if err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func testMigrations() []*Migration {
+	return []*Migration{
+		{Version: 1, Name: "users", Checksum: "a"},
+		{Version: 2, Name: "accounts", Checksum: "b"},
+		{Version: 3, Name: "transactions", Checksum: "c", PreviousChecksums: []string{"old"}},
+	}
+}
+
+func TestPlan(t *testing.T) {
+	migrations := testMigrations()
+
+	pending, err := Plan(migrations, []*Applied{{Version: 1, Checksum: "a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 || pending[0].Version != 2 || pending[1].Version != 3 {
+		t.Errorf("unexpected pending %+v", pending)
+	}
+
+	// version applied with earlier content of file
+	pending, err = Plan(migrations, []*Applied{{Version: 1, Checksum: "a"}, {Version: 2, Checksum: "b"}, {Version: 3, Checksum: "old"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("unexpected pending %+v", pending)
+	}
+
+	testCases := []struct {
+		name    string
+		applied []*Applied
+		err     error
+	}{
+		{name: "dirty", applied: []*Applied{{Version: 1, Checksum: "a", Dirty: true}}, err: ErrDirty},
+		{name: "edited", applied: []*Applied{{Version: 1, Checksum: "changed"}}, err: ErrChecksumMismatch},
+		{name: "newer database", applied: []*Applied{{Version: 4, Checksum: "d"}}, err: ErrUnknownVersion},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Plan(migrations, tc.applied)
+			if !errors.Is(err, tc.err) {
+				t.Errorf("got %v want %v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestMergeStatus(t *testing.T) {
+	statuses := mergeStatus(testMigrations(), []*Applied{
+		{Version: 1, Checksum: "a"},
+		{Version: 2, Checksum: "changed"},
+		{Version: 9, Name: "future", Checksum: "z"},
+	})
+
+	want := []string{StateApplied, StateChanged, StatePending, StateUnknown}
+	if len(statuses) != len(want) {
+		t.Fatalf("got %d statuses want %d", len(statuses), len(want))
+	}
+	for i, status := range statuses {
+		if status.State != want[i] {
+			t.Errorf("version %d: got %s want %s", status.Version, status.State, want[i])
+		}
+	}
+	if statuses[2].AppliedAt != nil {
+		t.Errorf("pending migration has applied_at")
+	}
+}
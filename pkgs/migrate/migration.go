@@ -0,0 +1,167 @@
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// file name is <version>_<name>.up.sql or <version>_<name>.down.sql
+// version is any increasing number, this repo use timestamp yyyymmddhhmmss
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// line "-- previous-checksum: <sha256>" of up file accept checksum of its earlier content
+// only for edits which are safe on databases where earlier content was applied
+var previousChecksumPattern = regexp.MustCompile(`(?m)^--\s*previous-checksum:\s*([0-9a-f]{64})\s*$`)
+
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+	// sha256 of up script, applied migration must not change
+	Checksum string
+	// checksums of earlier up script which are still accepted
+	PreviousChecksums []string
+}
+
+// Matches report whether checksum of applied version is current or accepted previous one
+func (m *Migration) Matches(checksum string) bool {
+	if m.Checksum == checksum {
+		return true
+	}
+	for _, previous := range m.PreviousChecksums {
+		if previous == checksum {
+			return true
+		}
+	}
+	return false
+}
+
+// Load read migrations of directory, sorted by version
+// every version must have both up and down file
+func Load(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[uint64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration file %q does not match <version>_<name>.(up|down).sql", entry.Name())
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration file %q: %w", entry.Name(), err)
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d has two names %q and %q", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+			migration.Checksum = checksum(content)
+			for _, match := range previousChecksumPattern.FindAllStringSubmatch(migration.Up, -1) {
+				migration.PreviousChecksums = append(migration.PreviousChecksums, match[1])
+			}
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s need both up and down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// SplitStatements split script on ; outside of quotes and comments
+// driver run one statement per exec (no multiStatements in dsn)
+func SplitStatements(script string) []string {
+	statements := []string{}
+	current := []rune{}
+	runes := []rune(script)
+
+	flush := func() {
+		statement := strings.TrimSpace(string(current))
+		if statement != "" {
+			statements = append(statements, statement)
+		}
+		current = current[:0]
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\'' || r == '"' || r == '`':
+			// copy quoted text, backslash and doubled quote escape quote
+			current = append(current, r)
+			for i++; i < len(runes); i++ {
+				current = append(current, runes[i])
+				if runes[i] == '\\' && r != '`' && i+1 < len(runes) {
+					i++
+					current = append(current, runes[i])
+					continue
+				}
+				if runes[i] == r {
+					if i+1 < len(runes) && runes[i+1] == r {
+						i++
+						current = append(current, runes[i])
+						continue
+					}
+					break
+				}
+			}
+		case r == '#' || (r == '-' && i+1 < len(runes) && runes[i+1] == '-'):
+			// line comment is dropped
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			current = append(current, '\n')
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			// block comment is dropped
+			for i += 2; i < len(runes) && !(runes[i] == '*' && i+1 < len(runes) && runes[i+1] == '/'); i++ {
+			}
+			i++
+			current = append(current, ' ')
+		case r == ';':
+			flush()
+		default:
+			current = append(current, r)
+		}
+	}
+	flush()
+	return statements
+}
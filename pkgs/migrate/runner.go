@@ -0,0 +1,400 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrDirty migration failed in middle, mysql ddl is not transactional so database must be fixed by hand
+	ErrDirty = errors.New("database is dirty")
+	// ErrChecksumMismatch applied migration file was edited
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	// ErrUnknownVersion database was migrated by newer code
+	ErrUnknownVersion = errors.New("unknown applied version")
+	// ErrLocked other runner hold the lock longer than lock timeout
+	ErrLocked = errors.New("migration lock is held")
+)
+
+const createTableSQL = "CREATE TABLE IF NOT EXISTS `schema_migrations` (" +
+	"`version` bigint unsigned NOT NULL, " +
+	"`name` varchar(255) NOT NULL, " +
+	"`checksum` char(64) NOT NULL, " +
+	"`dirty` tinyint(1) NOT NULL DEFAULT '0', " +
+	"`applied_at` datetime(3) NOT NULL, " +
+	"PRIMARY KEY (`version`)" +
+	") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci"
+
+type Applied struct {
+	Version   uint64
+	Name      string
+	Checksum  string
+	Dirty     bool
+	AppliedAt time.Time
+}
+
+const (
+	StatePending = "pending"
+	StateApplied = "applied"
+	StateDirty   = "dirty"
+	// file changed after it was applied
+	StateChanged = "changed"
+	// applied but no file, database is newer than code
+	StateUnknown = "unknown"
+)
+
+type Status struct {
+	Version   uint64
+	Name      string
+	State     string
+	AppliedAt *time.Time
+}
+
+type Option struct {
+	// mysql named lock, runners with same name wait for each other
+	LockName    string
+	LockTimeout time.Duration
+}
+
+// Runner apply migrations to mysql, one runner at a time by GET_LOCK
+type Runner struct {
+	db         *sql.DB
+	migrations []*Migration
+	option     Option
+	logger     *zap.Logger
+}
+
+func NewRunner(db *sql.DB, migrations []*Migration, logger *zap.Logger, option Option) *Runner {
+	if option.LockName == "" {
+		option.LockName = "schema_migrations"
+	}
+	if option.LockTimeout == 0 {
+		option.LockTimeout = time.Minute
+	}
+	return &Runner{
+		db:         db,
+		migrations: migrations,
+		option:     option,
+		logger:     logger,
+	}
+}
+
+// Up apply every pending migration in version order, return applied ones
+func (r *Runner) Up(ctx context.Context) ([]*Migration, error) {
+	var done []*Migration
+	err := r.withLock(ctx, func(conn *sql.Conn, applied []*Applied) error {
+		pending, err := Plan(r.migrations, applied)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range pending {
+			err = r.apply(ctx, conn, migration, migration.Up, true)
+			if err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down revert last steps applied migrations, newest first
+func (r *Runner) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	var done []*Migration
+	err := r.withLock(ctx, func(conn *sql.Conn, applied []*Applied) error {
+		_, err := Plan(r.migrations, applied)
+		if err != nil {
+			return err
+		}
+
+		byVersion := r.byVersion()
+		for i := len(applied) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := byVersion[applied[i].Version]
+			err = r.apply(ctx, conn, migration, migration.Down, false)
+			if err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status of every known and applied migration, by version
+func (r *Runner) Status(ctx context.Context) ([]*Status, error) {
+	var statuses []*Status
+	err := r.withLock(ctx, func(conn *sql.Conn, applied []*Applied) error {
+		statuses = mergeStatus(r.migrations, applied)
+		return nil
+	})
+	return statuses, err
+}
+
+// Baseline mark migrations up to version as applied without running them
+// for database created before schema_migrations existed (docker init scripts, AutoMigrate)
+func (r *Runner) Baseline(ctx context.Context, version uint64) error {
+	return r.withLock(ctx, func(conn *sql.Conn, applied []*Applied) error {
+		if len(applied) != 0 {
+			return fmt.Errorf("baseline need empty schema_migrations, %d versions are applied", len(applied))
+		}
+		return r.baseline(ctx, conn, version)
+	})
+}
+
+// BaselineLegacy baseline up to version when schema_migrations is empty but table exists
+// database created before migrations is detected by its table, new database is migrated from first version
+func (r *Runner) BaselineLegacy(ctx context.Context, version uint64, table string) (bool, error) {
+	baselined := false
+	err := r.withLock(ctx, func(conn *sql.Conn, applied []*Applied) error {
+		if len(applied) != 0 {
+			return nil
+		}
+		var tables int
+		err := conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?", table).Scan(&tables)
+		if err != nil || tables == 0 {
+			return err
+		}
+
+		err = r.baseline(ctx, conn, version)
+		if err != nil {
+			return err
+		}
+		baselined = true
+		return nil
+	})
+	return baselined, err
+}
+
+func (r *Runner) baseline(ctx context.Context, conn *sql.Conn, version uint64) error {
+	if _, ok := r.byVersion()[version]; !ok {
+		return fmt.Errorf("%w: baseline version %d", ErrUnknownVersion, version)
+	}
+
+	for _, migration := range r.migrations {
+		if migration.Version > version {
+			break
+		}
+		err := r.record(ctx, conn, migration, false)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Force clear dirty flag of version after database was fixed by hand
+// version is kept as applied, run down to revert it
+func (r *Runner) Force(ctx context.Context, version uint64) error {
+	return r.withLock(ctx, func(conn *sql.Conn, applied []*Applied) error {
+		result, err := conn.ExecContext(ctx, "UPDATE `schema_migrations` SET `dirty` = 0 WHERE `version` = ? AND `dirty` = 1", version)
+		if err != nil {
+			return err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return fmt.Errorf("version %d is not dirty", version)
+		}
+		return nil
+	})
+}
+
+// Plan verify applied migrations against files and return pending ones
+func Plan(migrations []*Migration, applied []*Applied) ([]*Migration, error) {
+	byVersion := map[uint64]*Migration{}
+	for _, migration := range migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	appliedVersions := map[uint64]bool{}
+	for _, appliedMigration := range applied {
+		if appliedMigration.Dirty {
+			return nil, fmt.Errorf("%w: version %d %s failed, fix database then force it", ErrDirty, appliedMigration.Version, appliedMigration.Name)
+		}
+		migration, ok := byVersion[appliedMigration.Version]
+		if !ok {
+			return nil, fmt.Errorf("%w: version %d %s", ErrUnknownVersion, appliedMigration.Version, appliedMigration.Name)
+		}
+		if !migration.Matches(appliedMigration.Checksum) {
+			return nil, fmt.Errorf("%w: version %d %s was edited after it was applied", ErrChecksumMismatch, migration.Version, migration.Name)
+		}
+		appliedVersions[appliedMigration.Version] = true
+	}
+
+	pending := []*Migration{}
+	for _, migration := range migrations {
+		if !appliedVersions[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+func mergeStatus(migrations []*Migration, applied []*Applied) []*Status {
+	appliedByVersion := map[uint64]*Applied{}
+	for _, appliedMigration := range applied {
+		appliedByVersion[appliedMigration.Version] = appliedMigration
+	}
+
+	statuses := []*Status{}
+	known := map[uint64]bool{}
+	for _, migration := range migrations {
+		known[migration.Version] = true
+		status := &Status{Version: migration.Version, Name: migration.Name, State: StatePending}
+		appliedMigration, ok := appliedByVersion[migration.Version]
+		if ok {
+			status.AppliedAt = &appliedMigration.AppliedAt
+			switch {
+			case appliedMigration.Dirty:
+				status.State = StateDirty
+			case !migration.Matches(appliedMigration.Checksum):
+				status.State = StateChanged
+			default:
+				status.State = StateApplied
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	for _, appliedMigration := range applied {
+		if known[appliedMigration.Version] {
+			continue
+		}
+		state := StateUnknown
+		if appliedMigration.Dirty {
+			state = StateDirty
+		}
+		statuses = append(statuses, &Status{
+			Version:   appliedMigration.Version,
+			Name:      appliedMigration.Name,
+			State:     state,
+			AppliedAt: &appliedMigration.AppliedAt,
+		})
+	}
+	return statuses
+}
+
+func (r *Runner) byVersion() map[uint64]*Migration {
+	byVersion := map[uint64]*Migration{}
+	for _, migration := range r.migrations {
+		byVersion[migration.Version] = migration
+	}
+	return byVersion
+}
+
+// withLock run fn on one connection holding named lock, GET_LOCK belong to connection
+func (r *Runner) withLock(ctx context.Context, fn func(conn *sql.Conn, applied []*Applied) error) error {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	err = conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", r.option.LockName, int(r.option.LockTimeout.Seconds())).Scan(&locked)
+	if err != nil {
+		return err
+	}
+	if !locked.Valid || locked.Int64 != 1 {
+		return fmt.Errorf("%w: %q after %v", ErrLocked, r.option.LockName, r.option.LockTimeout)
+	}
+	defer func() {
+		// lock is also released when connection is closed
+		_, err := conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", r.option.LockName)
+		if err != nil {
+			r.logger.Warn("[Migrate-ReleaseLock]", zap.String("Error", err.Error()))
+		}
+	}()
+
+	_, err = conn.ExecContext(ctx, createTableSQL)
+	if err != nil {
+		return err
+	}
+
+	applied, err := r.applied(ctx, conn)
+	if err != nil {
+		return err
+	}
+	return fn(conn, applied)
+}
+
+func (r *Runner) applied(ctx context.Context, conn *sql.Conn) ([]*Applied, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT `version`, `name`, `checksum`, `dirty`, `applied_at` FROM `schema_migrations` ORDER BY `version`")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := []*Applied{}
+	for rows.Next() {
+		appliedMigration := &Applied{}
+		err = rows.Scan(&appliedMigration.Version, &appliedMigration.Name, &appliedMigration.Checksum, &appliedMigration.Dirty, &appliedMigration.AppliedAt)
+		if err != nil {
+			return nil, err
+		}
+		applied = append(applied, appliedMigration)
+	}
+	return applied, rows.Err()
+}
+
+// apply mark version dirty, run script, then record result
+// dirty flag stay when script fail in middle, ddl already run can not be rolled back
+func (r *Runner) apply(ctx context.Context, conn *sql.Conn, migration *Migration, script string, up bool) error {
+	start := time.Now()
+	var err error
+	if up {
+		err = r.record(ctx, conn, migration, true)
+	} else {
+		_, err = conn.ExecContext(ctx, "UPDATE `schema_migrations` SET `dirty` = 1 WHERE `version` = ?", migration.Version)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range SplitStatements(script) {
+		_, err = conn.ExecContext(ctx, statement)
+		if err != nil {
+			return fmt.Errorf("%w: version %d %s: %s", ErrDirty, migration.Version, migration.Name, err.Error())
+		}
+	}
+
+	if up {
+		_, err = conn.ExecContext(ctx, "UPDATE `schema_migrations` SET `dirty` = 0, `applied_at` = ? WHERE `version` = ?", time.Now().UTC(), migration.Version)
+	} else {
+		_, err = conn.ExecContext(ctx, "DELETE FROM `schema_migrations` WHERE `version` = ?", migration.Version)
+	}
+	if err != nil {
+		return err
+	}
+
+	r.logger.Info("[Migrate]", zap.Uint64("Version", migration.Version), zap.String("Name", migration.Name),
+		zap.Bool("Up", up), zap.Duration("Duration", time.Since(start)))
+	return nil
+}
+
+func (r *Runner) record(ctx context.Context, conn *sql.Conn, migration *Migration, dirty bool) error {
+	_, err := conn.ExecContext(ctx, "INSERT INTO `schema_migrations` (`version`, `name`, `checksum`, `dirty`, `applied_at`) VALUES (?, ?, ?, ?, ?)",
+		migration.Version, migration.Name, migration.Checksum, dirty, time.Now().UTC())
+	return err
+}
+
+// RunScript run statements of script in order, for seed files which are safe to run again
+func RunScript(ctx context.Context, db *sql.DB, script string) error {
+	for _, statement := range SplitStatements(script) {
+		_, err := db.ExecContext(ctx, statement)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
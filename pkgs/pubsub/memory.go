@@ -0,0 +1,97 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// subscriberBuffer is how many messages subscriber can be behind before it is dropped
+const subscriberBuffer = 64
+
+type memoryTopic struct {
+	lastId      uint64
+	history     []*Message
+	subscribers map[chan *Message]struct{}
+}
+
+// memoryBroker is in process broker, for single instance
+type memoryBroker struct {
+	mu          sync.Mutex
+	historySize int
+	topics      map[string]*memoryTopic
+}
+
+func NewMemoryBroker(historySize int) Broker {
+	return &memoryBroker{
+		historySize: historySize,
+		topics:      make(map[string]*memoryTopic),
+	}
+}
+
+func (m *memoryBroker) topic(name string) *memoryTopic {
+	t, ok := m.topics[name]
+	if !ok {
+		t = &memoryTopic{subscribers: make(map[chan *Message]struct{})}
+		m.topics[name] = t
+	}
+	return t
+}
+
+func (m *memoryBroker) Publish(ctx context.Context, topic string, msgType string, data []byte) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t := m.topic(topic)
+	t.lastId++
+	msg := &Message{Id: t.lastId, Type: msgType, Data: data}
+
+	t.history = append(t.history, msg)
+	if len(t.history) > m.historySize {
+		t.history = t.history[len(t.history)-m.historySize:]
+	}
+
+	for ch := range t.subscribers {
+		select {
+		case ch <- msg:
+		default:
+			// never block publisher, slow subscriber resume from history
+			delete(t.subscribers, ch)
+			close(ch)
+		}
+	}
+	return msg.Id, nil
+}
+
+func (m *memoryBroker) Subscribe(ctx context.Context, topic string) (*Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ch := make(chan *Message, subscriberBuffer)
+	m.topic(topic).subscribers[ch] = struct{}{}
+
+	return &Subscription{
+		C: ch,
+		cancel: func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			t := m.topic(topic)
+			if _, ok := t.subscribers[ch]; ok {
+				delete(t.subscribers, ch)
+				close(ch)
+			}
+		},
+	}, nil
+}
+
+func (m *memoryBroker) Since(ctx context.Context, topic string, afterId uint64) ([]*Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	messages := []*Message{}
+	for _, msg := range m.topic(topic).history {
+		if msg.Id > afterId {
+			messages = append(messages, msg)
+		}
+	}
+	return messages, nil
+}
@@ -0,0 +1,52 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// Message id is increasing per topic, subscriber resume with last id it received
+type Message struct {
+	Id   uint64
+	Type string
+	Data []byte
+}
+
+// Broker fan out messages of topic to all subscribers
+// it keep last messages of each topic so subscriber can resume after reconnect
+type Broker interface {
+	Publish(ctx context.Context, topic string, msgType string, data []byte) (uint64, error)
+	// Subscribe receive messages published after it return
+	Subscribe(ctx context.Context, topic string) (*Subscription, error)
+	// Since return kept messages of topic with id > afterId, oldest first
+	Since(ctx context.Context, topic string, afterId uint64) ([]*Message, error)
+}
+
+// Subscription C is closed when subscription is closed
+// or when subscriber is too slow, then it should resume with Since
+type Subscription struct {
+	C      <-chan *Message
+	cancel func()
+	once   sync.Once
+}
+
+func (s *Subscription) Close() {
+	s.once.Do(s.cancel)
+}
+
+// Resume subscribe topic and return kept messages after lastId
+// subscribe first so nothing published between is missed
+// caller skip messages of subscription with id <= id of last message it sent
+func Resume(ctx context.Context, broker Broker, topic string, lastId uint64) (*Subscription, []*Message, error) {
+	subscription, err := broker.Subscribe(ctx, topic)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	messages, err := broker.Since(ctx, topic, lastId)
+	if err != nil {
+		subscription.Close()
+		return nil, nil, err
+	}
+	return subscription, messages, nil
+}
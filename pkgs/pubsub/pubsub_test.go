@@ -0,0 +1,81 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestMemoryBrokerPublishSubscribe(t *testing.T) {
+	ctx := context.Background()
+	broker := NewMemoryBroker(10)
+
+	subscription, err := broker.Subscribe(ctx, "user:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subscription.Close()
+
+	id, _ := broker.Publish(ctx, "user:1", "transaction.created", []byte(`{"id":1}`))
+	_, _ = broker.Publish(ctx, "user:2", "transaction.created", []byte(`{"id":2}`))
+	if id != 1 {
+		t.Fatalf("id = %d, want 1", id)
+	}
+
+	msg := <-subscription.C
+	if msg.Id != 1 || msg.Type != "transaction.created" || string(msg.Data) != `{"id":1}` {
+		t.Fatalf("unexpected message %+v", msg)
+	}
+	select {
+	case msg := <-subscription.C:
+		t.Fatalf("received message of other topic %+v", msg)
+	default:
+	}
+}
+
+func TestMemoryBrokerResume(t *testing.T) {
+	ctx := context.Background()
+	broker := NewMemoryBroker(3)
+	for i := 0; i < 5; i++ {
+		_, _ = broker.Publish(ctx, "user:1", "balance.updated", []byte(fmt.Sprint(i)))
+	}
+
+	// history keep only last 3
+	subscription, messages, err := Resume(ctx, broker, "user:1", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer subscription.Close()
+	if len(messages) != 3 || messages[0].Id != 3 || messages[2].Id != 5 {
+		t.Fatalf("unexpected replay %+v", messages)
+	}
+
+	messages, _ = broker.Since(ctx, "user:1", 4)
+	if len(messages) != 1 || messages[0].Id != 5 {
+		t.Fatalf("unexpected since %+v", messages)
+	}
+
+	_, _ = broker.Publish(ctx, "user:1", "balance.updated", []byte("5"))
+	if msg := <-subscription.C; msg.Id != 6 {
+		t.Fatalf("id = %d, want 6", msg.Id)
+	}
+}
+
+func TestMemoryBrokerDropSlowSubscriber(t *testing.T) {
+	ctx := context.Background()
+	broker := NewMemoryBroker(100)
+	subscription, _ := broker.Subscribe(ctx, "user:1")
+	defer subscription.Close()
+
+	for i := 0; i < subscriberBuffer+1; i++ {
+		_, _ = broker.Publish(ctx, "user:1", "balance.updated", nil)
+	}
+
+	received := 0
+	for range subscription.C {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Fatalf("received %d before close, want %d", received, subscriberBuffer)
+	}
+}
@@ -0,0 +1,123 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// publishScript take id, keep message in history and publish it in one step
+// so messages are published and kept in id order across instances
+// message is encoded as "<id> <type> <data>"
+var publishScript = redis.NewScript(`
+local id = redis.call('INCR', KEYS[1])
+local msg = id .. ' ' .. ARGV[1] .. ' ' .. ARGV[2]
+redis.call('RPUSH', KEYS[2], msg)
+redis.call('LTRIM', KEYS[2], -tonumber(ARGV[3]), -1)
+redis.call('EXPIRE', KEYS[2], tonumber(ARGV[4]))
+redis.call('PUBLISH', KEYS[3], msg)
+return id
+`)
+
+// redisBroker share topics between instances by redis pub/sub
+type redisBroker struct {
+	client      *redis.Client
+	historySize int
+	historyTTL  time.Duration
+}
+
+func NewRedisBroker(client *redis.Client, historySize int, historyTTL time.Duration) Broker {
+	return &redisBroker{
+		client:      client,
+		historySize: historySize,
+		historyTTL:  historyTTL,
+	}
+}
+
+func (r *redisBroker) keys(topic string) []string {
+	// seq key never expire, id must not restart while client may hold old last id
+	return []string{
+		fmt.Sprintf("pubsub:%s:seq", topic),
+		fmt.Sprintf("pubsub:%s:history", topic),
+		fmt.Sprintf("pubsub:%s", topic),
+	}
+}
+
+func (r *redisBroker) Publish(ctx context.Context, topic string, msgType string, data []byte) (uint64, error) {
+	id, err := publishScript.Run(ctx, r.client, r.keys(topic), msgType, string(data), r.historySize, int(r.historyTTL.Seconds())).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return uint64(id), nil
+}
+
+func (r *redisBroker) Subscribe(ctx context.Context, topic string) (*Subscription, error) {
+	redisPubSub := r.client.Subscribe(ctx, r.keys(topic)[2])
+	// wait for confirmation, messages published after here are received
+	_, err := redisPubSub.Receive(ctx)
+	if err != nil {
+		_ = redisPubSub.Close()
+		return nil, err
+	}
+
+	ch := make(chan *Message, subscriberBuffer)
+	done := make(chan struct{})
+	go func() {
+		defer close(ch)
+		for redisMsg := range redisPubSub.Channel() {
+			msg, err := decodeMessage(redisMsg.Payload)
+			if err != nil {
+				continue
+			}
+			select {
+			case ch <- msg:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return &Subscription{
+		C: ch,
+		cancel: func() {
+			// close redis channel, goroutine close ch
+			close(done)
+			_ = redisPubSub.Close()
+		},
+	}, nil
+}
+
+func (r *redisBroker) Since(ctx context.Context, topic string, afterId uint64) ([]*Message, error) {
+	values, err := r.client.LRange(ctx, r.keys(topic)[1], 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []*Message{}
+	for _, value := range values {
+		msg, err := decodeMessage(value)
+		if err != nil {
+			return nil, err
+		}
+		if msg.Id > afterId {
+			messages = append(messages, msg)
+		}
+	}
+	return messages, nil
+}
+
+func decodeMessage(value string) (*Message, error) {
+	parts := strings.SplitN(value, " ", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid message %q", value)
+	}
+	id, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &Message{Id: id, Type: parts[1], Data: []byte(parts[2])}, nil
+}
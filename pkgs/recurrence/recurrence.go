@@ -0,0 +1,242 @@
+package recurrence
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// this is small subset of RFC 5545 RRULE
+// FREQ=DAILY|WEEKLY|MONTHLY;INTERVAL=n;BYDAY=MO,WE;BYMONTHDAY=n;COUNT=n;UNTIL=20240601T000000Z
+// time of day of every occurrence is taken from anchor (first occurrence)
+
+var (
+	FREQDAILY   = "DAILY"
+	FREQWEEKLY  = "WEEKLY"
+	FREQMONTHLY = "MONTHLY"
+)
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// max periods to look ahead before give up, as BYMONTHDAY=31 with INTERVAL=12 from april
+var maxLookAheadPeriods = 48
+
+type Rule struct {
+	Freq     string
+	Interval int
+	ByDay    []time.Weekday
+	// 0 mean same day as anchor, day greater than days in month is clamped to last day
+	ByMonthDay int
+	// 0 mean unlimited, caller count occurrences already ran
+	Count int
+	Until time.Time
+}
+
+// Parse parse RRULE subset, "RRULE:" prefix is optional
+func Parse(value string) (*Rule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	rule := &Rule{Interval: 1}
+
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("rrule: invalid part %q", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(val)
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval <= 0 {
+				return nil, fmt.Errorf("rrule: invalid INTERVAL %q", val)
+			}
+			rule.Interval = interval
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				weekday, ok := weekdays[strings.ToUpper(day)]
+				if !ok {
+					return nil, fmt.Errorf("rrule: invalid BYDAY %q", day)
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			monthDay, err := strconv.Atoi(val)
+			if err != nil || monthDay < 1 || monthDay > 31 {
+				return nil, fmt.Errorf("rrule: invalid BYMONTHDAY %q", val)
+			}
+			rule.ByMonthDay = monthDay
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count <= 0 {
+				return nil, fmt.Errorf("rrule: invalid COUNT %q", val)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := time.Parse("20060102T150405Z", val)
+			if err != nil {
+				until, err = time.Parse("20060102", val)
+				if err != nil {
+					return nil, fmt.Errorf("rrule: invalid UNTIL %q", val)
+				}
+			}
+			rule.Until = until
+		default:
+			return nil, fmt.Errorf("rrule: unsupported part %q", key)
+		}
+	}
+
+	return rule, rule.Validate()
+}
+
+func (r *Rule) Validate() error {
+	if r.Freq != FREQDAILY && r.Freq != FREQWEEKLY && r.Freq != FREQMONTHLY {
+		return fmt.Errorf("rrule: FREQ expects one of [%s, %s, %s], !got: [%s]", FREQDAILY, FREQWEEKLY, FREQMONTHLY, r.Freq)
+	}
+	if r.Interval <= 0 {
+		return fmt.Errorf("rrule: INTERVAL must be greater than 0")
+	}
+	if len(r.ByDay) > 0 && r.Freq != FREQWEEKLY {
+		return fmt.Errorf("rrule: BYDAY only support with FREQ=%s", FREQWEEKLY)
+	}
+	if r.ByMonthDay > 0 && r.Freq != FREQMONTHLY {
+		return fmt.Errorf("rrule: BYMONTHDAY only support with FREQ=%s", FREQMONTHLY)
+	}
+	return nil
+}
+
+// Next return first occurrence strictly after `after`
+// occurrences start from anchor, false when no more occurrence (UNTIL)
+func (r *Rule) Next(anchor time.Time, after time.Time) (time.Time, bool) {
+	var next time.Time
+	var ok bool
+
+	switch r.Freq {
+	case FREQDAILY:
+		next, ok = r.nextDaily(anchor, after)
+	case FREQWEEKLY:
+		next, ok = r.nextWeekly(anchor, after)
+	case FREQMONTHLY:
+		next, ok = r.nextMonthly(anchor, after)
+	}
+
+	if !ok {
+		return time.Time{}, false
+	}
+
+	if !r.Until.IsZero() && next.After(r.Until) {
+		return time.Time{}, false
+	}
+
+	return next, true
+}
+
+// First return first occurrence from anchor (anchor itself if it match)
+func (r *Rule) First(anchor time.Time) (time.Time, bool) {
+	return r.Next(anchor, anchor.Add(-time.Nanosecond))
+}
+
+func (r *Rule) nextDaily(anchor time.Time, after time.Time) (time.Time, bool) {
+	if after.Before(anchor) {
+		return anchor, true
+	}
+
+	days := daysBetween(anchor, after.In(anchor.Location()))
+	candidate := anchor.AddDate(0, 0, days/r.Interval*r.Interval)
+	for !candidate.After(after) {
+		candidate = candidate.AddDate(0, 0, r.Interval)
+	}
+	return candidate, true
+}
+
+func (r *Rule) nextWeekly(anchor time.Time, after time.Time) (time.Time, bool) {
+	byDay := r.ByDay
+	if len(byDay) == 0 {
+		byDay = []time.Weekday{anchor.Weekday()}
+	}
+
+	// offset from monday
+	offsets := make([]int, 0, len(byDay))
+	for _, day := range byDay {
+		offsets = append(offsets, (int(day)+6)%7)
+	}
+	sort.Ints(offsets)
+
+	anchorWeekStart := anchor.AddDate(0, 0, -((int(anchor.Weekday()) + 6) % 7))
+	reference := anchor
+	if after.After(anchor) {
+		reference = after.In(anchor.Location())
+	}
+
+	weeks := daysBetween(anchorWeekStart, reference) / 7
+	period := weeks / r.Interval * r.Interval
+	for i := 0; i < maxLookAheadPeriods; i++ {
+		for _, offset := range offsets {
+			candidate := anchorWeekStart.AddDate(0, 0, period*7+offset)
+			if !candidate.Before(anchor) && candidate.After(after) {
+				return candidate, true
+			}
+		}
+		period += r.Interval
+	}
+
+	return time.Time{}, false
+}
+
+func (r *Rule) nextMonthly(anchor time.Time, after time.Time) (time.Time, bool) {
+	monthDay := r.ByMonthDay
+	if monthDay == 0 {
+		monthDay = anchor.Day()
+	}
+
+	reference := anchor
+	if after.After(anchor) {
+		reference = after.In(anchor.Location())
+	}
+
+	months := (reference.Year()-anchor.Year())*12 + int(reference.Month()) - int(anchor.Month())
+	period := months / r.Interval * r.Interval
+	for i := 0; i < maxLookAheadPeriods; i++ {
+		// first day of month then add day, so never overflow into next month
+		firstOfMonth := time.Date(anchor.Year(), anchor.Month()+time.Month(period), 1,
+			anchor.Hour(), anchor.Minute(), anchor.Second(), anchor.Nanosecond(), anchor.Location())
+		day := monthDay
+		if lastDay := daysIn(firstOfMonth); day > lastDay {
+			day = lastDay
+		}
+
+		candidate := firstOfMonth.AddDate(0, 0, day-1)
+		if !candidate.Before(anchor) && candidate.After(after) {
+			return candidate, true
+		}
+		period += r.Interval
+	}
+
+	return time.Time{}, false
+}
+
+// daysBetween count calendar days from a to b on location of a
+func daysBetween(a time.Time, b time.Time) int {
+	b = b.In(a.Location())
+	dayA := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	dayB := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(dayB.Sub(dayA).Hours() / 24)
+}
+
+func daysIn(firstOfMonth time.Time) int {
+	return firstOfMonth.AddDate(0, 1, -1).Day()
+}
@@ -0,0 +1,75 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	rule, err := Parse("RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=4")
+	if err != nil {
+		t.Fatalf("expects valid rrule, got %v", err)
+	}
+	if rule.Freq != FREQWEEKLY || rule.Interval != 2 || len(rule.ByDay) != 2 || rule.Count != 4 {
+		t.Errorf("parsed wrong rule %+v", rule)
+	}
+
+	invalids := []string{
+		"FREQ=YEARLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;BYDAY=MO",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=DAILY;BYHOUR=10",
+	}
+	for _, value := range invalids {
+		if _, err := Parse(value); err == nil {
+			t.Errorf("expects error on %q", value)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	loc := time.FixedZone("UTC+7", 7*60*60)
+	anchor := time.Date(2024, 1, 31, 9, 0, 0, 0, loc) // wednesday
+
+	cases := []struct {
+		name   string
+		rule   *Rule
+		after  time.Time
+		expect time.Time
+	}{
+		{"daily before anchor", &Rule{Freq: FREQDAILY, Interval: 1}, anchor.Add(-time.Hour), anchor},
+		{"daily on anchor", &Rule{Freq: FREQDAILY, Interval: 1}, anchor, time.Date(2024, 2, 1, 9, 0, 0, 0, loc)},
+		{"every 3 days", &Rule{Freq: FREQDAILY, Interval: 3}, time.Date(2024, 2, 5, 12, 0, 0, 0, loc), time.Date(2024, 2, 6, 9, 0, 0, 0, loc)},
+		{"weekly same weekday", &Rule{Freq: FREQWEEKLY, Interval: 1}, anchor, time.Date(2024, 2, 7, 9, 0, 0, 0, loc)},
+		{"weekly by day", &Rule{Freq: FREQWEEKLY, Interval: 1, ByDay: []time.Weekday{time.Monday, time.Friday}}, anchor, time.Date(2024, 2, 2, 9, 0, 0, 0, loc)},
+		{"biweekly monday", &Rule{Freq: FREQWEEKLY, Interval: 2, ByDay: []time.Weekday{time.Monday}}, anchor, time.Date(2024, 2, 12, 9, 0, 0, 0, loc)},
+		{"monthly clamp last day", &Rule{Freq: FREQMONTHLY, Interval: 1}, anchor, time.Date(2024, 2, 29, 9, 0, 0, 0, loc)},
+		{"monthly day 31 in april", &Rule{Freq: FREQMONTHLY, Interval: 1}, time.Date(2024, 4, 1, 0, 0, 0, 0, loc), time.Date(2024, 4, 30, 9, 0, 0, 0, loc)},
+		{"monthly on day 15", &Rule{Freq: FREQMONTHLY, Interval: 1, ByMonthDay: 15}, anchor, time.Date(2024, 2, 15, 9, 0, 0, 0, loc)},
+		{"quarterly", &Rule{Freq: FREQMONTHLY, Interval: 3, ByMonthDay: 1}, anchor, time.Date(2024, 4, 1, 9, 0, 0, 0, loc)},
+		{"after in utc", &Rule{Freq: FREQDAILY, Interval: 1}, time.Date(2024, 2, 1, 3, 0, 0, 0, time.UTC), time.Date(2024, 2, 2, 9, 0, 0, 0, loc)},
+	}
+
+	for _, c := range cases {
+		got, ok := c.rule.Next(anchor, c.after)
+		if !ok || !got.Equal(c.expect) {
+			t.Errorf("case [%s]: expects %v, got %v (%v)", c.name, c.expect, got, ok)
+		}
+	}
+}
+
+func TestNextUntil(t *testing.T) {
+	anchor := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	rule := &Rule{Freq: FREQDAILY, Interval: 1, Until: time.Date(2024, 1, 2, 23, 0, 0, 0, time.UTC)}
+
+	if _, ok := rule.Next(anchor, time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)); ok {
+		t.Errorf("expects no occurrence after until")
+	}
+
+	first, ok := rule.First(anchor)
+	if !ok || !first.Equal(anchor) {
+		t.Errorf("expects first occurrence is anchor, got %v", first)
+	}
+}
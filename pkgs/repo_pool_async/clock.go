@@ -0,0 +1,19 @@
+package repo_pool_async
+
+import "time"
+
+// Clock of pool, commit timeout and retry backoff are measured with it so tests decide them without waiting
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
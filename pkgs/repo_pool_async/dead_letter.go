@@ -0,0 +1,127 @@
+package repo_pool_async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrDeadLetterNotFound = errors.New("repo_pool_async: dead letter not found")
+
+// DeadLetter is job which failed every attempt of RetryPolicy
+// handler is kept in memory so letter can be replayed on its pool, letters are lost on restart
+type DeadLetter struct {
+	Id       uint64    `json:"id"`
+	Key      string    `json:"key,omitempty"`
+	Error    string    `json:"error"`
+	Attempts int       `json:"attempts"`
+	TraceId  string    `json:"trace_id,omitempty"`
+	PushedAt time.Time `json:"pushed_at"`
+	FailedAt time.Time `json:"failed_at"`
+
+	pool    *RepoUpdatePoolBusyWaiting
+	handler Handler
+}
+
+func newDeadLetter(job *Job, attempts int, err error) *DeadLetter {
+	letter := &DeadLetter{
+		Key:      job.key,
+		Error:    err.Error(),
+		Attempts: attempts,
+		PushedAt: job.pushedAt,
+		FailedAt: time.Now(),
+		pool:     job.pool,
+		handler:  job.handler,
+	}
+	if job.spanContext.HasTraceID() {
+		letter.TraceId = job.spanContext.TraceID().String()
+	}
+	return letter
+}
+
+// DeadLetterSink receive jobs which keep failing
+type DeadLetterSink interface {
+	Add(letter *DeadLetter)
+}
+
+// DeadLetterQueue is in memory DeadLetterSink, oldest letter is dropped over size
+type DeadLetterQueue struct {
+	lock    sync.Mutex
+	size    int
+	nextId  uint64
+	letters []*DeadLetter
+}
+
+// DeadLetters is sink of pools without Options.DeadLetters, listed and replayed by admin api
+var DeadLetters = NewDeadLetterQueue(1000)
+
+func NewDeadLetterQueue(size int) *DeadLetterQueue {
+	return &DeadLetterQueue{size: size}
+}
+
+func (q *DeadLetterQueue) Add(letter *DeadLetter) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.nextId++
+	letter.Id = q.nextId
+	q.letters = append(q.letters, letter)
+	if len(q.letters) > q.size {
+		q.letters = q.letters[len(q.letters)-q.size:]
+	}
+	deadLetters.Set(float64(len(q.letters)))
+}
+
+// List letters, oldest first
+func (q *DeadLetterQueue) List() []DeadLetter {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	letters := make([]DeadLetter, len(q.letters))
+	for i, letter := range q.letters {
+		letters[i] = *letter
+	}
+	return letters
+}
+
+// Replay push handler of letter again on its pool, letter is removed when pool accept job
+// job fail again into a new letter
+func (q *DeadLetterQueue) Replay(ctx context.Context, id uint64) (*Job, error) {
+	letter := q.remove(id)
+	if letter == nil {
+		return nil, ErrDeadLetterNotFound
+	}
+
+	job := letter.pool.PushPriority(ctx, letter.Key, letter.handler)
+	if err := job.Err(); err != nil {
+		q.restore(letter)
+		return nil, err
+	}
+	// original job was committed, replay is too
+	job.Commit()
+	return job, nil
+}
+
+func (q *DeadLetterQueue) remove(id uint64) *DeadLetter {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	for i, letter := range q.letters {
+		if letter.Id == id {
+			q.letters = append(q.letters[:i], q.letters[i+1:]...)
+			deadLetters.Set(float64(len(q.letters)))
+			return letter
+		}
+	}
+	return nil
+}
+
+// restore letter which is not replayed at its place, ids are ordered
+func (q *DeadLetterQueue) restore(letter *DeadLetter) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	i := 0
+	for i < len(q.letters) && q.letters[i].Id < letter.Id {
+		i++
+	}
+	q.letters = append(q.letters[:i], append([]*DeadLetter{letter}, q.letters[i:]...)...)
+	deadLetters.Set(float64(len(q.letters)))
+}
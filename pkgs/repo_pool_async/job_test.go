@@ -0,0 +1,220 @@
+package repo_pool_async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// fakeClock move only on Advance, After channels fire when their time is reached
+type fakeClock struct {
+	lock    sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(d)
+	waiters := c.waiters[:0]
+	for _, waiter := range c.waiters {
+		if waiter.at.After(c.now) {
+			waiters = append(waiters, waiter)
+			continue
+		}
+		waiter.ch <- c.now
+	}
+	c.waiters = waiters
+}
+
+// blockUntilWaiters wait until n goroutines wait on clock, so Advance is not lost
+func (c *fakeClock) blockUntilWaiters(t *testing.T, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		c.lock.Lock()
+		waiting := len(c.waiters)
+		c.lock.Unlock()
+		if waiting >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("%d goroutines are not waiting on clock", n)
+}
+
+func newClockPool(t *testing.T, clock Clock) *RepoUpdatePoolBusyWaiting {
+	p := NewPoolWithOptions(context.Background(), 1, zap.NewNop(), Options{
+		CommitTimeout: time.Second,
+		Clock:         clock,
+		DeadLetters:   NewDeadLetterQueue(10),
+	})
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		p.Close(ctx)
+	})
+	return p
+}
+
+func TestJobCommitted(t *testing.T) {
+	p := newClockPool(t, newFakeClock())
+
+	var processed int32
+	job := p.PushPriority(context.Background(), "", func(ctx context.Context) error {
+		atomic.AddInt32(&processed, 1)
+		return nil
+	})
+	if !job.Commit() {
+		t.Fatal("pending job is not committed")
+	}
+	if err := waitJob(t, job); err != nil {
+		t.Fatal(err)
+	}
+	if job.State() != JobDone || atomic.LoadInt32(&processed) != 1 {
+		t.Errorf("got state %s, processed %d", job.State(), processed)
+	}
+	if job.Commit() || job.Abort() {
+		t.Error("done job change state")
+	}
+}
+
+func TestJobAborted(t *testing.T) {
+	p := newClockPool(t, newFakeClock())
+
+	var processed int32
+	job := p.PushPriority(context.Background(), "", func(ctx context.Context) error {
+		atomic.AddInt32(&processed, 1)
+		return nil
+	})
+	if !job.Abort() {
+		t.Fatal("pending job is not aborted")
+	}
+	if err := waitJob(t, job); !errors.Is(err, ErrJobAborted) {
+		t.Errorf("got %v want ErrJobAborted", err)
+	}
+	if job.Commit() {
+		t.Error("aborted job is committed")
+	}
+
+	// worker discard aborted job and take next one
+	next := push(p, context.Background())
+	if err := waitJob(t, next); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&processed); got != 0 {
+		t.Errorf("aborted job is processed %d times", got)
+	}
+}
+
+func TestJobNeverCommittedExpire(t *testing.T) {
+	clock := newFakeClock()
+	p := newClockPool(t, clock)
+
+	var processed int32
+	job := p.PushPriority(context.Background(), "", func(ctx context.Context) error {
+		atomic.AddInt32(&processed, 1)
+		return nil
+	})
+	// worker wait for commit until commit timeout
+	clock.blockUntilWaiters(t, 1)
+	if job.State() != JobPending {
+		t.Fatalf("got state %s want pending", job.State())
+	}
+	clock.Advance(time.Second - time.Nanosecond)
+	select {
+	case <-job.Done():
+		t.Fatal("job expired before commit timeout")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clock.Advance(time.Nanosecond)
+	if err := waitJob(t, job); !errors.Is(err, ErrJobExpired) {
+		t.Errorf("got %v want ErrJobExpired", err)
+	}
+	if job.Commit() {
+		t.Error("expired job is committed")
+	}
+	if got := atomic.LoadInt32(&processed); got != 0 {
+		t.Errorf("expired job is processed %d times", got)
+	}
+}
+
+func TestJobCommittedWhileWorkerWait(t *testing.T) {
+	clock := newFakeClock()
+	p := newClockPool(t, clock)
+
+	job := p.PushPriority(context.Background(), "", func(ctx context.Context) error { return nil })
+	clock.blockUntilWaiters(t, 1)
+	// commit is late but before timeout, whatever the real time is
+	clock.Advance(999 * time.Millisecond)
+	job.Commit()
+	if err := waitJob(t, job); err != nil {
+		t.Errorf("got %v want processed", err)
+	}
+}
+
+func TestJobRetryBackoffOnClock(t *testing.T) {
+	clock := newFakeClock()
+	p := NewPoolWithOptions(context.Background(), 1, zap.NewNop(), Options{
+		Clock:       clock,
+		Retry:       RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Minute},
+		DeadLetters: NewDeadLetterQueue(10),
+	})
+	defer p.Close(context.Background())
+
+	var attempts int32
+	job := p.PushPriority(context.Background(), "", func(ctx context.Context) error {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			return errors.New("redis is down")
+		}
+		return nil
+	})
+	job.Commit()
+
+	// second attempt only after backoff of one minute on clock
+	clock.blockUntilWaiters(t, 1)
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Fatalf("attempts before backoff got %d want 1", got)
+	}
+	clock.Advance(time.Minute)
+	if err := waitJob(t, job); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("attempts got %d want 2", got)
+	}
+}
@@ -0,0 +1,57 @@
+package repo_pool_async
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// jobs by result: processed, failed (every attempt failed, sent to dead letters), aborted (rollback),
+// expired (not committed before commit timeout), rejected (pool closed or full), dropped (oldest queued job dropped by OverflowDropOldest),
+// coalesced (skipped for a later committed job of same key)
+var jobsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "repo_pool_async_jobs_total",
+	Help: "Async jobs of every pool by result (processed, failed, aborted, expired, rejected, dropped, coalesced).",
+}, []string{"result"})
+
+var jobRetriesTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "repo_pool_async_job_retries_total",
+	Help: "Attempts of async jobs retried after handler failed.",
+})
+
+var deadLetters = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "repo_pool_async_dead_letters",
+	Help: "Jobs in dead letter queue waiting for replay.",
+})
+
+var _ = promauto.NewGaugeFunc(prometheus.GaugeOpts{
+	Name: "repo_pool_async_queue_depth",
+	Help: "Jobs waiting for a worker in every open pool.",
+}, func() float64 {
+	return float64(QueueDepth())
+})
+
+var _ = promauto.NewGaugeFunc(prometheus.GaugeOpts{
+	Name: "repo_pool_async_available_workers",
+	Help: "Idle workers of every open pool.",
+}, func() float64 {
+	return float64(availableWorkers())
+})
+
+func availableWorkers() uint32 {
+	poolsLock.Lock()
+	defer poolsLock.Unlock()
+	var available uint32
+	for p := range pools {
+		available += atomic.LoadUint32(&p.availableWorkers)
+	}
+	return available
+}
+
+var _ = promauto.NewGaugeFunc(prometheus.GaugeOpts{
+	Name: "repo_pool_async_temporary_workers",
+	Help: "Temporary goroutines running jobs of every open pool, bounded by max spill of each pool.",
+}, func() float64 {
+	return float64(TotalStats().Spilling)
+})
@@ -0,0 +1,123 @@
+package repo_pool_async
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// OverflowPolicy is what PushPriority do when queue of pool is full
+type OverflowPolicy string
+
+const (
+	// OverflowBlock wait until a worker take a job, or until ctx of caller is done (ErrPoolFull)
+	OverflowBlock OverflowPolicy = "block"
+	// OverflowDropOldest drop oldest queued job (ErrJobDropped) to queue the new one
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+	// OverflowReject reject new job with ErrPoolFull
+	OverflowReject OverflowPolicy = "reject"
+	// OverflowSpill run new job on a temporary goroutine, up to MaxSpill at same time, then reject
+	OverflowSpill OverflowPolicy = "spill"
+)
+
+var (
+	ErrPoolFull   = errors.New("repo_pool_async: pool queue is full")
+	ErrPoolClosed = errors.New("repo_pool_async: pool is closed")
+	ErrJobDropped = errors.New("repo_pool_async: job is dropped for a newer job")
+	// ErrJobCoalesced is result of job skipped for a later committed job of same key
+	ErrJobCoalesced = errors.New("repo_pool_async: job is coalesced into a later job of same key")
+)
+
+// ParseOverflowPolicy of config value
+func ParseOverflowPolicy(value string) (OverflowPolicy, error) {
+	switch policy := OverflowPolicy(value); policy {
+	case OverflowBlock, OverflowDropOldest, OverflowReject, OverflowSpill:
+		return policy, nil
+	}
+	return "", fmt.Errorf("overflow policy %q must be block, drop_oldest, reject or spill", value)
+}
+
+type Options struct {
+	// Capacity is max queued jobs waiting for a worker
+	Capacity int
+	Overflow OverflowPolicy
+	// MaxSpill is max temporary goroutines at same time, they run job when no worker is available
+	// (priority strategy) and when queue is full with OverflowSpill, 0 disable them
+	MaxSpill int
+	Retry    RetryPolicy
+	// DeadLetters receive jobs failing every attempt, default to package DeadLetters
+	DeadLetters DeadLetterSink
+	// CommitTimeout from push, job not committed by then is discarded with ErrJobExpired
+	// worker taking a pending job wait for its commit, so keep it short
+	CommitTimeout time.Duration
+	// Coalesce jobs of same key, held jobs followed by a committed job are skipped
+	// enable it when job of a key write whole value (set or delete cache of key), not a part of it
+	Coalesce bool
+	// Clock default to time package
+	Clock Clock
+}
+
+// defaults of pools created with zero values
+const (
+	defaultCapacity      = 1000
+	defaultCommitTimeout = time.Second
+)
+
+var (
+	defaultOptionsLock = &sync.Mutex{}
+	defaultOptions     = Options{
+		Capacity:      defaultCapacity,
+		Overflow:      OverflowReject,
+		MaxSpill:      100,
+		CommitTimeout: defaultCommitTimeout,
+		Coalesce:      true,
+		Retry: RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: 100 * time.Millisecond,
+			MaxBackoff:     2 * time.Second,
+			Multiplier:     2,
+			Jitter:         0.2,
+		},
+	}
+)
+
+// SetDefaultOptions of pools created after by NewPool, set it at startup before use cases are created
+func SetDefaultOptions(opts Options) {
+	defaultOptionsLock.Lock()
+	defer defaultOptionsLock.Unlock()
+	defaultOptions = opts
+}
+
+func DefaultOptions() Options {
+	defaultOptionsLock.Lock()
+	defer defaultOptionsLock.Unlock()
+	return defaultOptions
+}
+
+// PoolStats of one pool, TotalStats is sum of every open pool
+type PoolStats struct {
+	Workers          int    `json:"workers"`
+	AvailableWorkers int    `json:"available_workers"`
+	QueueDepth       int    `json:"queue_depth"`
+	Held             int    `json:"held"`
+	Capacity         int    `json:"capacity"`
+	Spilling         int    `json:"spilling"`
+	Rejected         uint64 `json:"rejected"`
+	Dropped          uint64 `json:"dropped"`
+	Coalesced        uint64 `json:"coalesced"`
+}
+
+func (s PoolStats) add(other PoolStats) PoolStats {
+	return PoolStats{
+		Workers:          s.Workers + other.Workers,
+		AvailableWorkers: s.AvailableWorkers + other.AvailableWorkers,
+		QueueDepth:       s.QueueDepth + other.QueueDepth,
+		Held:             s.Held + other.Held,
+		Capacity:         s.Capacity + other.Capacity,
+		Spilling:         s.Spilling + other.Spilling,
+		Rejected:         s.Rejected + other.Rejected,
+		Dropped:          s.Dropped + other.Dropped,
+		Coalesced:        s.Coalesced + other.Coalesced,
+	}
+}
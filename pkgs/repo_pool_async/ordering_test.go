@@ -0,0 +1,138 @@
+package repo_pool_async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// recorder of handlers which ran, in order
+type recorder struct {
+	lock sync.Mutex
+	ran  []string
+}
+
+func (r *recorder) handler(name string, release <-chan struct{}) Handler {
+	return func(ctx context.Context) error {
+		if release != nil {
+			<-release
+		}
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		r.ran = append(r.ran, name)
+		return nil
+	}
+}
+
+func (r *recorder) list() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]string(nil), r.ran...)
+}
+
+func newKeyPool(t *testing.T, coalesce bool) *RepoUpdatePoolBusyWaiting {
+	p := NewPoolWithOptions(context.Background(), 2, zap.NewNop(), Options{Coalesce: coalesce})
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		p.Close(ctx)
+	})
+	time.Sleep(10 * time.Millisecond)
+	return p
+}
+
+func pushKey(p *RepoUpdatePoolBusyWaiting, key string, handler Handler) *Job {
+	job := p.PushPriority(context.Background(), key, handler)
+	job.Commit()
+	return job
+}
+
+func TestPoolSameKeyInOrder(t *testing.T) {
+	p := newKeyPool(t, false)
+	r := &recorder{}
+
+	release := make(chan struct{})
+	first := pushKey(p, "account:3", r.handler("account:3 first", release))
+	second := pushKey(p, "account:3", r.handler("account:3 second", nil))
+	// other key run on second worker while first job of account:3 is running
+	other := pushKey(p, "account:4", r.handler("account:4", nil))
+	if err := waitJob(t, other); err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Stats().Held; got != 1 {
+		t.Errorf("held got %d want 1", got)
+	}
+
+	close(release)
+	for _, job := range []*Job{first, second} {
+		if err := waitJob(t, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []string{"account:4", "account:3 first", "account:3 second"}
+	if got := r.list(); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("ran %v want %v", got, want)
+	}
+	if stats := p.Stats(); stats.Held != 0 {
+		t.Errorf("held got %d want 0", stats.Held)
+	}
+}
+
+func TestPoolCoalesceLatestCommitted(t *testing.T) {
+	p := newKeyPool(t, true)
+	r := &recorder{}
+
+	release := make(chan struct{})
+	head := pushKey(p, "account:3", r.handler("head", release))
+	stale := pushKey(p, "account:3", r.handler("stale", nil))
+	latest := pushKey(p, "account:3", r.handler("latest", nil))
+	// not committed yet, it does not supersede latest
+	pending := p.PushPriority(context.Background(), "account:3", r.handler("pending", nil))
+
+	close(release)
+	if err := waitJob(t, stale); !errors.Is(err, ErrJobCoalesced) {
+		t.Errorf("got %v want ErrJobCoalesced", err)
+	}
+	for _, job := range []*Job{head, latest} {
+		if err := waitJob(t, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pending.Abort()
+	if err := waitJob(t, pending); !errors.Is(err, ErrJobAborted) {
+		t.Errorf("got %v want ErrJobAborted", err)
+	}
+
+	want := []string{"head", "latest"}
+	if got := r.list(); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("ran %v want %v", got, want)
+	}
+	if got := p.Stats().Coalesced; got != 1 {
+		t.Errorf("coalesced got %d want 1", got)
+	}
+}
+
+func TestPoolCloseAbandonHeldJobs(t *testing.T) {
+	p := NewPoolWithOptions(context.Background(), 1, zap.NewNop(), Options{})
+	time.Sleep(10 * time.Millisecond)
+	r := &recorder{}
+
+	release := make(chan struct{})
+	defer close(release)
+	pushKey(p, "account:3", r.handler("busy", release))
+	held := pushKey(p, "account:3", r.handler("held", nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	abandoned, _ := p.Close(ctx)
+	if abandoned != 1 {
+		t.Errorf("abandoned got %d want 1", abandoned)
+	}
+	if err := waitJob(t, held); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("got %v want ErrPoolClosed", err)
+	}
+}
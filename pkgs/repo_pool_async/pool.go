@@ -2,7 +2,10 @@ package repo_pool_async
 
 import (
 	"context"
-	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"sync"
 	"sync/atomic"
@@ -10,96 +13,604 @@ import (
 )
 
 type RepoUpdatePoolBusyWaiting struct {
-	q               *Queue
+	q *Queue
+	// jobs run on ctx of pool, caller ctx (request) is done or reused when job run
+	ctx             context.Context
 	dynamicPriority bool
 	muLock          *sync.Mutex
-	//condProducer     *sync.Cond
+	// producers wait on condProducer when queue is full (OverflowBlock)
+	condProducer     *sync.Cond
 	condConsumer     *sync.Cond
 	availableWorkers uint32
 	logger           *zap.Logger
+	// closed pool reject new jobs, workers exit when queue is empty
+	closed bool
+	// workers and temporary workers, Close wait for them
+	running sync.WaitGroup
+	opts    Options
+	workers int
+	// temporary goroutines running now, bounded by opts.MaxSpill
+	spilling int
+	// ordering keys with a job queued or running (head), later jobs of key are held until head is finished
+	keys      map[string]*keyQueue
+	held      int
+	rejected  uint64
+	dropped   uint64
+	coalesced uint64
 }
 
+// keyQueue of one ordering key, jobs of key run one at a time in push order
+type keyQueue struct {
+	head    *Job
+	waiting []*Job
+}
+
+// pools created by NewPool, each use case own one, CloseAll drain them at shutdown
+var (
+	poolsLock = &sync.Mutex{}
+	pools     = map[*RepoUpdatePoolBusyWaiting]struct{}{}
+)
+
+// NewPool with DefaultOptions
 func NewPool(ctx context.Context, maxSizeWorker int, logger *zap.Logger) *RepoUpdatePoolBusyWaiting {
+	return NewPoolWithOptions(ctx, maxSizeWorker, logger, DefaultOptions())
+}
+
+func NewPoolWithOptions(ctx context.Context, maxSizeWorker int, logger *zap.Logger, opts Options) *RepoUpdatePoolBusyWaiting {
+	if opts.Capacity <= 0 {
+		opts.Capacity = defaultCapacity
+	}
+	if opts.Overflow == "" {
+		opts.Overflow = OverflowReject
+	}
+	if opts.Retry.MaxAttempts <= 0 {
+		opts.Retry.MaxAttempts = 1
+	}
+	if opts.DeadLetters == nil {
+		opts.DeadLetters = DeadLetters
+	}
+	if opts.CommitTimeout <= 0 {
+		opts.CommitTimeout = defaultCommitTimeout
+	}
+	if opts.Clock == nil {
+		opts.Clock = realClock{}
+	}
 	p := &RepoUpdatePoolBusyWaiting{
-		q:      &Queue{},
-		muLock: &sync.Mutex{},
-		logger: logger,
+		q:       &Queue{},
+		ctx:     ctx,
+		muLock:  &sync.Mutex{},
+		logger:  logger,
+		opts:    opts,
+		workers: maxSizeWorker,
+		keys:    map[string]*keyQueue{},
 	}
 
-	//p.condProducer = sync.NewCond(p.muLock)
+	p.condProducer = sync.NewCond(p.muLock)
 	p.condConsumer = sync.NewCond(p.muLock)
 
+	p.running.Add(maxSizeWorker)
 	for i := 0; i < maxSizeWorker; i++ {
 		go p.runWorker(ctx)
 	}
 
+	poolsLock.Lock()
+	pools[p] = struct{}{}
+	poolsLock.Unlock()
 	return p
 }
 
-type Handler func(ctx context.Context)
+var tracer = otel.Tracer("money_forward_code_challenge/pkgs/repo_pool_async")
+
+// Handler of job, error is retried with RetryPolicy of pool then sent to dead letters
+type Handler func(ctx context.Context) error
+
+// JobState of job, pending -> committed or aborted -> running -> done
+// job which is not committed before commit timeout is aborted (ErrJobExpired), aborted job is done without running
+type JobState int32
+
+const (
+	JobPending JobState = iota
+	JobCommitted
+	JobAborted
+	JobRunning
+	JobDone
+)
+
+func (s JobState) String() string {
+	switch s {
+	case JobPending:
+		return "pending"
+	case JobCommitted:
+		return "committed"
+	case JobAborted:
+		return "aborted"
+	case JobRunning:
+		return "running"
+	case JobDone:
+		return "done"
+	}
+	return "unknown"
+}
+
 type Job struct {
-	handler      Handler
-	expiredTime  int64
-	responseTime int64
+	pool    *RepoUpdatePoolBusyWaiting
+	key     string
+	handler Handler
+	state   atomic.Int32
+	// decided is closed when job is committed or aborted by caller
+	decided chan struct{}
+	// span of caller (request), job span is its child
+	spanContext trace.SpanContext
+	pushedAt    time.Time
+	dequeuedAt  time.Time
+	// done is closed when job is finished, err is its result
+	done    chan struct{}
+	errLock sync.Mutex
+	err     error
 }
 
 func (j *Job) process(ctx context.Context) {
-	if j.responseTime >= j.expiredTime {
+	// span start when job is pushed so wait in queue and worker delay are on trace of request
+	ctx, span := tracer.Start(trace.ContextWithSpanContext(ctx, j.spanContext), "repo_pool_async.Job",
+		trace.WithTimestamp(j.pushedAt),
+		trace.WithAttributes(attribute.Bool("job.temporary_worker", j.dequeuedAt.IsZero())),
+	)
+	defer span.End()
+	if !j.dequeuedAt.IsZero() {
+		span.AddEvent("dequeued", trace.WithTimestamp(j.dequeuedAt))
+	}
+
+	if !j.awaitCommit() {
+		span.SetAttributes(attribute.String("job.result", "discarded"))
 		return
 	}
-	j.handler(ctx)
+	span.AddEvent("committed")
+
+	retry := j.pool.opts.Retry
+	for attempt := 1; ; attempt++ {
+		span.AddEvent("process", trace.WithAttributes(attribute.Int("job.attempt", attempt)))
+		err := j.handler(ctx)
+		if err == nil {
+			span.SetAttributes(attribute.String("job.result", "processed"), attribute.Int("job.attempts", attempt))
+			jobsTotal.WithLabelValues("processed").Inc()
+			j.finish(nil)
+			return
+		}
+
+		span.RecordError(err)
+		if attempt >= retry.MaxAttempts || !sleep(ctx, j.pool.opts.Clock, retry.Backoff(attempt)) {
+			span.SetStatus(codes.Error, err.Error())
+			span.SetAttributes(attribute.String("job.result", "failed"), attribute.Int("job.attempts", attempt))
+			jobsTotal.WithLabelValues("failed").Inc()
+			j.pool.logger.Warn("[RepoPoolAsync-Job]", zap.Int("Attempts", attempt), zap.Error(err))
+			j.pool.opts.DeadLetters.Add(newDeadLetter(j, attempt, err))
+			j.finish(err)
+			return
+		}
+		jobRetriesTotal.Inc()
+	}
+}
+
+// awaitCommit wait until caller commit or abort job, at most until commit timeout from push
+// true when job is committed and now running
+func (j *Job) awaitCommit() bool {
+	if j.State() == JobPending {
+		clock := j.pool.opts.Clock
+		select {
+		case <-j.decided:
+		case <-clock.After(j.pushedAt.Add(j.pool.opts.CommitTimeout).Sub(clock.Now())):
+			if j.state.CompareAndSwap(int32(JobPending), int32(JobAborted)) {
+				jobsTotal.WithLabelValues("expired").Inc()
+				j.finish(ErrJobExpired)
+				return false
+			}
+		}
+	}
+	return j.state.CompareAndSwap(int32(JobCommitted), int32(JobRunning))
 }
 
-func (j *Job) Run(ctx context.Context) {
-	j.responseTime = time.Now().UnixMilli()
+// Commit job after transaction of caller is committed, only committed job is run by a worker
+// false when job is not pending anymore (aborted, expired, rejected or dropped)
+func (j *Job) Commit() bool {
+	if !j.state.CompareAndSwap(int32(JobPending), int32(JobCommitted)) {
+		return false
+	}
+	close(j.decided)
+	return true
+}
+
+// Abort job when transaction of caller is rolled back, job is done with ErrJobAborted and never run
+// false when job is not pending anymore
+func (j *Job) Abort() bool {
+	if !j.state.CompareAndSwap(int32(JobPending), int32(JobAborted)) {
+		return false
+	}
+	close(j.decided)
+	jobsTotal.WithLabelValues("aborted").Inc()
+	j.finish(ErrJobAborted)
+	return true
+}
+
+// coalesce job held behind a later committed job of same key, it is done with ErrJobCoalesced
+// false when job is already aborted or expired
+func (j *Job) coalesce() bool {
+	for {
+		state := j.state.Load()
+		if state != int32(JobPending) && state != int32(JobCommitted) {
+			return false
+		}
+		if j.state.CompareAndSwap(state, int32(JobAborted)) {
+			if state == int32(JobPending) {
+				close(j.decided)
+			}
+			j.finish(ErrJobCoalesced)
+			return true
+		}
+	}
+}
+
+func (j *Job) State() JobState {
+	return JobState(j.state.Load())
 }
 
-func (p *RepoUpdatePoolBusyWaiting) PushPriority(ctx context.Context, handler Handler) *Job {
+// Done is closed when job is finished: processed, failed after retries, aborted, expired or never processed
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
+// Wait until job is finished and return its result, or ctx error when ctx is done first
+// result is error of handler after last attempt, ErrJobAborted, ErrJobExpired, or why pool did not accept job
+func (j *Job) Wait(ctx context.Context) error {
+	select {
+	case <-j.done:
+		return j.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Err is result of finished job, nil while job is pending
+// job rejected by PushPriority (ErrPoolFull, ErrPoolClosed) is finished when PushPriority return
+func (j *Job) Err() error {
+	j.errLock.Lock()
+	defer j.errLock.Unlock()
+	return j.err
+}
+
+func (j *Job) finish(err error) {
+	j.errLock.Lock()
+	defer j.errLock.Unlock()
+	select {
+	case <-j.done:
+		return
+	default:
+	}
+	j.err = err
+	j.state.Store(int32(JobDone))
+	close(j.done)
+}
+
+// PushPriority job of ordering key (like "account:3"), jobs of same key run one at a time in push order
+// and jobs of different keys run in parallel, empty key is not ordered
+func (p *RepoUpdatePoolBusyWaiting) PushPriority(ctx context.Context, key string, handler Handler) *Job {
 	// priority true mean if don't have any
 	// workers available then use strategy priority
 	// spawn temporary worker
 	// to process fastest
 	// good for acid transaction call cache update
 	job := &Job{
-		handler:      handler,
-		expiredTime:  time.Now().Add(100 * time.Millisecond).UnixMilli(),
-		responseTime: time.Now().Add(100 * time.Millisecond).UnixMilli(),
+		pool:        p,
+		key:         key,
+		handler:     handler,
+		decided:     make(chan struct{}),
+		spanContext: trace.SpanContextFromContext(ctx),
+		pushedAt:    p.opts.Clock.Now(),
+		done:        make(chan struct{}),
 	}
-	if atomic.LoadUint32(&p.availableWorkers) == 0 {
-		go func() {
-			job.process(ctx)
-		}()
-	} else {
-		p.q.En(job)
-		fmt.Println("repo_pool_async not empty: ", p.q.Empty())
-		p.condConsumer.Signal()
+
+	p.muLock.Lock()
+	defer p.muLock.Unlock()
+	err := p.push(ctx, job)
+	if err != nil {
+		// job is never processed (cache is refreshed on next read)
+		job.finish(err)
+		p.next(job)
+		p.logger.Warn("[RepoPoolAsync-PushPriority]", zap.String("Rejected", err.Error()))
+		p.rejected++
+		jobsTotal.WithLabelValues("rejected").Inc()
 	}
 
 	return job
 }
 
+// push job with lock held, job is run by temporary goroutine or queued
+func (p *RepoUpdatePoolBusyWaiting) push(ctx context.Context, job *Job) error {
+	if p.closed {
+		return ErrPoolClosed
+	}
+	if p.hold(job) {
+		return nil
+	}
+	if atomic.LoadUint32(&p.availableWorkers) == 0 && p.spill(job) {
+		return nil
+	}
+
+	if p.q.Size() >= p.opts.Capacity && p.opts.Overflow == OverflowBlock {
+		// wake up producer when caller give up
+		stop := context.AfterFunc(ctx, func() {
+			p.muLock.Lock()
+			defer p.muLock.Unlock()
+			p.condProducer.Broadcast()
+		})
+		defer stop()
+	}
+	// held jobs of dropped heads are released once job is queued, they must not take slots freed for it
+	var dropped []*Job
+	for p.q.Size() >= p.opts.Capacity {
+		switch p.opts.Overflow {
+		case OverflowBlock:
+			if ctx.Err() != nil {
+				return ErrPoolFull
+			}
+			p.condProducer.Wait()
+			if p.closed {
+				return ErrPoolClosed
+			}
+		case OverflowDropOldest:
+			oldest := p.q.De().(*Job)
+			oldest.finish(ErrJobDropped)
+			dropped = append(dropped, oldest)
+			p.dropped++
+			jobsTotal.WithLabelValues("dropped").Inc()
+		case OverflowSpill:
+			if p.spill(job) {
+				return nil
+			}
+			return ErrPoolFull
+		default:
+			return ErrPoolFull
+		}
+	}
+
+	p.q.En(job)
+	p.logger.Debug("[RepoPoolAsync-PushPriority]", zap.Int("Queued", p.q.Size()))
+	p.condConsumer.Signal()
+	for _, oldest := range dropped {
+		p.next(oldest)
+	}
+	return nil
+}
+
+// hold job when a job of its key is queued or running, else job become head of its key, lock is held
+func (p *RepoUpdatePoolBusyWaiting) hold(job *Job) bool {
+	if job.key == "" {
+		return false
+	}
+	kq, ok := p.keys[job.key]
+	if !ok {
+		p.keys[job.key] = &keyQueue{head: job}
+		return false
+	}
+	kq.waiting = append(kq.waiting, job)
+	p.held++
+	return true
+}
+
+// next job of key is run after head job is finished, lock is held
+// with opts.Coalesce held jobs followed by a committed job are skipped, only latest committed write run
+func (p *RepoUpdatePoolBusyWaiting) next(job *Job) {
+	kq, ok := p.keys[job.key]
+	if !ok || kq.head != job {
+		return
+	}
+
+	latest := 0
+	if p.opts.Coalesce {
+		for i, waiting := range kq.waiting {
+			if waiting.State() == JobCommitted {
+				latest = i
+			}
+		}
+	}
+	for i, waiting := range kq.waiting {
+		p.held--
+		if i < latest && waiting.coalesce() {
+			p.coalesced++
+			jobsTotal.WithLabelValues("coalesced").Inc()
+			continue
+		}
+		if i < latest || waiting.State() == JobDone {
+			// aborted or expired while held
+			continue
+		}
+		kq.head = waiting
+		kq.waiting = kq.waiting[i+1:]
+		// job was accepted by PushPriority, it is not bounded by capacity again
+		if atomic.LoadUint32(&p.availableWorkers) > 0 || !p.spill(waiting) {
+			p.q.En(waiting)
+			p.condConsumer.Signal()
+		}
+		return
+	}
+	delete(p.keys, job.key)
+}
+
+// spill job to temporary goroutine when under opts.MaxSpill, lock is held
+func (p *RepoUpdatePoolBusyWaiting) spill(job *Job) bool {
+	if p.spilling >= p.opts.MaxSpill {
+		return false
+	}
+	p.spilling++
+	p.running.Add(1)
+	go func() {
+		defer p.running.Done()
+		job.process(p.ctx)
+		p.muLock.Lock()
+		p.spilling--
+		p.next(job)
+		p.muLock.Unlock()
+	}()
+	return true
+}
+
 func (p *RepoUpdatePoolBusyWaiting) runWorker(ctx context.Context) {
+	defer p.running.Done()
 	atomic.AddUint32(&p.availableWorkers, 1)
 	for {
 		// lock to check cond
 		p.muLock.Lock()
-		for p.q.Empty() {
+		for p.q.Empty() && !p.closed {
 			p.condConsumer.Wait()
 		}
+		if p.q.Empty() {
+			// closed and drained
+			p.muLock.Unlock()
+			return
+		}
 		// if can wake up because have job then
 		// pop front job but no release mutex lock for sync
 		job := p.q.De().(*Job)
-		p.muLock.Unlock()
-		time.Sleep(time.Millisecond * 300)
-		// now release lock
-
-		p.logger.Info("Process Job")
+		job.dequeuedAt = p.opts.Clock.Now()
+		p.condProducer.Signal()
 		// when receive job then decrease available workers
 		atomic.AddUint32(&p.availableWorkers, ^uint32(0))
+		// now release lock
+		p.muLock.Unlock()
 
-		// process job with response time smaller than expired time
+		p.logger.Debug("Process Job")
+		// process job once it is committed, aborted and expired jobs are discarded
 		job.process(ctx)
-		// when worker finish job then increase available workers
+		// when worker finish job then increase available workers, next job of key is queued for it
+		p.muLock.Lock()
 		atomic.AddUint32(&p.availableWorkers, 1)
+		p.next(job)
+		p.muLock.Unlock()
+	}
+}
+
+// Len is number of queued jobs waiting for a worker, and jobs held behind a job of same key
+func (p *RepoUpdatePoolBusyWaiting) Len() int {
+	p.muLock.Lock()
+	defer p.muLock.Unlock()
+	return p.q.Size() + p.held
+}
+
+// Stats of pool
+func (p *RepoUpdatePoolBusyWaiting) Stats() PoolStats {
+	p.muLock.Lock()
+	defer p.muLock.Unlock()
+	return PoolStats{
+		Workers:          p.workers,
+		AvailableWorkers: int(atomic.LoadUint32(&p.availableWorkers)),
+		QueueDepth:       p.q.Size(),
+		Held:             p.held,
+		Capacity:         p.opts.Capacity,
+		Spilling:         p.spilling,
+		Rejected:         p.rejected,
+		Dropped:          p.dropped,
+		Coalesced:        p.coalesced,
+	}
+}
+
+// TotalStats is sum of Stats of every open pool
+func TotalStats() PoolStats {
+	poolsLock.Lock()
+	defer poolsLock.Unlock()
+	var total PoolStats
+	for p := range pools {
+		total = total.add(p.Stats())
+	}
+	return total
+}
+
+// QueueDepth is number of queued jobs of every open pool
+func QueueDepth() int {
+	poolsLock.Lock()
+	defer poolsLock.Unlock()
+	depth := 0
+	for p := range pools {
+		depth += p.Len()
+	}
+	return depth
+}
+
+// Close stop accepting jobs and wait until queued jobs are processed
+// when ctx is done first, jobs still queued are dropped and returned as abandoned
+// jobs already processing are not abandoned, they finish in background
+func (p *RepoUpdatePoolBusyWaiting) Close(ctx context.Context) (int, error) {
+	poolsLock.Lock()
+	delete(pools, p)
+	poolsLock.Unlock()
+
+	p.muLock.Lock()
+	p.closed = true
+	p.muLock.Unlock()
+	p.condConsumer.Broadcast()
+	p.condProducer.Broadcast()
+
+	drained := make(chan struct{})
+	go func() {
+		p.running.Wait()
+		close(drained)
+	}()
+
+	var err error
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	// queue is not empty when ctx is done first or when pool has no worker
+	p.muLock.Lock()
+	abandoned := p.q.Size() + p.held
+	for !p.q.Empty() {
+		p.q.De().(*Job).finish(ErrPoolClosed)
+	}
+	for _, kq := range p.keys {
+		for _, job := range kq.waiting {
+			job.finish(ErrPoolClosed)
+		}
+	}
+	p.keys = map[string]*keyQueue{}
+	p.held = 0
+	p.muLock.Unlock()
+	p.condConsumer.Broadcast()
+
+	if abandoned > 0 || err != nil {
+		p.logger.Warn("[RepoPoolAsync-Close]", zap.Int("Abandoned", abandoned), zap.Error(err))
+	}
+	return abandoned, err
+}
+
+// CloseAll close every open pool at same time, return total abandoned jobs
+func CloseAll(ctx context.Context) (int, error) {
+	poolsLock.Lock()
+	open := make([]*RepoUpdatePoolBusyWaiting, 0, len(pools))
+	for p := range pools {
+		open = append(open, p)
+	}
+	poolsLock.Unlock()
+
+	var (
+		wg        sync.WaitGroup
+		lock      sync.Mutex
+		abandoned int
+		firstErr  error
+	)
+	wg.Add(len(open))
+	for _, p := range open {
+		go func(p *RepoUpdatePoolBusyWaiting) {
+			defer wg.Done()
+			n, err := p.Close(ctx)
+			lock.Lock()
+			defer lock.Unlock()
+			abandoned += n
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}(p)
 	}
+	wg.Wait()
+	return abandoned, firstErr
 }
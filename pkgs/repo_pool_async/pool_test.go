@@ -0,0 +1,266 @@
+package repo_pool_async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+)
+
+func pushJobs(p *RepoUpdatePoolBusyWaiting, n int, processed *int32) {
+	for i := 0; i < n; i++ {
+		job := p.PushPriority(context.Background(), "", func(ctx context.Context) error {
+			atomic.AddInt32(processed, 1)
+			return nil
+		})
+		job.Commit()
+	}
+}
+
+func TestPoolCloseDrainsQueue(t *testing.T) {
+	p := NewPool(context.Background(), 1, zap.NewNop())
+	// wait worker available so jobs are queued
+	time.Sleep(10 * time.Millisecond)
+
+	var processed int32
+	pushJobs(p, 3, &processed)
+
+	abandoned, err := p.Close(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if abandoned != 0 {
+		t.Errorf("abandoned got %d want 0", abandoned)
+	}
+	if got := atomic.LoadInt32(&processed); got != 3 {
+		t.Errorf("processed got %d want 3", got)
+	}
+
+	pushJobs(p, 1, &processed)
+	time.Sleep(50 * time.Millisecond)
+	if got := atomic.LoadInt32(&processed); got != 3 {
+		t.Errorf("job pushed after close must be rejected, processed %d", got)
+	}
+}
+
+func TestPoolCloseReportsAbandoned(t *testing.T) {
+	// without temporary goroutines jobs wait in queue for the only worker
+	p := NewPoolWithOptions(context.Background(), 1, zap.NewNop(), Options{MaxSpill: 0})
+	time.Sleep(10 * time.Millisecond)
+
+	// worker is busy until close gave up
+	release := make(chan struct{})
+	defer close(release)
+	busy := p.PushPriority(context.Background(), "", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+	busy.Commit()
+	time.Sleep(10 * time.Millisecond)
+
+	var processed int32
+	pushJobs(p, 4, &processed)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	abandoned, err := p.Close(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v want deadline exceeded", err)
+	}
+	// busy job is taken by the worker, others are still queued
+	if abandoned != 4 {
+		t.Errorf("abandoned got %d want 4", abandoned)
+	}
+}
+
+func TestCloseAll(t *testing.T) {
+	var processed int32
+	for i := 0; i < 2; i++ {
+		p := NewPool(context.Background(), 1, zap.NewNop())
+		time.Sleep(10 * time.Millisecond)
+		pushJobs(p, 1, &processed)
+	}
+
+	abandoned, err := CloseAll(context.Background())
+	if err != nil || abandoned != 0 {
+		t.Fatalf("got %d, %v", abandoned, err)
+	}
+	if got := atomic.LoadInt32(&processed); got != 2 {
+		t.Errorf("processed got %d want 2", got)
+	}
+}
+
+func TestJobSpanIsChildOfCaller(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+
+	p := NewPool(context.Background(), 1, zap.NewNop())
+	time.Sleep(10 * time.Millisecond)
+	// pool tracer is global, swap it for the recorder
+	previous := tracer
+	tracer = provider.Tracer("test")
+	defer func() { tracer = previous }()
+
+	pushed := time.Now()
+	job := p.PushPriority(ctx, "", func(ctx context.Context) error { return nil })
+	job.Commit()
+	parent.End()
+	_, err := p.Close(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var jobSpan sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == "repo_pool_async.Job" {
+			jobSpan = span
+		}
+	}
+	if jobSpan == nil {
+		t.Fatal("job span is not recorded")
+	}
+	if jobSpan.Parent().SpanID() != parent.SpanContext().SpanID() || jobSpan.SpanContext().TraceID() != parent.SpanContext().TraceID() {
+		t.Errorf("job span is not child of caller span")
+	}
+	// wait in queue and for commit is inside job span
+	if jobSpan.StartTime().Before(pushed) || jobSpan.StartTime().After(time.Now()) {
+		t.Errorf("job span start at push, got %s pushed at %s", jobSpan.StartTime(), pushed)
+	}
+}
+
+// pool without worker never dequeue, queued jobs stay until Close
+func newIdlePool(t *testing.T, opts Options) *RepoUpdatePoolBusyWaiting {
+	p := NewPoolWithOptions(context.Background(), 0, zap.NewNop(), opts)
+	t.Cleanup(func() {
+		p.Close(context.Background())
+	})
+	return p
+}
+
+// push committed job
+func push(p *RepoUpdatePoolBusyWaiting, ctx context.Context) *Job {
+	job := p.PushPriority(ctx, "", func(ctx context.Context) error { return nil })
+	job.Commit()
+	return job
+}
+
+func TestPoolOverflowReject(t *testing.T) {
+	p := newIdlePool(t, Options{Capacity: 2, Overflow: OverflowReject})
+
+	jobs := []*Job{push(p, context.Background()), push(p, context.Background()), push(p, context.Background())}
+	if jobs[0].Err() != nil || jobs[1].Err() != nil {
+		t.Fatalf("queued jobs are rejected, got %v, %v", jobs[0].Err(), jobs[1].Err())
+	}
+	if !errors.Is(jobs[2].Err(), ErrPoolFull) {
+		t.Errorf("got %v want ErrPoolFull", jobs[2].Err())
+	}
+	stats := p.Stats()
+	if stats.QueueDepth != 2 || stats.Capacity != 2 || stats.Rejected != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestPoolOverflowDropOldest(t *testing.T) {
+	p := newIdlePool(t, Options{Capacity: 2, Overflow: OverflowDropOldest})
+
+	oldest := push(p, context.Background())
+	push(p, context.Background())
+	newest := push(p, context.Background())
+	if !errors.Is(oldest.Err(), ErrJobDropped) || newest.Err() != nil {
+		t.Errorf("got oldest %v, newest %v", oldest.Err(), newest.Err())
+	}
+	stats := p.Stats()
+	if stats.QueueDepth != 2 || stats.Dropped != 1 || stats.Rejected != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestPoolOverflowDropOldestKeepHeldJobs(t *testing.T) {
+	p := newIdlePool(t, Options{Capacity: 2, Overflow: OverflowDropOldest})
+
+	head := pushKey(p, "account:1", func(ctx context.Context) error { return nil })
+	held := pushKey(p, "account:1", func(ctx context.Context) error { return nil })
+	other := push(p, context.Background())
+	newest := push(p, context.Background())
+
+	// held job of dropped head is queued after newest, it does not drop other job again
+	if !errors.Is(head.Err(), ErrJobDropped) || other.Err() != nil || newest.Err() != nil || held.Err() != nil {
+		t.Errorf("got head %v, other %v, newest %v, held %v", head.Err(), other.Err(), newest.Err(), held.Err())
+	}
+	stats := p.Stats()
+	if stats.QueueDepth != 3 || stats.Held != 0 || stats.Dropped != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestPoolOverflowBlock(t *testing.T) {
+	p := newIdlePool(t, Options{Capacity: 1, Overflow: OverflowBlock})
+	push(p, context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	job := push(p, ctx)
+	if !errors.Is(job.Err(), ErrPoolFull) {
+		t.Errorf("got %v want ErrPoolFull", job.Err())
+	}
+	if waited := time.Since(start); waited < 50*time.Millisecond {
+		t.Errorf("push must block until ctx is done, waited %s", waited)
+	}
+
+	// blocked producer is released by Close
+	blocked := make(chan *Job)
+	go func() {
+		blocked <- push(p, context.Background())
+	}()
+	time.Sleep(10 * time.Millisecond)
+	p.Close(context.Background())
+	select {
+	case job := <-blocked:
+		if !errors.Is(job.Err(), ErrPoolClosed) {
+			t.Errorf("got %v want ErrPoolClosed", job.Err())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("producer is still blocked after close")
+	}
+}
+
+func TestPoolOverflowSpill(t *testing.T) {
+	// spill budget is used, job overflowing queue is rejected
+	p := newIdlePool(t, Options{Capacity: 1, Overflow: OverflowSpill, MaxSpill: 0})
+	queued := push(p, context.Background())
+	rejected := push(p, context.Background())
+	if queued.Err() != nil || !errors.Is(rejected.Err(), ErrPoolFull) {
+		t.Errorf("got %v, %v", queued.Err(), rejected.Err())
+	}
+
+	// no worker is available, jobs run on temporary goroutines
+	p = newIdlePool(t, Options{Capacity: 1, Overflow: OverflowSpill, MaxSpill: 100})
+	for i := 0; i < 5; i++ {
+		if job := push(p, context.Background()); job.Err() != nil {
+			t.Fatalf("job %d is rejected, %v", i, job.Err())
+		}
+	}
+	if stats := p.Stats(); stats.QueueDepth != 0 || stats.Rejected != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestTotalStats(t *testing.T) {
+	first := newIdlePool(t, Options{Capacity: 1})
+	second := newIdlePool(t, Options{Capacity: 1})
+	push(first, context.Background())
+	push(second, context.Background())
+	push(second, context.Background())
+
+	stats := TotalStats()
+	if stats.QueueDepth < 2 || stats.Rejected < 1 {
+		t.Errorf("unexpected total stats %+v", stats)
+	}
+}
@@ -0,0 +1,61 @@
+package repo_pool_async
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+var (
+	// ErrJobExpired is result of job which is not committed before commit timeout
+	ErrJobExpired = errors.New("repo_pool_async: job is not committed before commit timeout")
+	// ErrJobAborted is result of job aborted by caller (rollback)
+	ErrJobAborted = errors.New("repo_pool_async: job is aborted")
+)
+
+// RetryPolicy of failed handler, backoff is exponential with jitter
+// attempt n wait InitialBackoff * Multiplier^(n-1), capped at MaxBackoff, +- Jitter fraction of it
+// worker (or temporary goroutine) of job wait backoff, it does not take other jobs meanwhile
+type RetryPolicy struct {
+	// MaxAttempts include first attempt, 1 disable retry
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter is between 0 and 1, 0.2 randomize backoff between 80% and 120%
+	Jitter float64
+}
+
+// randFloat is swapped in tests for deterministic jitter
+var randFloat = rand.Float64
+
+// Backoff before attempt+1 when attempt failed
+func (r RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := r.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	backoff := float64(r.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if r.MaxBackoff > 0 && backoff > float64(r.MaxBackoff) {
+		backoff = float64(r.MaxBackoff)
+	}
+	if r.Jitter > 0 {
+		backoff += backoff * r.Jitter * (2*randFloat() - 1)
+	}
+	return time.Duration(backoff)
+}
+
+// sleep for d on clock, false when ctx is done first
+func sleep(ctx context.Context, clock Clock, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	select {
+	case <-clock.After(d):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
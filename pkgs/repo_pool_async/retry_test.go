@@ -0,0 +1,140 @@
+package repo_pool_async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	previous := randFloat
+	defer func() { randFloat = previous }()
+
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 4: 800 * time.Millisecond, 5: time.Second} {
+		if got := policy.Backoff(attempt); got != want {
+			t.Errorf("attempt %d got %s want %s", attempt, got, want)
+		}
+	}
+
+	policy.Jitter = 0.2
+	randFloat = func() float64 { return 0 }
+	if got := policy.Backoff(1); got != 80*time.Millisecond {
+		t.Errorf("lowest jitter got %s want 80ms", got)
+	}
+	randFloat = func() float64 { return 1 }
+	if got := policy.Backoff(1); got != 120*time.Millisecond {
+		t.Errorf("highest jitter got %s want 120ms", got)
+	}
+}
+
+func newRetryPool(t *testing.T, deadLetters DeadLetterSink) *RepoUpdatePoolBusyWaiting {
+	p := NewPoolWithOptions(context.Background(), 1, zap.NewNop(), Options{
+		Retry:         RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+		DeadLetters:   deadLetters,
+		CommitTimeout: 20 * time.Millisecond,
+	})
+	t.Cleanup(func() {
+		p.Close(context.Background())
+	})
+	time.Sleep(10 * time.Millisecond)
+	return p
+}
+
+func waitJob(t *testing.T, job *Job) error {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	err := job.Wait(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("job is not finished")
+	}
+	return err
+}
+
+func TestJobRetryUntilSuccess(t *testing.T) {
+	letters := NewDeadLetterQueue(10)
+	p := newRetryPool(t, letters)
+
+	var attempts int32
+	job := p.PushPriority(context.Background(), "", func(ctx context.Context) error {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			return errors.New("redis is down")
+		}
+		return nil
+	})
+	job.Commit()
+
+	if err := waitJob(t, job); err != nil {
+		t.Fatalf("got %v want success on last attempt", err)
+	}
+	select {
+	case <-job.Done():
+	default:
+		t.Error("done is not closed")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("attempts got %d want 3", got)
+	}
+	if len(letters.List()) != 0 {
+		t.Errorf("succeeded job is dead letter, %+v", letters.List())
+	}
+}
+
+func TestJobDeadLetterReplay(t *testing.T) {
+	letters := NewDeadLetterQueue(10)
+	p := newRetryPool(t, letters)
+
+	var healthy atomic.Bool
+	handlerErr := errors.New("redis is down")
+	job := p.PushPriority(context.Background(), "", func(ctx context.Context) error {
+		if healthy.Load() {
+			return nil
+		}
+		return handlerErr
+	})
+	job.Commit()
+
+	if err := waitJob(t, job); !errors.Is(err, handlerErr) {
+		t.Fatalf("got %v want handler error", err)
+	}
+	list := letters.List()
+	if len(list) != 1 || list[0].Attempts != 3 || list[0].Error != handlerErr.Error() {
+		t.Fatalf("unexpected dead letters %+v", list)
+	}
+
+	healthy.Store(true)
+	replayed, err := letters.Replay(context.Background(), list[0].Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := waitJob(t, replayed); err != nil {
+		t.Errorf("replay got %v", err)
+	}
+	if len(letters.List()) != 0 {
+		t.Errorf("replayed letter is not removed, %+v", letters.List())
+	}
+	if _, err := letters.Replay(context.Background(), list[0].Id); !errors.Is(err, ErrDeadLetterNotFound) {
+		t.Errorf("got %v want ErrDeadLetterNotFound", err)
+	}
+}
+
+func TestJobWaitNotProcessed(t *testing.T) {
+	p := newRetryPool(t, NewDeadLetterQueue(10))
+
+	// never committed
+	expired := p.PushPriority(context.Background(), "", func(ctx context.Context) error { return nil })
+	if err := waitJob(t, expired); !errors.Is(err, ErrJobExpired) {
+		t.Errorf("got %v want ErrJobExpired", err)
+	}
+
+	p.Close(context.Background())
+	rejected := p.PushPriority(context.Background(), "", func(ctx context.Context) error { return nil })
+	if err := waitJob(t, rejected); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("got %v want ErrPoolClosed", err)
+	}
+}
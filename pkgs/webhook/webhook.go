@@ -0,0 +1,195 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// receiver verify payload by
+// hex(hmac_sha256(secret, timestamp + "." + body)) == X-Signature without "sha256=" prefix
+// and reject timestamp too far from now (replay)
+
+var (
+	HeaderSignature  = "X-Signature"
+	HeaderTimestamp  = "X-Timestamp"
+	HeaderEventType  = "X-Event-Type"
+	HeaderDeliveryId = "X-Delivery-Id"
+
+	signaturePrefix = "sha256="
+)
+
+// ErrBlockedAddress is returned when receiver address is not public
+var ErrBlockedAddress = errors.New("receiver address is not public")
+
+// Sign return value of X-Signature header
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify check signature and that timestamp is within tolerance of now
+func Verify(secret string, signature string, timestamp int64, body []byte, now time.Time, tolerance time.Duration) error {
+	age := now.Sub(time.Unix(timestamp, 0))
+	if age > tolerance || age < -tolerance {
+		return fmt.Errorf("timestamp %d is out of tolerance %v", timestamp, tolerance)
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body))) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+// NewSecret return random secret of subscription
+func NewSecret() (string, error) {
+	b := make([]byte, 24)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Backoff is delay before attempt n+1 after n failed attempts
+// base * 2^(n-1), capped by max
+func Backoff(attempts int, base time.Duration, max time.Duration) time.Duration {
+	if attempts < 1 {
+		return base
+	}
+
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}
+
+// Request is one delivery attempt
+type Request struct {
+	URL        string
+	Secret     string
+	EventType  string
+	DeliveryId uint32
+	Body       []byte
+	Now        time.Time
+}
+
+// Result of one attempt, StatusCode is 0 when no response
+type Result struct {
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// Delivered is true on any 2xx
+func (r *Result) Delivered() bool {
+	return r.Err == nil && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// PublicIP is false for loopback, private, link-local, multicast and unspecified addresses
+func PublicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil && ip4[0] == 0 {
+		// 0.0.0.0/8 is this network
+		return false
+	}
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+// Sender post signed payload, client timeout bound every attempt
+type Sender struct {
+	client *http.Client
+}
+
+// NewSender only dial public addresses, receiver url is chosen by user and is posted from inside network
+func NewSender(timeout time.Duration) *Sender {
+	return NewSenderAllowIP(timeout, PublicIP)
+}
+
+// NewSenderAllowIP is NewSender which dial addresses allowIP accept, e.g. receivers of tests on loopback
+func NewSenderAllowIP(timeout time.Duration, allowIP func(net.IP) bool) *Sender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		// address is checked after dns resolution on every dial, so dns rebinding can not reach internal address
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !allowIP(ip) {
+				return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+			}
+			return nil
+		},
+	}
+	return &Sender{
+		client: &http.Client{
+			Timeout: timeout,
+			// no proxy from environment, proxy would dial the address instead of dialer
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				ForceAttemptHTTP2:   true,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+				TLSHandshakeTimeout: timeout,
+			},
+			// redirect is not followed, receiver must answer on subscribed url
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *Sender) Send(ctx context.Context, req *Request) *Result {
+	start := time.Now()
+	result := &Result{}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	timestamp := req.Now.Unix()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, timestamp, req.Body))
+	httpReq.Header.Set(HeaderEventType, req.EventType)
+	httpReq.Header.Set(HeaderDeliveryId, strconv.FormatUint(uint64(req.DeliveryId), 10))
+
+	resp, err := s.client.Do(httpReq)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer resp.Body.Close()
+	// drain so connection is reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	result.StatusCode = resp.StatusCode
+	if !result.Delivered() {
+		result.Err = fmt.Errorf("receiver answered %d", resp.StatusCode)
+	}
+	return result
+}
@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"type":"transaction.created"}`)
+	signature := Sign("secret", now.Unix(), body)
+
+	if err := Verify("secret", signature, now.Unix(), body, now, 5*time.Minute); err != nil {
+		t.Fatalf("expects valid signature, got %v", err)
+	}
+	if err := Verify("other", signature, now.Unix(), body, now, 5*time.Minute); err == nil {
+		t.Error("expects mismatch with other secret")
+	}
+	if err := Verify("secret", signature, now.Unix(), []byte(`{}`), now, 5*time.Minute); err == nil {
+		t.Error("expects mismatch with other body")
+	}
+	if err := Verify("secret", signature, now.Unix(), body, now.Add(10*time.Minute), 5*time.Minute); err == nil {
+		t.Error("expects replay rejected out of tolerance")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	cases := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, time.Second},
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{10, time.Minute},
+	}
+	for _, c := range cases {
+		if got := Backoff(c.attempts, time.Second, time.Minute); got != c.want {
+			t.Errorf("Backoff(%d): expects %v, got %v", c.attempts, c.want, got)
+		}
+	}
+}
+
+func TestSenderSignedDelivery(t *testing.T) {
+	now := time.Now()
+	body := []byte(`{"type":"balance.updated"}`)
+	received := make(chan error, 1)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		err := Verify("secret", r.Header.Get(HeaderSignature), timestamp, payload, time.Now(), time.Minute)
+		if err == nil && (r.Header.Get(HeaderEventType) != "balance.updated" || r.Header.Get(HeaderDeliveryId) != "7") {
+			err = io.ErrUnexpectedEOF
+		}
+		received <- err
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	result := NewSenderAllowIP(time.Second, allowAll).Send(context.Background(), &Request{
+		URL:        receiver.URL,
+		Secret:     "secret",
+		EventType:  "balance.updated",
+		DeliveryId: 7,
+		Body:       body,
+		Now:        now,
+	})
+	if !result.Delivered() || result.StatusCode != http.StatusNoContent {
+		t.Fatalf("expects delivered, got %+v", result)
+	}
+	if err := <-received; err != nil {
+		t.Fatalf("receiver rejected delivery: %v", err)
+	}
+}
+
+func TestSenderFailure(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	sender := NewSenderAllowIP(time.Second, allowAll)
+	result := sender.Send(context.Background(), &Request{URL: receiver.URL, Secret: "secret", Body: []byte(`{}`), Now: time.Now()})
+	if result.Delivered() || result.StatusCode != http.StatusInternalServerError || result.Err == nil {
+		t.Fatalf("expects failed with 500, got %+v", result)
+	}
+
+	receiver.Close()
+	result = sender.Send(context.Background(), &Request{URL: receiver.URL, Secret: "secret", Body: []byte(`{}`), Now: time.Now()})
+	if result.Delivered() || result.StatusCode != 0 || result.Err == nil {
+		t.Fatalf("expects connection error, got %+v", result)
+	}
+}
+
+// receivers of tests listen on loopback
+func allowAll(ip net.IP) bool {
+	return true
+}
+
+func TestSenderBlockNotPublicAddress(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("loopback receiver must not be reached")
+	}))
+	defer receiver.Close()
+
+	result := NewSender(time.Second).Send(context.Background(), &Request{URL: receiver.URL, Secret: "secret", Body: []byte(`{}`), Now: time.Now()})
+	if result.Delivered() || !errors.Is(result.Err, ErrBlockedAddress) {
+		t.Fatalf("expects ErrBlockedAddress, got %+v", result)
+	}
+
+	for _, blocked := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "0.0.0.0", "::1", "fe80::1", "fd00::1"} {
+		if PublicIP(net.ParseIP(blocked)) {
+			t.Errorf("expects %s not public", blocked)
+		}
+	}
+	if !PublicIP(net.ParseIP("93.184.216.34")) {
+		t.Error("expects 93.184.216.34 public")
+	}
+}